@@ -2,6 +2,7 @@ package blockchain
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/sonm-io/core/blockchain/tsc"
 	token_api "github.com/sonm-io/core/blockchain/tsc/api"
 	pb "github.com/sonm-io/core/proto"
@@ -75,8 +77,23 @@ type Tokener interface {
 type Blockchainer interface {
 	Dealer
 	Tokener
+
+	// WaitMined blocks until the given transaction is mined, returning its
+	// receipt
+	WaitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, error)
+
+	// SubscribeDealClosed returns a channel receiving ids of deals closed
+	// from now on by given hub/client addresses, empty address means any.
+	// The channel is closed when the context is done or the subscription
+	// breaks. ErrSubscriptionsUnsupported is returned if the Ethereum
+	// endpoint does not support subscriptions, for example over HTTP.
+	SubscribeDealClosed(ctx context.Context, hubAddr string, clientAddr string) (<-chan *big.Int, error)
 }
 
+// ErrSubscriptionsUnsupported is returned when the Ethereum endpoint is
+// unable to push events, so callers should poll instead.
+var ErrSubscriptionsUnsupported = errors.New("event subscriptions are not supported by the Ethereum endpoint")
+
 func initEthClient(ethEndpoint *string) (*ethclient.Client, error) {
 	var endpoint string
 	if ethEndpoint == nil {
@@ -139,6 +156,10 @@ func NewAPI(ethEndpoint *string, gasPrice *int64) (Blockchainer, error) {
 	return bch, nil
 }
 
+func (bch *api) WaitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	return bind.WaitMined(ctx, bch.client, tx)
+}
+
 // ----------------
 // Deals appearance
 // ----------------
@@ -317,6 +338,64 @@ func (bch *api) GetAcceptedDeal(hubAddr string, clientAddr string) ([]*big.Int,
 }
 
 func (bch *api) GetClosedDeal(hubAddr string, clientAddr string) ([]*big.Int, error) {
+	logs, err := bch.client.FilterLogs(context.Background(), dealClosedQuery(hubAddr))
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*big.Int
+
+	for _, l := range logs {
+		if isClientDealLog(l, clientAddr) {
+			out = append(out, l.Topics[3].Big())
+		}
+	}
+
+	return out, nil
+}
+
+func (bch *api) SubscribeDealClosed(ctx context.Context, hubAddr string, clientAddr string) (<-chan *big.Int, error) {
+	logs := make(chan types.Log)
+	sub, err := bch.client.SubscribeFilterLogs(ctx, dealClosedQuery(hubAddr), logs)
+	if err == rpc.ErrNotificationsUnsupported {
+		return nil, ErrSubscriptionsUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *big.Int)
+	go func() {
+		defer close(out)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case l := <-logs:
+				// reverted logs do not close deals
+				if l.Removed || !isClientDealLog(l, clientAddr) {
+					continue
+				}
+
+				select {
+				case out <- l.Topics[3].Big():
+				case <-ctx.Done():
+					return
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// dealClosedQuery makes a query for DealClosed events of the given hub,
+// empty address means any hub.
+func dealClosedQuery(hubAddr string) ethereum.FilterQuery {
 	var topics [][]common.Hash
 
 	// precompile EventName topics
@@ -324,33 +403,22 @@ func (bch *api) GetClosedDeal(hubAddr string, clientAddr string) ([]*big.Int, er
 	topics = append(topics, eventTopic)
 
 	// add filter topic by hub address
-	// filtering by client address implemented below
+	// filtering by client address implemented by isClientDealLog
 	if hubAddr != "" {
 		var addrTopic = []common.Hash{common.HexToHash(common.HexToAddress(hubAddr).String())}
 		topics = append(topics, addrTopic)
 	}
 
-	logs, err := bch.client.FilterLogs(context.Background(), ethereum.FilterQuery{
+	return ethereum.FilterQuery{
 		Addresses: []common.Address{common.HexToAddress(tsc.DealsAddress)},
 		Topics:    topics,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	var out []*big.Int
-
-	for _, l := range logs {
-		// filtering by client address
-		if clientAddr != "" {
-			if l.Topics[2] != common.HexToHash(clientAddr) {
-				continue
-			}
-		}
-		out = append(out, l.Topics[3].Big())
 	}
+}
 
-	return out, nil
+// isClientDealLog tells whether the deal event log belongs to the given
+// client, empty address means any client.
+func isClientDealLog(l types.Log, clientAddr string) bool {
+	return clientAddr == "" || l.Topics[2] == common.HexToHash(clientAddr)
 }
 
 func (bch *api) GetDeals(address string) ([]*big.Int, error) {
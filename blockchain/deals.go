@@ -0,0 +1,105 @@
+package blockchain
+
+import (
+	"math/big"
+
+	pb "github.com/sonm-io/core/proto"
+)
+
+// GetDealIDs returns ids of deals with the given status between the hub and
+// the client, an empty address matching anyone. Any status other than
+// pending, accepted or closed matches all of them.
+func GetDealIDs(bc Dealer, status pb.DealStatus, hubAddr, clientAddr string) ([]*big.Int, error) {
+	switch status {
+	case pb.DealStatus_PENDING:
+		return bc.GetOpenedDeal(hubAddr, clientAddr)
+	case pb.DealStatus_ACCEPTED:
+		return bc.GetAcceptedDeal(hubAddr, clientAddr)
+	case pb.DealStatus_CLOSED:
+		return bc.GetClosedDeal(hubAddr, clientAddr)
+	}
+
+	var ids []*big.Int
+	for _, st := range []pb.DealStatus{pb.DealStatus_PENDING, pb.DealStatus_ACCEPTED, pb.DealStatus_CLOSED} {
+		found, err := GetDealIDs(bc, st, hubAddr, clientAddr)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, found...)
+	}
+
+	return ids, nil
+}
+
+// GetMyDeals returns deals with the given status where the address acts
+// either as a supplier or as a buyer.
+func GetMyDeals(bc Dealer, status pb.DealStatus, addr string) ([]*pb.Deal, error) {
+	asSupplier, err := GetDealIDs(bc, status, addr, "")
+	if err != nil {
+		return nil, err
+	}
+
+	asBuyer, err := GetDealIDs(bc, status, "", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// the same deal may appear in both lists when trading with ourselves,
+	// so deduplicate by id
+	seen := make(map[string]bool)
+	deals := make([]*pb.Deal, 0, len(asSupplier)+len(asBuyer))
+	for _, id := range append(asSupplier, asBuyer...) {
+		if seen[id.String()] {
+			continue
+		}
+		seen[id.String()] = true
+
+		deal, err := bc.GetDealInfo(id)
+		if err != nil {
+			return nil, err
+		}
+
+		deals = append(deals, deal)
+	}
+
+	return deals, nil
+}
+
+// FindDealBySpecHash returns a pending or accepted deal between any of the
+// own addresses and the counterparty with the given specification hash, or
+// nil if there is none. Empty addr means any counterparty.
+func FindDealBySpecHash(bc Dealer, own []string, addr, hash string) (*pb.Deal, error) {
+	seen := make(map[string]bool)
+	for _, ownAddr := range own {
+		for _, status := range []pb.DealStatus{pb.DealStatus_PENDING, pb.DealStatus_ACCEPTED} {
+			// we may act either as a supplier or as a buyer
+			asSupplier, err := GetDealIDs(bc, status, ownAddr, addr)
+			if err != nil {
+				return nil, err
+			}
+
+			asBuyer, err := GetDealIDs(bc, status, addr, ownAddr)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, id := range append(asSupplier, asBuyer...) {
+				if seen[id.String()] {
+					continue
+				}
+				seen[id.String()] = true
+
+				deal, err := bc.GetDealInfo(id)
+				if err != nil {
+					return nil, err
+				}
+
+				if deal.GetStatus() == status && deal.GetSpecificationHash() == hash {
+					return deal, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
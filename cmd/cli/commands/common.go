@@ -1,11 +1,16 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/sonm-io/core/accounts"
@@ -15,6 +20,17 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
+// exitCodeNetwork is the exit code of commands failed because of network
+// problems, like running out of time waiting for replies.
+const exitCodeNetwork = 3
+
+// Values of the "--color" flag.
+const (
+	colorModeAuto   = "auto"
+	colorModeAlways = "always"
+	colorModeNever  = "never"
+)
+
 const (
 	// log flag names
 	logTypeFlag       = "type"
@@ -32,7 +48,29 @@ var (
 	// flags var
 	nodeAddressFlag string
 	outputModeFlag  string
+	colorModeFlag   = colorModeAuto
+	timezoneFlag    = "utc"
 	timeoutFlag     = 60 * time.Second
+	// bytePrecisionFlag is the number of decimal places in human-readable
+	// byte sizes
+	bytePrecisionFlag = 1
+	// envelopeFlag wraps JSON output into an envelope with metadata
+	envelopeFlag bool
+	// quietFlag makes list commands print bare identifiers, taking
+	// precedence over the output mode.
+	quietFlag bool
+	// priceUnitFlag is the unit to print prices in, empty for raw amounts.
+	priceUnitFlag string
+	// formatFlag is a Go template to print details with instead of the
+	// output mode.
+	formatFlag string
+	// noTrailingCRFlag makes printers end lines with LF instead of CRLF.
+	noTrailingCRFlag bool
+	// prettyFlag makes JSON output indented.
+	prettyFlag bool
+	// timeLocation is the time zone to print timestamps in, set from the
+	// "--timezone" flag.
+	timeLocation = time.UTC
 
 	// logging flag vars
 	logType       string
@@ -47,26 +85,121 @@ var (
 	sessionKey *ecdsa.PrivateKey = nil
 	creds      credentials.TransportCredentials
 
+	// commandCtx bounds the whole command by the "--timeout" flag, all RPC
+	// contexts are derived from it. It is built once before the command
+	// runs, see newCommandContext.
+	commandCtx = context.Background()
+	// commandCancel releases commandCtx resources after the command has
+	// finished.
+	commandCancel context.CancelFunc = func() {}
+	// commandTimeouts let commands waiting for something on purpose, like
+	// a deal, extend the "--timeout" flag value they are given. Zero means
+	// that the command is not bounded, running until interrupted.
+	commandTimeouts = map[*cobra.Command]func(timeout time.Duration) time.Duration{}
+	// osExit is overridden in tests.
+	osExit = os.Exit
+
 	// errors
 	errCannotParsePropsFile = errors.New("cannot parse props file")
+	errCommandTimedOut      = errors.New("command timed out")
+	errSpecHashRequired     = errors.New("--spec-hash is required")
+	errUnknownColorMode     = errors.New("--color must be one of: auto, always, never")
 )
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&nodeAddressFlag, "node", "127.0.0.1:9999", "node addr")
-	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 60*time.Second, "Connection timeout")
-	rootCmd.PersistentFlags().StringVar(&outputModeFlag, "out", "", "Output mode: simple or json")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 60*time.Second, "Timeout of the whole command, zero for no limit")
+	rootCmd.PersistentFlags().StringVar(&outputModeFlag, "out", "", "Output mode: simple, table, csv or json")
+	rootCmd.PersistentFlags().IntVar(&bytePrecisionFlag, "byte-precision", 1, "Decimal places in human-readable sizes")
+	rootCmd.PersistentFlags().StringVar(&colorModeFlag, "color", colorModeAuto, "Colorize statuses: auto, always or never")
+	rootCmd.PersistentFlags().StringVar(&timezoneFlag, "timezone", "utc", "Time zone to print timestamps in: local, utc or an IANA name, e.g. Europe/Berlin")
+	rootCmd.PersistentFlags().BoolVar(&envelopeFlag, "envelope", false, "Wrap JSON output into an envelope with metadata")
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Print only identifiers of listed items, one per line")
+	rootCmd.PersistentFlags().StringVar(&priceUnitFlag, "price-unit", "", "Unit to print prices in: wei, gwei or ether, raw amounts by default")
+	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", "", "Print deal, order and task details using the given Go template, e.g. '{{.Id}} {{.Price}}'")
+	rootCmd.PersistentFlags().BoolVar(&noTrailingCRFlag, "no-trailing-cr", false, "End output lines with LF instead of CRLF")
+	rootCmd.PersistentFlags().BoolVar(&prettyFlag, "pretty", false, "Indent JSON output")
 
 	rootCmd.AddCommand(hubRootCmd, marketRootCmd, nodeDealsRootCmd, taskRootCmd)
-	rootCmd.AddCommand(loginCmd, approveTokenCmd, versionCmd)
+	rootCmd.AddCommand(loginCmd, approveTokenCmd, versionCmd, renderCmd, locatorRootCmd)
 }
 
 // Root configure and return root command
 func Root(c config.Config) *cobra.Command {
 	cfg = c
 	rootCmd.SetOutput(os.Stdout)
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		// Set first, so errors of other flags are printed the same way.
+		if noTrailingCRFlag {
+			rootCmd.SetOutput(newLFWriter(rootCmd.OutOrStderr()))
+		}
+
+		switch colorModeFlag {
+		case colorModeAuto, colorModeAlways, colorModeNever:
+		default:
+			showError(cmd, "Invalid flag", errUnknownColorMode)
+			osExit(1)
+		}
+
+		if err := checkPriceUnit(priceUnitFlag); err != nil {
+			showError(cmd, "Invalid flag", err)
+			osExit(1)
+		}
+
+		location, err := parseTimezone(timezoneFlag)
+		if err != nil {
+			showError(cmd, "Invalid flag", err)
+			osExit(1)
+		}
+		timeLocation = location
+
+		commandCtx, commandCancel = newCommandContext(cmd)
+	}
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		commandCancel()
+	}
 	return rootCmd
 }
 
+// newCommandContext builds the context bounding the whole command by the
+// "--timeout" flag, unless the command overrides it in commandTimeouts.
+func newCommandContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	timeout := timeoutFlag
+	if override, ok := commandTimeouts[cmd]; ok {
+		timeout = override(timeout)
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// parseTimezone parses the "--timezone" flag value.
+func parseTimezone(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "utc":
+		return time.UTC, nil
+	case "local":
+		return time.Local, nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: %v", name, err)
+	}
+
+	return location, nil
+}
+
+// isCommandTimeout reports whether the error is caused by the command
+// running out of time, which may be reported either by the context itself
+// or by gRPC as a status.
+func isCommandTimeout(err error) bool {
+	return err != nil && commandCtx.Err() == context.DeadlineExceeded
+}
+
 // commandError allow to present any internal error as JSON
 type commandError struct {
 	rawErr  error
@@ -88,6 +221,11 @@ func newCommandError(message string, err error) *commandError {
 }
 
 func showError(cmd *cobra.Command, message string, err error) {
+	if isCommandTimeout(err) {
+		message, err = errCommandTimedOut.Error(), nil
+		defer osExit(exitCodeNetwork)
+	}
+
 	if isSimpleFormat() {
 		showErrorInSimple(cmd, message, err)
 	} else {
@@ -105,6 +243,14 @@ func showErrorInSimple(cmd *cobra.Command, message string, err error) {
 
 func showErrorInJSON(cmd *cobra.Command, message string, err error) {
 	jerr := newCommandError(message, err)
+	if envelopeFlag {
+		if jerr.rawErr != nil {
+			jerr.Error = jerr.rawErr.Error()
+		}
+		showJSON(cmd, "error", jerr)
+		return
+	}
+
 	cmd.Println(jerr.ToJSONString())
 }
 
@@ -122,6 +268,11 @@ func showOkSimple(cmd *cobra.Command) {
 
 func showOkJson(cmd *cobra.Command) {
 	r := map[string]string{"status": "OK"}
+	if envelopeFlag {
+		showJSON(cmd, "ok", r)
+		return
+	}
+
 	j, _ := json.Marshal(r)
 	cmd.Println(string(j))
 }
@@ -138,6 +289,33 @@ func isSimpleFormat() bool {
 	return true
 }
 
+// isTableFormat tells whether lists should be printed as aligned tables.
+// Table mode is a flavor of the simple one, so printers without a table
+// layout keep printing in simple format.
+func isTableFormat() bool {
+	if outputModeFlag != "" {
+		return outputModeFlag == config.OutputModeTable
+	}
+
+	return cfg.OutputFormat() == config.OutputModeTable
+}
+
+// isCSVFormat tells whether lists should be exported as CSV. Like the
+// table mode, it affects only printers of lists of records.
+func isCSVFormat() bool {
+	if outputModeFlag != "" {
+		return outputModeFlag == config.OutputModeCSV
+	}
+
+	return cfg.OutputFormat() == config.OutputModeCSV
+}
+
+// newTable returns a writer aligning tab-separated columns to the longest
+// value in each of them. It must be flushed after writing all the rows.
+func newTable(cmd *cobra.Command) *tabwriter.Writer {
+	return tabwriter.NewWriter(cmd.OutOrStderr(), 0, 0, 2, ' ', 0)
+}
+
 // loadKeyStoreWrapper implemented to match cobra.Command.PreRun signature.
 //
 // Function loads and opens keystore. Also, storing opened key in "sessionKey" var
@@ -171,7 +349,68 @@ func loadKeyStoreWrapper(cmd *cobra.Command, _ []string) {
 	creds = util.NewTLS(TLSConfig)
 }
 
-func showJSON(cmd *cobra.Command, s interface{}) {
+type envelopeMeta struct {
+	Command    string `json:"command"`
+	Timestamp  string `json:"ts"`
+	CLIVersion string `json:"cli_version"`
+}
+
+// jsonSchemaVersion is the version of the JSON output reported in
+// envelopes. It must be bumped whenever the shape of any document changes
+// incompatibly, so consumers can detect that.
+const jsonSchemaVersion = 1
+
+// jsonEnvelope describes the JSON output with the "--envelope" flag set.
+type jsonEnvelope struct {
+	Version int `json:"version"`
+	// Type tells what kind of object the data is, like "deal" or
+	// "task_status".
+	Type string       `json:"type"`
+	Meta envelopeMeta `json:"meta"`
+	Data interface{}  `json:"data"`
+}
+
+// showCSV prints the header and the rows as RFC 4180 CSV, quoting fields
+// when necessary.
+func showCSV(cmd *cobra.Command, header []string, rows [][]string) {
+	w := csv.NewWriter(cmd.OutOrStderr())
+	w.UseCRLF = true
+	w.Write(header)
+	w.WriteAll(rows)
+}
+
+// showIDs prints identifiers one per line and nothing else, so that the
+// output of list commands can be passed to xargs. Lines end with a bare LF,
+// otherwise xargs would keep CR as a part of the last argument.
+func showIDs(cmd *cobra.Command, ids []string) {
+	for _, id := range ids {
+		cmd.Printf("%s\n", id)
+	}
+}
+
+// showJSON prints the object as a single line of JSON. The type names the
+// kind of the object in the envelope, if it is enabled.
+func showJSON(cmd *cobra.Command, docType string, s interface{}) {
+	if envelopeFlag {
+		s = &jsonEnvelope{
+			Version: jsonSchemaVersion,
+			Type:    docType,
+			Meta: envelopeMeta{
+				Command:    cmd.CommandPath(),
+				Timestamp:  time.Now().UTC().Format(time.RFC3339),
+				CLIVersion: version,
+			},
+			Data: s,
+		}
+	}
+
+	if prettyFlag {
+		b, _ := json.MarshalIndent(s, "", "  ")
+		// Lines end the same way as in other output.
+		cmd.Printf("%s\r\n", bytes.Replace(b, []byte("\n"), []byte("\r\n"), -1))
+		return
+	}
+
 	b, _ := json.Marshal(s)
 	cmd.Printf("%s\r\n", b)
 }
@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShowJSONEnvelope(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+	envelopeFlag = true
+	defer func() { envelopeFlag = false }()
+
+	version = "1.2.3"
+	printVersion(rootCmd, version)
+
+	v := struct {
+		Version int               `json:"version"`
+		Type    string            `json:"type"`
+		Meta    map[string]string `json:"meta"`
+		Data    map[string]string `json:"data"`
+	}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+
+	assert.Equal(t, jsonSchemaVersion, v.Version)
+	assert.Equal(t, "version", v.Type)
+	assert.Equal(t, "sonm", v.Meta["command"])
+	assert.Equal(t, "1.2.3", v.Meta["cli_version"])
+	ts, err := time.Parse(time.RFC3339, v.Meta["ts"])
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, ts.Location())
+	assert.Equal(t, map[string]string{"version": "1.2.3"}, v.Data)
+}
+
+func TestShowErrorEnvelope(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+	envelopeFlag = true
+	defer func() { envelopeFlag = false }()
+
+	showError(rootCmd, "Cannot get deal", errors.New("deal not found"))
+
+	v := struct {
+		Type string            `json:"type"`
+		Meta map[string]string `json:"meta"`
+		Data map[string]string `json:"data"`
+	}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+
+	assert.Equal(t, "error", v.Type)
+	assert.Equal(t, "sonm", v.Meta["command"])
+	assert.Equal(t, "Cannot get deal", v.Data["message"])
+	assert.Equal(t, "deal not found", v.Data["error"])
+}
+
+func TestShowJSONNoEnvelope(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+	envelopeFlag = false
+
+	showJSON(rootCmd, "ok", map[string]string{"status": "OK"})
+	assert.Equal(t, "{\"status\":\"OK\"}\r\n", buf.String())
+}
+
+func TestShowJSONPretty(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+	prettyFlag = true
+	defer func() { prettyFlag = false }()
+
+	showJSON(rootCmd, "deal", map[string]interface{}{"id": "42", "price": "1000"})
+	assert.Equal(t, "{\r\n  \"id\": \"42\",\r\n  \"price\": \"1000\"\r\n}\r\n", buf.String())
+}
+
+// hangingDealsClient never replies until the request context is done.
+type hangingDealsClient struct {
+	pb.DealManagementClient
+}
+
+func (c *hangingDealsClient) Status(ctx context.Context, in *pb.ID, opts ...grpc.CallOption) (*pb.Deal, error) {
+	<-ctx.Done()
+	return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+}
+
+func TestCommandTimeout(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	prevCtx, prevExit := commandCtx, osExit
+	defer func() { commandCtx, osExit = prevCtx, prevExit }()
+
+	var cancel context.CancelFunc
+	commandCtx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	exitCode := -1
+	osExit = func(code int) { exitCode = code }
+
+	itr := &dealsInteractor{timeout: time.Minute, deals: &hangingDealsClient{}}
+	_, err := itr.Status("1")
+	require.Error(t, err)
+
+	showError(rootCmd, "Cannot get deal deal", err)
+	assert.Equal(t, "[ERR] command timed out\r\n", buf.String())
+	assert.Equal(t, exitCodeNetwork, exitCode)
+}
+
+func TestNewCommandContext(t *testing.T) {
+	defer func(timeout time.Duration) { timeoutFlag = timeout }(timeoutFlag)
+	timeoutFlag = time.Minute
+
+	ctx, cancel := newCommandContext(versionCmd)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	// Waiting for a deal extends the timeout.
+	defer func() { orderCreateWait = 0 }()
+	orderCreateWait = time.Hour
+
+	ctx, cancel = newCommandContext(marketCreteCmd)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Hour+time.Minute), deadline, time.Second)
+
+	// So does repeating the search.
+	defer func(timeout time.Duration) { orderSearchRetry, orderSearchRetryTimeout = 0, timeout }(orderSearchRetryTimeout)
+	orderSearchRetry = time.Second
+	orderSearchRetryTimeout = time.Hour
+
+	ctx, cancel = newCommandContext(marketSearchCmd)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Hour+time.Minute), deadline, time.Second)
+
+	// Watching is not bounded at all.
+	defer func() { taskStatusWatch = false }()
+	taskStatusWatch = true
+
+	ctx, cancel = newCommandContext(taskStatusCmd)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+
+	timeoutFlag = 0
+	ctx, cancel = newCommandContext(versionCmd)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+}
+
+func TestShowErrorNotTimeout(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	showError(rootCmd, "Cannot get deal", errors.New("deal not found"))
+	assert.Equal(t, "[ERR] Cannot get deal: deal not found\r\n", buf.String())
+}
+
+func TestParseTimezone(t *testing.T) {
+	location, err := parseTimezone("UTC")
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, location)
+
+	location, err = parseTimezone("local")
+	require.NoError(t, err)
+	assert.Equal(t, time.Local, location)
+
+	location, err = parseTimezone("Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", location.String())
+
+	_, err = parseTimezone("Mars/Olympus_Mons")
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/sonm-io/core/util"
+)
+
+// Deal ids may be suffixed by a short checksum, like "1234-5e", to catch
+// typos in pasted ids locally instead of getting "deal not found" from the
+// chain. Plain numeric ids are accepted as well.
+
+var errDealIDChecksumMismatch = errors.New("deal id checksum mismatch, probably there is a typo")
+
+func dealIDChecksum(id string) string {
+	return fmt.Sprintf("%02x", crc32.ChecksumIEEE([]byte(id))&0xff)
+}
+
+// formatDealID appends a checksum to the given deal id.
+func formatDealID(id string) string {
+	return id + "-" + dealIDChecksum(id)
+}
+
+// parseDealID verifies the checksum of the deal id if there is any and
+// returns the plain numeric id.
+func parseDealID(s string) (string, error) {
+	id := s
+	if pos := strings.IndexByte(s, '-'); pos >= 0 {
+		id = s[:pos]
+		if s[pos+1:] != dealIDChecksum(id) {
+			return "", errDealIDChecksumMismatch
+		}
+	}
+
+	if _, err := util.ParseBigInt(id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
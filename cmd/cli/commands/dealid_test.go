@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDealIDChecksummed(t *testing.T) {
+	id, err := parseDealID(formatDealID("1234"))
+	require.NoError(t, err)
+	assert.Equal(t, "1234", id)
+}
+
+func TestParseDealIDPlain(t *testing.T) {
+	id, err := parseDealID("1234")
+	require.NoError(t, err)
+	assert.Equal(t, "1234", id)
+
+	_, err = parseDealID("12a4")
+	assert.Error(t, err)
+}
+
+func TestParseDealIDCorrupted(t *testing.T) {
+	checksum := dealIDChecksum("1234")
+
+	// Dropped, added and replaced digits.
+	for _, id := range []string{"124", "12344", "1235", "1334"} {
+		_, err := parseDealID(id + "-" + checksum)
+		assert.Equal(t, errDealIDChecksumMismatch, err, id)
+	}
+}
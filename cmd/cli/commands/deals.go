@@ -6,25 +6,42 @@ import (
 	"strings"
 
 	pb "github.com/sonm-io/core/proto"
-	"github.com/sonm-io/core/util"
 	"github.com/spf13/cobra"
 )
 
 var (
 	dealListFlagFrom   string
 	dealListFlagStatus string
+	dealListFlagFilter string
+	dealListFlagLimit  uint64
+	dealListFlagOffset uint64
+
+	dealFindFlagSpecHash     string
+	dealFindFlagCounterparty string
 )
 
 func init() {
 	dealsListCmd.PersistentFlags().StringVar(&dealListFlagFrom, "from", "",
-		"Transactions author, using self address if empty")
+		"Transactions author, showing deals where you are either buyer or supplier if empty")
 	dealsListCmd.PersistentFlags().StringVar(&dealListFlagStatus, "status", "ANY",
 		"Transaction status (ANY, PENDING, ACCEPTED, CLOSED)")
+	dealsListCmd.PersistentFlags().StringVar(&dealListFlagFilter, "filter", "",
+		"Filter expression, e.g. 'price < 1000 && status != \"CLOSED\"'")
+	dealsListCmd.PersistentFlags().Uint64Var(&dealListFlagLimit, "limit", 0,
+		"Deals count to show, all if zero")
+	dealsListCmd.PersistentFlags().Uint64Var(&dealListFlagOffset, "offset", 0,
+		"Deals count to skip")
+
+	dealsFindCmd.PersistentFlags().StringVar(&dealFindFlagSpecHash, "spec-hash", "",
+		"Specification hash of the deal")
+	dealsFindCmd.PersistentFlags().StringVar(&dealFindFlagCounterparty, "counterparty", "",
+		"Counterparty address, any if empty")
 
 	nodeDealsRootCmd.AddCommand(
 		dealsListCmd,
 		dealsStatusCmd,
 		dealsFinishCmd,
+		dealsFindCmd,
 	)
 }
 
@@ -46,18 +63,23 @@ var dealsListCmd = &cobra.Command{
 		}
 
 		status := convertTransactionStatus(dealListFlagStatus)
-		from := dealListFlagFrom
-		if from == "" {
-			from = util.PubKeyToAddr(sessionKey.PublicKey).Hex()
+		// empty author makes the Node look up deals for its own address
+		// in both buyer and supplier roles
+		deals, err := itr.List(dealListFlagFrom, status)
+		if err != nil {
+			showError(cmd, "Cannot get deals list", err)
+			os.Exit(1)
 		}
 
-		deals, err := itr.List(from, status)
+		deals, err = filterDeals(dealListFlagFilter, deals)
 		if err != nil {
-			showError(cmd, "Cannot get deals list", err)
+			showError(cmd, "Cannot apply filter", err)
 			os.Exit(1)
 		}
 
-		printDealsList(cmd, deals)
+		page := newPagination(len(deals), dealListFlagOffset, dealListFlagLimit)
+		from, to := page.bounds()
+		printDealsList(cmd, deals[from:to], page)
 	},
 }
 
@@ -73,10 +95,9 @@ var dealsStatusCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		id := args[0]
-		_, err = util.ParseBigInt(id)
+		id, err := parseDealID(args[0])
 		if err != nil {
-			showError(cmd, "Cannot convert arg to number", err)
+			showError(cmd, "Invalid deal id", err)
 			os.Exit(1)
 		}
 
@@ -102,10 +123,9 @@ var dealsFinishCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		id := args[0]
-		_, err = util.ParseBigInt(id)
+		id, err := parseDealID(args[0])
 		if err != nil {
-			showError(cmd, "Cannot convert arg to number", err)
+			showError(cmd, "Invalid deal id", err)
 			os.Exit(1)
 		}
 
@@ -118,6 +138,32 @@ var dealsFinishCmd = &cobra.Command{
 	},
 }
 
+var dealsFindCmd = &cobra.Command{
+	Use:    "find --spec-hash <hash>",
+	Short:  "Find pending or accepted deal by its specification hash",
+	PreRun: loadKeyStoreWrapper,
+	Run: func(cmd *cobra.Command, _ []string) {
+		if dealFindFlagSpecHash == "" {
+			showError(cmd, "Invalid parameter", errSpecHashRequired)
+			os.Exit(1)
+		}
+
+		itr, err := NewDealsInteractor(nodeAddressFlag, timeoutFlag)
+		if err != nil {
+			showError(cmd, "Cannot connect to Node", err)
+			os.Exit(1)
+		}
+
+		deal, err := itr.FindBySpecHash(dealFindFlagCounterparty, dealFindFlagSpecHash)
+		if err != nil {
+			showError(cmd, "Cannot find deal", err)
+			os.Exit(1)
+		}
+
+		printDealInfo(cmd, deal)
+	},
+}
+
 func convertTransactionStatus(s string) pb.DealStatus {
 	s = strings.ToUpper(s)
 	// looks stupid, but more convenient to use and easy to type
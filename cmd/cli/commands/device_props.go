@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Well-known device properties.
+const (
+	// DevicePropHashrate is a benchmark score of a GPU in hashes per second.
+	DevicePropHashrate = "hashrate"
+	// DevicePropCycles is a benchmark score of a CPU in cycles per second.
+	DevicePropCycles = "cycles"
+)
+
+// DeviceProps wraps device properties as transferred to and from the Hub,
+// rejecting values that can't be compared, i.e. NaN and infinities.
+type DeviceProps struct {
+	props map[string]float64
+}
+
+// NewDeviceProps validates the given properties.
+func NewDeviceProps(props map[string]float64) (*DeviceProps, error) {
+	p := &DeviceProps{props: make(map[string]float64, len(props))}
+	for key, value := range props {
+		if err := p.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// Get returns the value of the given property.
+func (p *DeviceProps) Get(key string) (float64, bool) {
+	value, ok := p.props[key]
+	return value, ok
+}
+
+// Set sets the value of the given property.
+func (p *DeviceProps) Set(key string, value float64) error {
+	if key == "" {
+		return errors.New("property name is empty")
+	}
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("property %q must be a finite number, got %v", key, value)
+	}
+
+	p.props[key] = value
+	return nil
+}
+
+// Hashrate returns the GPU benchmark score.
+func (p *DeviceProps) Hashrate() (float64, bool) {
+	return p.Get(DevicePropHashrate)
+}
+
+// SetHashrate sets the GPU benchmark score.
+func (p *DeviceProps) SetHashrate(value float64) error {
+	return p.Set(DevicePropHashrate, value)
+}
+
+// Cycles returns the CPU benchmark score.
+func (p *DeviceProps) Cycles() (float64, bool) {
+	return p.Get(DevicePropCycles)
+}
+
+// SetCycles sets the CPU benchmark score.
+func (p *DeviceProps) SetCycles(value float64) error {
+	return p.Set(DevicePropCycles, value)
+}
+
+// Keys returns property names in alphabetical order.
+func (p *DeviceProps) Keys() []string {
+	return sortedKeys(p.props)
+}
+
+// Map returns properties in the form accepted by the Hub.
+func (p *DeviceProps) Map() map[string]float64 {
+	return p.props
+}
+
+// SetFromString parses an assignment like "hashrate=30.5".
+func (p *DeviceProps) SetFromString(assignment string) error {
+	parts := strings.SplitN(assignment, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid property assignment %q, expected key=value", assignment)
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return fmt.Errorf("invalid value of property %q: %v", parts[0], err)
+	}
+
+	return p.Set(strings.TrimSpace(parts[0]), value)
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevicePropsSetGet(t *testing.T) {
+	props, err := NewDeviceProps(map[string]float64{"foo": 3.14})
+	require.NoError(t, err)
+
+	require.NoError(t, props.SetHashrate(30.5))
+	require.NoError(t, props.SetFromString("cycles = 42"))
+
+	hashrate, ok := props.Hashrate()
+	assert.True(t, ok)
+	assert.Equal(t, 30.5, hashrate)
+
+	cycles, ok := props.Cycles()
+	assert.True(t, ok)
+	assert.Equal(t, 42.0, cycles)
+
+	assert.Equal(t, map[string]float64{"foo": 3.14, "hashrate": 30.5, "cycles": 42}, props.Map())
+}
+
+func TestDevicePropsRejectsNonFinite(t *testing.T) {
+	_, err := NewDeviceProps(map[string]float64{"foo": math.NaN()})
+	assert.Error(t, err)
+
+	props, err := NewDeviceProps(nil)
+	require.NoError(t, err)
+
+	assert.Error(t, props.SetHashrate(math.Inf(1)))
+	assert.Error(t, props.SetHashrate(math.Inf(-1)))
+	assert.Error(t, props.SetFromString("hashrate=NaN"))
+	assert.Error(t, props.SetFromString("hashrate"))
+	assert.Error(t, props.SetFromString("=1"))
+
+	_, ok := props.Hashrate()
+	assert.False(t, ok)
+}
+
+func TestPrintDevicesPropsSorted(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	props, err := NewDeviceProps(map[string]float64{"hashrate": 30.5, "cycles": 42})
+	require.NoError(t, err)
+
+	printDevicesProps(rootCmd, props)
+	assert.Equal(t, "cycles = 42.000000\r\nhashrate = 30.500000\r\n", buf.String())
+}
@@ -6,11 +6,27 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	deviceTypeCPU = "cpu"
+	deviceTypeGPU = "gpu"
+)
+
+var (
+	devicePropsAssignments []string
+	deviceListTypeFlag     string
+)
+
 func init() {
+	deviceListCmd.Flags().StringVar(&deviceListTypeFlag, "device-type", "",
+		"Show only devices of the given type: cpu or gpu, all if empty")
+	deviceUpdatePropsCmd.Flags().StringArrayVar(&devicePropsAssignments, "set", nil,
+		"Property to set as key=value, may be repeated")
+
 	hubDeviceRootCmd.AddCommand(
 		deviceListCmd,
 		deviceGetPropsCmd,
 		deviceSetDevPropsCmd,
+		deviceUpdatePropsCmd,
 	)
 }
 
@@ -24,6 +40,13 @@ var deviceListCmd = &cobra.Command{
 	Short:  "Show Hub's aggregated hardware",
 	PreRun: loadKeyStoreWrapper,
 	Run: func(cmd *cobra.Command, args []string) {
+		switch deviceListTypeFlag {
+		case "", deviceTypeCPU, deviceTypeGPU:
+		default:
+			showError(cmd, "Invalid device type, must be cpu or gpu", nil)
+			os.Exit(1)
+		}
+
 		hub, err := NewHubInteractor(nodeAddressFlag, timeoutFlag)
 		if err != nil {
 			showError(cmd, "Cannot connect to Node", err)
@@ -36,7 +59,7 @@ var deviceListCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		printDeviceList(cmd, devices)
+		printDeviceList(cmd, devices, deviceListTypeFlag)
 	},
 }
 
@@ -59,7 +82,13 @@ var deviceGetPropsCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		printDevicesProps(cmd, reply.GetProperties())
+		props, err := NewDeviceProps(reply.GetProperties())
+		if err != nil {
+			showError(cmd, "Invalid device properties", err)
+			os.Exit(1)
+		}
+
+		printDevicesProps(cmd, props)
 	},
 }
 
@@ -77,13 +106,19 @@ var deviceSetDevPropsCmd = &cobra.Command{
 		workerID := args[0]
 		propsFile := args[1]
 
-		props, err := loadPropsFile(propsFile)
+		rawProps, err := loadPropsFile(propsFile)
 		if err != nil {
 			showError(cmd, errCannotParsePropsFile.Error(), nil)
 			os.Exit(1)
 		}
 
-		_, err = hub.SetDeviceProperties(workerID, props)
+		props, err := NewDeviceProps(rawProps)
+		if err != nil {
+			showError(cmd, "Invalid device properties", err)
+			os.Exit(1)
+		}
+
+		_, err = hub.SetDeviceProperties(workerID, props.Map())
 		if err != nil {
 			showError(cmd, "Cannot set device properties", err)
 			os.Exit(1)
@@ -91,3 +126,50 @@ var deviceSetDevPropsCmd = &cobra.Command{
 		showOk(cmd)
 	},
 }
+
+var deviceUpdatePropsCmd = &cobra.Command{
+	Use:    "update <dev_id> --set key=value",
+	Short:  "Update some Device properties, keeping others",
+	Args:   cobra.MinimumNArgs(1),
+	PreRun: loadKeyStoreWrapper,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(devicePropsAssignments) == 0 {
+			showError(cmd, "Nothing to update, use --set key=value", nil)
+			os.Exit(1)
+		}
+
+		hub, err := NewHubInteractor(nodeAddressFlag, timeoutFlag)
+		if err != nil {
+			showError(cmd, "Cannot connect to Node", err)
+			os.Exit(1)
+		}
+
+		devID := args[0]
+		reply, err := hub.GetDeviceProperties(devID)
+		if err != nil {
+			showError(cmd, "Cannot get device properties", err)
+			os.Exit(1)
+		}
+
+		props, err := NewDeviceProps(reply.GetProperties())
+		if err != nil {
+			showError(cmd, "Invalid device properties", err)
+			os.Exit(1)
+		}
+
+		for _, assignment := range devicePropsAssignments {
+			if err := props.SetFromString(assignment); err != nil {
+				showError(cmd, "Cannot update device properties", err)
+				os.Exit(1)
+			}
+		}
+
+		_, err = hub.SetDeviceProperties(devID, props.Map())
+		if err != nil {
+			showError(cmd, "Cannot set device properties", err)
+			os.Exit(1)
+		}
+
+		printDevicesProps(cmd, props)
+	},
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/sonm-io/core/proto"
+)
+
+// matchCheck is a result of comparing a single dimension of the requested
+// slot with the one of a candidate order.
+type matchCheck struct {
+	Dimension string `json:"dimension"`
+	OK        bool   `json:"ok"`
+	Want      string `json:"want"`
+	Have      string `json:"have"`
+}
+
+// matchExplanation describes why an order matches the requested slot, or
+// why it doesn't.
+type matchExplanation struct {
+	Order  *pb.Order     `json:"order"`
+	Checks []*matchCheck `json:"checks"`
+}
+
+func (m *matchExplanation) Matched() bool {
+	for _, check := range m.Checks {
+		if !check.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns only failed checks.
+func (m *matchExplanation) Failed() []*matchCheck {
+	var failed []*matchCheck
+	for _, check := range m.Checks {
+		if !check.OK {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// explainMatch compares the requested slot with the slot of the given
+// order dimension by dimension.
+//
+// ASK orders offer resources, so they must provide at least what is
+// requested. BID orders require resources, so they must not want more than
+// the requested slot has.
+func explainMatch(slot *pb.Slot, order *pb.Order) *matchExplanation {
+	want := slot.GetResources()
+	have := order.GetSlot().GetResources()
+
+	fits := func(want, have uint64) bool {
+		if order.GetOrderType() == pb.OrderType_BID {
+			return have <= want
+		}
+		return have >= want
+	}
+
+	network := fits(uint64(want.GetNetworkType()), uint64(have.GetNetworkType())) &&
+		fits(want.GetNetTrafficIn(), have.GetNetTrafficIn()) &&
+		fits(want.GetNetTrafficOut(), have.GetNetTrafficOut())
+
+	checks := []*matchCheck{
+		{
+			Dimension: "CPU",
+			OK:        fits(want.GetCpuCores(), have.GetCpuCores()),
+			Want:      fmt.Sprintf("%d cores", want.GetCpuCores()),
+			Have:      fmt.Sprintf("%d cores", have.GetCpuCores()),
+		},
+		{
+			Dimension: "GPU",
+			OK:        fits(uint64(want.GetGpuCount()), uint64(have.GetGpuCount())),
+			Want:      want.GetGpuCount().String(),
+			Have:      have.GetGpuCount().String(),
+		},
+		{
+			Dimension: "RAM",
+			OK:        fits(want.GetRamBytes(), have.GetRamBytes()),
+			Want:      formatBytes(want.GetRamBytes()),
+			Have:      formatBytes(have.GetRamBytes()),
+		},
+		{
+			Dimension: "Network",
+			OK:        network,
+			Want:      formatNetwork(want),
+			Have:      formatNetwork(have),
+		},
+		explainRating(slot, order),
+	}
+
+	if order.GetOrderType() == pb.OrderType_BID {
+		// the buyer's location requirements must be met by our location
+		checks = append(checks, explainGeo(order.GetSlot().GetGeo(), slot.GetGeo()))
+	} else {
+		checks = append(checks, explainGeo(slot.GetGeo(), order.GetSlot().GetGeo()))
+	}
+
+	return &matchExplanation{Order: order, Checks: checks}
+}
+
+func formatNetwork(res *pb.Resources) string {
+	return fmt.Sprintf("%s, in %s, out %s", res.GetNetworkType().String(),
+		formatBytes(res.GetNetTrafficIn()), formatBytes(res.GetNetTrafficOut()))
+}
+
+// explainRating checks the counterparty rating: the supplier's one for ASK
+// orders and the buyer's one for BID orders.
+func explainRating(slot *pb.Slot, order *pb.Order) *matchCheck {
+	want, have := slot.GetSupplierRating(), order.GetSlot().GetSupplierRating()
+	if order.GetOrderType() == pb.OrderType_BID {
+		want, have = slot.GetBuyerRating(), order.GetSlot().GetBuyerRating()
+	}
+
+	return &matchCheck{
+		Dimension: "Rating",
+		OK:        have >= want,
+		Want:      fmt.Sprintf(">= %d", want),
+		Have:      fmt.Sprintf("%d", have),
+	}
+}
+
+// explainGeo checks the location, empty country or city means "anywhere".
+func explainGeo(want, have *pb.Geo) *matchCheck {
+	ok := (want.GetCountry() == "" || strings.EqualFold(want.GetCountry(), have.GetCountry())) &&
+		(want.GetCity() == "" || strings.EqualFold(want.GetCity(), have.GetCity()))
+
+	return &matchCheck{
+		Dimension: "Geo",
+		OK:        ok,
+		Want:      formatGeo(want),
+		Have:      formatGeo(have),
+	}
+}
+
+func formatGeo(geo *pb.Geo) string {
+	if geo.GetCountry() == "" && geo.GetCity() == "" {
+		return "any"
+	}
+
+	return strings.Trim(geo.GetCountry()+"/"+geo.GetCity(), "/")
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeExplainTestSlot() *pb.Slot {
+	return &pb.Slot{
+		SupplierRating: 10,
+		Geo:            &pb.Geo{Country: "RU"},
+		Resources: &pb.Resources{
+			CpuCores:    4,
+			RamBytes:    4 * 1024 * 1024 * 1024,
+			GpuCount:    pb.GPUCount_SINGLE_GPU,
+			NetworkType: pb.NetworkType_INCOMING,
+		},
+	}
+}
+
+func TestExplainMatchFull(t *testing.T) {
+	order := &pb.Order{
+		Id:        "1",
+		OrderType: pb.OrderType_ASK,
+		Slot: &pb.Slot{
+			SupplierRating: 20,
+			Geo:            &pb.Geo{Country: "ru", City: "Novosibirsk"},
+			Resources: &pb.Resources{
+				CpuCores:    8,
+				RamBytes:    8 * 1024 * 1024 * 1024,
+				GpuCount:    pb.GPUCount_MULTIPLE_GPU,
+				NetworkType: pb.NetworkType_INCOMING,
+			},
+		},
+	}
+
+	explanation := explainMatch(makeExplainTestSlot(), order)
+	assert.True(t, explanation.Matched())
+	assert.Empty(t, explanation.Failed())
+	assert.Len(t, explanation.Checks, 6)
+}
+
+func TestExplainMatchRAMOnly(t *testing.T) {
+	order := &pb.Order{
+		Id:        "2",
+		OrderType: pb.OrderType_ASK,
+		Slot: &pb.Slot{
+			SupplierRating: 20,
+			Geo:            &pb.Geo{Country: "RU"},
+			Resources: &pb.Resources{
+				CpuCores:    8,
+				RamBytes:    2 * 1024 * 1024 * 1024,
+				GpuCount:    pb.GPUCount_SINGLE_GPU,
+				NetworkType: pb.NetworkType_INCOMING,
+			},
+		},
+	}
+
+	explanation := explainMatch(makeExplainTestSlot(), order)
+	require.False(t, explanation.Matched())
+
+	failed := explanation.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "RAM", failed[0].Dimension)
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printMatchExplanation(rootCmd, []*matchExplanation{explanation})
+	assert.Equal(t, "1) ASK 2 | price = \r\n   RAM: want 4.0 GB, have 2.0 GB\r\n", buf.String())
+}
+
+func TestExplainMatchBid(t *testing.T) {
+	// BID orders must not require more than we have.
+	order := &pb.Order{
+		Id:        "3",
+		OrderType: pb.OrderType_BID,
+		Slot: &pb.Slot{
+			Resources: &pb.Resources{
+				CpuCores: 16,
+				RamBytes: 1024,
+				GpuCount: pb.GPUCount_NO_GPU,
+			},
+		},
+	}
+
+	failed := explainMatch(makeExplainTestSlot(), order).Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "CPU", failed[0].Dimension)
+}
@@ -0,0 +1,511 @@
+package commands
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	ds "github.com/c2h5oh/datasize"
+	"github.com/sonm-io/core/insonmnia/structs"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+)
+
+// Filter expressions are a tiny language to select orders and deals in list
+// commands, for example:
+//
+//	price < 1000 && (cpu >= 4 || gpu == "MULTIPLE_GPU")
+//
+// Numeric fields can be compared using any of ==, !=, <, <=, > and >=,
+// string fields support only == and !=.
+
+type filterKind int
+
+const (
+	filterNumber filterKind = iota
+	filterString
+)
+
+func (k filterKind) String() string {
+	if k == filterNumber {
+		return "number"
+	}
+	return "string"
+}
+
+type filterValue struct {
+	kind filterKind
+	num  *big.Int
+	str  string
+}
+
+func numberValue(v *big.Int) filterValue {
+	return filterValue{kind: filterNumber, num: v}
+}
+
+func stringValue(v string) filterValue {
+	return filterValue{kind: filterString, str: v}
+}
+
+// filterSchema maps field names to their types.
+type filterSchema map[string]filterKind
+
+// filterRecord maps field names to their values for a single list item.
+type filterRecord map[string]filterValue
+
+var orderFilterSchema = filterSchema{
+	"price":    filterNumber,
+	"cpu":      filterNumber,
+	"ram":      filterNumber,
+	"gpu":      filterString,
+	"type":     filterString,
+	"supplier": filterString,
+	"buyer":    filterString,
+}
+
+var dealFilterSchema = filterSchema{
+	"price":    filterNumber,
+	"status":   filterString,
+	"supplier": filterString,
+	"buyer":    filterString,
+}
+
+type filterError struct {
+	pos int
+	msg string
+}
+
+func (e *filterError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.msg, e.pos+1)
+}
+
+func newFilterError(pos int, format string, args ...interface{}) error {
+	return &filterError{pos: pos, msg: fmt.Sprintf(format, args...)}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOp
+	tokenAnd
+	tokenOr
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenizeFilter(expr string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(expr); {
+		ch := rune(expr[pos])
+		switch {
+		case unicode.IsSpace(ch):
+			pos++
+		case ch == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			pos++
+		case ch == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			pos++
+		case strings.HasPrefix(expr[pos:], "&&"):
+			tokens = append(tokens, token{kind: tokenAnd, text: "&&", pos: pos})
+			pos += 2
+		case strings.HasPrefix(expr[pos:], "||"):
+			tokens = append(tokens, token{kind: tokenOr, text: "||", pos: pos})
+			pos += 2
+		case strings.ContainsRune("=!<>", ch):
+			op := string(ch)
+			if pos+1 < len(expr) && expr[pos+1] == '=' {
+				op += "="
+			}
+			if op == "=" || op == "!" {
+				return nil, newFilterError(pos, "unexpected %q", op)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: pos})
+			pos += len(op)
+		case ch == '"' || ch == '\'':
+			end := strings.IndexRune(expr[pos+1:], ch)
+			if end < 0 {
+				return nil, newFilterError(pos, "unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: expr[pos+1 : pos+1+end], pos: pos})
+			pos += end + 2
+		case unicode.IsDigit(ch):
+			start := pos
+			for pos < len(expr) && unicode.IsDigit(rune(expr[pos])) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[start:pos], pos: start})
+		case unicode.IsLetter(ch) || ch == '_':
+			start := pos
+			for pos < len(expr) && (unicode.IsLetter(rune(expr[pos])) || unicode.IsDigit(rune(expr[pos])) || expr[pos] == '_') {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: expr[start:pos], pos: start})
+		default:
+			return nil, newFilterError(pos, "unexpected %q", ch)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)}), nil
+}
+
+// filterExpr is a compiled filter expression.
+type filterExpr interface {
+	eval(record filterRecord) bool
+}
+
+type filterOr struct{ lhs, rhs filterExpr }
+
+func (e *filterOr) eval(record filterRecord) bool {
+	return e.lhs.eval(record) || e.rhs.eval(record)
+}
+
+type filterAnd struct{ lhs, rhs filterExpr }
+
+func (e *filterAnd) eval(record filterRecord) bool {
+	return e.lhs.eval(record) && e.rhs.eval(record)
+}
+
+// filterOperand is either a field reference or a literal.
+type filterOperand struct {
+	field   string
+	literal filterValue
+}
+
+func (o *filterOperand) value(record filterRecord) filterValue {
+	if o.field != "" {
+		return record[o.field]
+	}
+	return o.literal
+}
+
+type filterCompare struct {
+	op       string
+	lhs, rhs *filterOperand
+}
+
+func (e *filterCompare) eval(record filterRecord) bool {
+	lhs, rhs := e.lhs.value(record), e.rhs.value(record)
+
+	var cmp int
+	if lhs.kind == filterNumber {
+		cmp = lhs.num.Cmp(rhs.num)
+	} else {
+		cmp = strings.Compare(lhs.str, rhs.str)
+	}
+
+	switch e.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+	schema filterSchema
+}
+
+// parseFilter compiles the given expression, checking that all referenced
+// fields exist in the schema and are compared with values of the same type.
+func parseFilter(expr string, schema filterSchema) (filterExpr, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens, schema: schema}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, newFilterError(tok.pos, "unexpected %q", tok.text)
+	}
+
+	return e, nil
+}
+
+func (p *filterParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	lhs, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &filterOr{lhs: lhs, rhs: rhs}
+	}
+
+	return lhs, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	lhs, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenAnd {
+		p.next()
+		rhs, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &filterAnd{lhs: lhs, rhs: rhs}
+	}
+
+	return lhs, nil
+}
+
+func (p *filterParser) parseTerm() (filterExpr, error) {
+	if p.peek().kind == tokenLParen {
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, newFilterError(tok.pos, "expected \")\"")
+		}
+
+		return e, nil
+	}
+
+	lhsTok := p.peek()
+	lhs, lhsKind, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	opTok := p.next()
+	if opTok.kind != tokenOp {
+		return nil, newFilterError(opTok.pos, "expected comparison operator")
+	}
+
+	rhsTok := p.peek()
+	rhs, rhsKind, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if lhs.field == "" && rhs.field == "" {
+		return nil, newFilterError(lhsTok.pos, "at least one side of comparison must be a field")
+	}
+
+	if lhsKind != rhsKind {
+		return nil, newFilterError(rhsTok.pos, "type mismatch: cannot compare %s with %s", lhsKind, rhsKind)
+	}
+
+	if lhsKind == filterString && opTok.text != "==" && opTok.text != "!=" {
+		return nil, newFilterError(opTok.pos, "operator %q is not defined for strings", opTok.text)
+	}
+
+	return &filterCompare{op: opTok.text, lhs: lhs, rhs: rhs}, nil
+}
+
+func (p *filterParser) parseOperand() (*filterOperand, filterKind, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenIdent:
+		kind, ok := p.schema[tok.text]
+		if !ok {
+			return nil, 0, newFilterError(tok.pos, "unknown field %q", tok.text)
+		}
+		return &filterOperand{field: tok.text}, kind, nil
+	case tokenNumber:
+		v, _ := new(big.Int).SetString(tok.text, 10)
+		return &filterOperand{literal: numberValue(v)}, filterNumber, nil
+	case tokenString:
+		return &filterOperand{literal: stringValue(tok.text)}, filterString, nil
+	case tokenEOF:
+		return nil, 0, newFilterError(tok.pos, "unexpected end of expression")
+	default:
+		return nil, 0, newFilterError(tok.pos, "unexpected %q", tok.text)
+	}
+}
+
+func parsePriceOrZero(price string) *big.Int {
+	v, err := util.ParseBigInt(price)
+	if err != nil {
+		return big.NewInt(0)
+	}
+	return v
+}
+
+func orderFilterRecord(order *pb.Order) filterRecord {
+	res := order.GetSlot().GetResources()
+	return filterRecord{
+		"price":    numberValue(parsePriceOrZero(order.GetPrice())),
+		"cpu":      numberValue(new(big.Int).SetUint64(res.GetCpuCores())),
+		"ram":      numberValue(new(big.Int).SetUint64(res.GetRamBytes())),
+		"gpu":      stringValue(res.GetGpuCount().String()),
+		"type":     stringValue(order.GetOrderType().String()),
+		"supplier": stringValue(order.GetSupplierID()),
+		"buyer":    stringValue(order.GetByuerID()),
+	}
+}
+
+func dealFilterRecord(deal *pb.Deal) filterRecord {
+	return filterRecord{
+		"price":    numberValue(parsePriceOrZero(deal.GetPrice())),
+		"status":   stringValue(deal.GetStatus().String()),
+		"supplier": stringValue(deal.GetSupplierID()),
+		"buyer":    stringValue(deal.GetBuyerID()),
+	}
+}
+
+// filterOrders returns orders matching the given expression. An empty
+// expression matches everything.
+func filterOrders(expr string, orders []*pb.Order) ([]*pb.Order, error) {
+	if strings.TrimSpace(expr) == "" {
+		return orders, nil
+	}
+
+	f, err := parseFilter(expr, orderFilterSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*pb.Order, 0, len(orders))
+	for _, order := range orders {
+		if f.eval(orderFilterRecord(order)) {
+			out = append(out, order)
+		}
+	}
+
+	return out, nil
+}
+
+// filterDeals returns deals matching the given expression. An empty
+// expression matches everything.
+func filterDeals(expr string, deals []*pb.Deal) ([]*pb.Deal, error) {
+	if strings.TrimSpace(expr) == "" {
+		return deals, nil
+	}
+
+	f, err := parseFilter(expr, dealFilterSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*pb.Deal, 0, len(deals))
+	for _, deal := range deals {
+		if f.eval(dealFilterRecord(deal)) {
+			out = append(out, deal)
+		}
+	}
+
+	return out, nil
+}
+
+// orderThresholds are the minimal resources of orders to keep, zero values
+// keep everything.
+type orderThresholds struct {
+	cpuCores uint64
+	ramBytes uint64
+	gpuCount pb.GPUCount
+}
+
+// parseOrderThresholds parses RAM given as a human-readable size, like "4GB",
+// and GPU count given by its name, like "SINGLE_GPU". Empty strings mean no
+// threshold.
+func parseOrderThresholds(cpuCores uint64, ram, gpu string) (*orderThresholds, error) {
+	thresholds := &orderThresholds{cpuCores: cpuCores}
+
+	if ram != "" {
+		var size ds.ByteSize
+		if err := size.UnmarshalText([]byte(ram)); err != nil {
+			return nil, fmt.Errorf("invalid RAM size %q: %v", ram, err)
+		}
+		thresholds.ramBytes = size.Bytes()
+	}
+
+	if gpu != "" {
+		count, err := structs.ParseGPUCount(strings.ToUpper(gpu))
+		if err != nil {
+			return nil, fmt.Errorf("invalid GPU count %q, expected one of: NO_GPU, SINGLE_GPU, MULTIPLE_GPU", gpu)
+		}
+		thresholds.gpuCount = count
+	}
+
+	return thresholds, nil
+}
+
+func (t *orderThresholds) match(order *pb.Order) bool {
+	res := order.GetSlot().GetResources()
+	return res.GetCpuCores() >= t.cpuCores &&
+		res.GetRamBytes() >= t.ramBytes &&
+		res.GetGpuCount() >= t.gpuCount
+}
+
+// filterOrdersByThresholds returns orders having at least the given
+// resources.
+func filterOrdersByThresholds(thresholds *orderThresholds, orders []*pb.Order) []*pb.Order {
+	out := make([]*pb.Order, 0, len(orders))
+	for _, order := range orders {
+		if thresholds.match(order) {
+			out = append(out, order)
+		}
+	}
+
+	return out
+}
+
+// filterSlotsByCountry returns slots located in the given country, ignoring
+// case. Slots with unknown country never match, an empty country matches
+// everything.
+func filterSlotsByCountry(country string, slots map[string]*pb.Slot) map[string]*pb.Slot {
+	if country == "" {
+		return slots
+	}
+
+	out := make(map[string]*pb.Slot, len(slots))
+	for id, slot := range slots {
+		if strings.EqualFold(slot.GetGeo().GetCountry(), country) {
+			out[id] = slot
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"testing"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeFilterTestOrders() []*pb.Order {
+	return []*pb.Order{
+		{
+			Id:         "1",
+			Price:      "500",
+			SupplierID: "0x1",
+			OrderType:  pb.OrderType_ASK,
+			Slot:       &pb.Slot{Resources: &pb.Resources{CpuCores: 2, RamBytes: 2 << 30, GpuCount: pb.GPUCount_NO_GPU}},
+		},
+		{
+			Id:         "2",
+			Price:      "900",
+			SupplierID: "0x2",
+			OrderType:  pb.OrderType_ASK,
+			Slot:       &pb.Slot{Resources: &pb.Resources{CpuCores: 8, RamBytes: 16 << 30, GpuCount: pb.GPUCount_MULTIPLE_GPU}},
+		},
+		{
+			Id:         "3",
+			Price:      "1500",
+			SupplierID: "0x2",
+			OrderType:  pb.OrderType_ASK,
+			Slot:       &pb.Slot{Resources: &pb.Resources{CpuCores: 4, RamBytes: 8 << 30, GpuCount: pb.GPUCount_SINGLE_GPU}},
+		},
+	}
+}
+
+func filteredOrderIDs(t *testing.T, expr string) []string {
+	orders, err := filterOrders(expr, makeFilterTestOrders())
+	require.NoError(t, err)
+
+	ids := []string{}
+	for _, order := range orders {
+		ids = append(ids, order.Id)
+	}
+	return ids
+}
+
+func TestFilterOrders(t *testing.T) {
+	cases := map[string][]string{
+		"":                                       {"1", "2", "3"},
+		"price < 1000":                           {"1", "2"},
+		"price < 1000 && cpu >= 4":               {"2"},
+		"cpu <= 2 || price >= 1500":              {"1", "3"},
+		"supplier == '0x2' && price != 900":      {"3"},
+		`gpu == "NO_GPU" || gpu == "SINGLE_GPU"`: {"1", "3"},
+		"(price < 1000 || cpu == 4) && gpu != 'NO_GPU'": {"2", "3"},
+		"1000 > price": {"1", "2"},
+	}
+
+	for expr, expected := range cases {
+		assert.Equal(t, expected, filteredOrderIDs(t, expr), expr)
+	}
+}
+
+func TestFilterOrdersByThresholds(t *testing.T) {
+	cases := []struct {
+		cpu      uint64
+		ram      string
+		gpu      string
+		expected []string
+	}{
+		{0, "", "", []string{"1", "2", "3"}},
+		{4, "", "", []string{"2", "3"}},
+		{0, "8GB", "", []string{"2", "3"}},
+		{0, "10GB", "", []string{"2"}},
+		{0, "", "SINGLE_GPU", []string{"2", "3"}},
+		{0, "", "multiple_gpu", []string{"2"}},
+		{0, "", "NO_GPU", []string{"1", "2", "3"}},
+		{2, "2GB", "SINGLE_GPU", []string{"2", "3"}},
+		{16, "", "", []string{}},
+	}
+
+	for _, c := range cases {
+		thresholds, err := parseOrderThresholds(c.cpu, c.ram, c.gpu)
+		require.NoError(t, err)
+
+		ids := []string{}
+		for _, order := range filterOrdersByThresholds(thresholds, makeFilterTestOrders()) {
+			ids = append(ids, order.Id)
+		}
+		assert.Equal(t, c.expected, ids, "cpu: %d, ram: %q, gpu: %q", c.cpu, c.ram, c.gpu)
+	}
+}
+
+func TestParseOrderThresholdsErrors(t *testing.T) {
+	_, err := parseOrderThresholds(0, "4 apples", "")
+	assert.Error(t, err)
+
+	_, err = parseOrderThresholds(0, "", "SEVERAL_GPU")
+	assert.Error(t, err)
+}
+
+func TestFilterDeals(t *testing.T) {
+	deals := []*pb.Deal{
+		{Id: "1", Price: "100", Status: pb.DealStatus_ACCEPTED},
+		{Id: "2", Price: "200", Status: pb.DealStatus_CLOSED},
+	}
+
+	filtered, err := filterDeals("status != 'CLOSED'", deals)
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "1", filtered[0].Id)
+
+	// Deals carry no resources.
+	_, err = filterDeals("cpu > 1", deals)
+	assert.EqualError(t, err, `unknown field "cpu" at position 1`)
+}
+
+func TestFilterErrors(t *testing.T) {
+	cases := map[string]string{
+		"price < 'cheap'":        "type mismatch: cannot compare number with string at position 9",
+		"supplier > '0x1'":       `operator ">" is not defined for strings at position 10`,
+		"gpu == 1":               "type mismatch: cannot compare string with number at position 8",
+		"price < 1000 &&":        "unexpected end of expression at position 16",
+		"price = 1000":           `unexpected "=" at position 7`,
+		"(price < 1000":          `expected ")" at position 14`,
+		"price < 1000 cpu > 2":   `unexpected "cpu" at position 14`,
+		"1 < 2":                  "at least one side of comparison must be a field at position 1",
+		"supplier == '0x1":       "unterminated string at position 13",
+		"price < 1000 # comment": `unexpected '#' at position 14`,
+	}
+
+	for expr, expected := range cases {
+		_, err := filterOrders(expr, makeFilterTestOrders())
+		assert.EqualError(t, err, expected, expr)
+	}
+}
+
+func TestFilterSlotsByCountry(t *testing.T) {
+	slots := map[string]*pb.Slot{
+		"1": {Geo: &pb.Geo{Country: "RU", City: "Moscow"}},
+		"2": {Geo: &pb.Geo{Country: "ru"}},
+		"3": {Geo: &pb.Geo{Country: "US"}},
+		"4": {Geo: &pb.Geo{City: "Berlin"}},
+		"5": {},
+	}
+
+	assert.Equal(t, []string{"1", "2"}, sortedKeys(filterSlotsByCountry("Ru", slots)))
+	assert.Empty(t, filterSlotsByCountry("DE", slots))
+	assert.Len(t, filterSlotsByCountry("", slots), 5)
+}
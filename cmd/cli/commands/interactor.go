@@ -149,7 +149,7 @@ func (it *hubInteractor) TaskStatus(id string) (*pb.TaskStatusReply, error) {
 }
 
 func NewHubInteractor(addr string, timeout time.Duration) (NodeHubInteractor, error) {
-	cc, err := util.MakeGrpcClient(context.Background(), addr, creds)
+	cc, err := util.MakeGrpcClient(commandCtx, addr, creds)
 	if err != nil {
 		return nil, err
 	}
@@ -221,11 +221,11 @@ func (it *marketInteractor) CancelOrder(id string) error {
 }
 
 func ctx(timeout time.Duration) (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), timeout)
+	return context.WithTimeout(commandCtx, timeout)
 }
 
 func NewMarketInteractor(addr string, timeout time.Duration) (NodeMarketInteractor, error) {
-	cc, err := util.MakeGrpcClient(context.Background(), addr, creds)
+	cc, err := util.MakeGrpcClient(commandCtx, addr, creds)
 	if err != nil {
 		return nil, err
 	}
@@ -243,6 +243,7 @@ type DealsInteractor interface {
 	List(from string, status pb.DealStatus) ([]*pb.Deal, error)
 	Status(id string) (*pb.Deal, error)
 	FinishDeal(id string) error
+	FindBySpecHash(counterparty, specHash string) (*pb.Deal, error)
 }
 
 type dealsInteractor struct {
@@ -278,8 +279,15 @@ func (it *dealsInteractor) FinishDeal(id string) error {
 	return err
 }
 
+func (it *dealsInteractor) FindBySpecHash(counterparty, specHash string) (*pb.Deal, error) {
+	ctx, cancel := ctx(it.timeout)
+	defer cancel()
+
+	return it.deals.FindBySpecHash(ctx, &pb.DealFindRequest{Counterparty: counterparty, SpecHash: specHash})
+}
+
 func NewDealsInteractor(addr string, timeout time.Duration) (DealsInteractor, error) {
-	cc, err := util.MakeGrpcClient(context.Background(), addr, creds)
+	cc, err := util.MakeGrpcClient(commandCtx, addr, creds)
 	if err != nil {
 		return nil, err
 	}
@@ -295,7 +303,9 @@ type TasksInteractor interface {
 	ImagePush(ctx context.Context) (pb.Hub_PushTaskClient, error)
 	Start(req *pb.HubStartTaskRequest) (*pb.HubStartTaskReply, error)
 	Status(id, hub string) (*pb.TaskStatusReply, error)
-	Logs(req *pb.TaskLogsRequest) (pb.TaskManagement_LogsClient, error)
+	// Logs opens a log stream for the task, which lives until the given
+	// context is canceled.
+	Logs(ctx context.Context, req *pb.TaskLogsRequest) (pb.TaskManagement_LogsClient, error)
 	Stop(id, hub string) (*pb.Empty, error)
 	ImagePull(dealID, taskID string) (pb.Hub_PullTaskClient, error)
 }
@@ -331,10 +341,7 @@ func (it *tasksInteractor) Status(id, hub string) (*pb.TaskStatusReply, error) {
 	return it.tasks.Status(ctx, &pb.TaskID{Id: id, HubAddr: hub})
 }
 
-func (it *tasksInteractor) Logs(req *pb.TaskLogsRequest) (pb.TaskManagement_LogsClient, error) {
-	ctx, cancel := ctx(it.timeout)
-	defer cancel()
-
+func (it *tasksInteractor) Logs(ctx context.Context, req *pb.TaskLogsRequest) (pb.TaskManagement_LogsClient, error) {
 	return it.tasks.Logs(ctx, req)
 }
 
@@ -357,7 +364,7 @@ func (it *tasksInteractor) ImagePull(dealID, taskID string) (pb.Hub_PullTaskClie
 }
 
 func NewTasksInteractor(addr string, timeout time.Duration) (TasksInteractor, error) {
-	cc, err := util.MakeGrpcClient(context.Background(), addr, creds)
+	cc, err := util.MakeGrpcClient(commandCtx, addr, creds)
 	if err != nil {
 		return nil, err
 	}
@@ -367,3 +374,31 @@ func NewTasksInteractor(addr string, timeout time.Duration) (TasksInteractor, er
 		tasks:   pb.NewTaskManagementClient(cc),
 	}, nil
 }
+
+type LocatorInteractor interface {
+	Stats() (*pb.LocatorStatsReply, error)
+}
+
+type locatorInteractor struct {
+	timeout time.Duration
+	locator pb.LocatorClient
+}
+
+func (it *locatorInteractor) Stats() (*pb.LocatorStatsReply, error) {
+	ctx, cancel := ctx(it.timeout)
+	defer cancel()
+
+	return it.locator.Stats(ctx, &pb.Empty{})
+}
+
+func NewLocatorInteractor(addr string, timeout time.Duration) (LocatorInteractor, error) {
+	cc, err := util.MakeGrpcClient(commandCtx, addr, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return &locatorInteractor{
+		timeout: timeout,
+		locator: pb.NewLocatorClient(cc),
+	}, nil
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	locatorRootCmd.AddCommand(locatorStatsCmd)
+}
+
+var locatorRootCmd = &cobra.Command{
+	Use:   "locator",
+	Short: "Locator introspection",
+}
+
+var locatorStatsCmd = &cobra.Command{
+	Use:    "stats <locator_addr>",
+	Short:  "Show the number of registered nodes and how fresh they are",
+	PreRun: loadKeyStoreWrapper,
+	Args:   cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		locator, err := NewLocatorInteractor(args[0], timeoutFlag)
+		if err != nil {
+			showError(cmd, "Cannot connect to Locator", err)
+			os.Exit(1)
+		}
+
+		stats, err := locator.Stats()
+		if err != nil {
+			showError(cmd, "Cannot get locator stats", err)
+			os.Exit(1)
+		}
+
+		printLocatorStats(cmd, stats)
+	},
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"io"
+)
+
+// lfWriter translates CRLF line endings of printers into bare LF ones for
+// the "--no-trailing-cr" flag. Lone CRs, for example those rewinding
+// progress lines, are kept.
+type lfWriter struct {
+	out io.Writer
+	// pendingCR is set when the last write has ended with CR, which is
+	// dropped if the next write starts with LF.
+	pendingCR bool
+}
+
+func newLFWriter(w io.Writer) *lfWriter {
+	return &lfWriter{out: w}
+}
+
+func (w *lfWriter) Write(p []byte) (int, error) {
+	buf := make([]byte, 0, len(p)+1)
+	for _, c := range p {
+		if w.pendingCR {
+			w.pendingCR = false
+			if c != '\n' {
+				buf = append(buf, '\r')
+			}
+		}
+
+		if c == '\r' {
+			w.pendingCR = true
+			continue
+		}
+
+		buf = append(buf, c)
+	}
+
+	if _, err := w.out.Write(buf); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
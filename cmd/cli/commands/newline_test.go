@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := newLFWriter(buf)
+
+	w.Write([]byte("ID:  42\r\nPrice: 1000\r\n"))
+	// CRLF split between writes.
+	w.Write([]byte("Status: ACCEPTED\r"))
+	w.Write([]byte("\n"))
+	// Lone CRs rewind progress lines.
+	w.Write([]byte("10%\r20%\r"))
+	n, err := w.Write([]byte("done\r\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+	assert.Equal(t, "ID:  42\nPrice: 1000\nStatus: ACCEPTED\n10%\r20%\rdone\n", buf.String())
+}
+
+func TestNoTrailingCRFlag(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	prevCtx := commandCtx
+	noTrailingCRFlag = true
+	defer func() { commandCtx, noTrailingCRFlag = prevCtx, false }()
+
+	rootCmd.PersistentPreRun(rootCmd, nil)
+
+	version = "1.2.3"
+	printVersion(rootCmd, version)
+	showError(rootCmd, "Cannot get deal", nil)
+	assert.Equal(t, "Version: 1.2.3\n[ERR] Cannot get deal\n", buf.String())
+}
@@ -1,22 +1,109 @@
 package commands
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/mattn/go-isatty"
+	"github.com/sonm-io/core/insonmnia/node"
 	"github.com/sonm-io/core/insonmnia/structs"
+	pb "github.com/sonm-io/core/proto"
 	"github.com/spf13/cobra"
 )
 
+var errDealWaitTimeout = errors.New("timed out waiting for deal")
+
 var (
-	ordersSearchLimit uint64 = 0
-	orderSearchType          = "ANY"
+	ordersSearchLimit  uint64 = 0
+	ordersSearchOffset uint64 = 0
+	orderSearchType           = "ANY"
+	orderSnapshotPath  string
+	orderSearchFilter  string
+	orderSearchMinCPU  uint64
+	orderSearchMinRAM  string
+	orderSearchGPU     string
+	orderSearchExplain bool
+	orderCreateWait    time.Duration
+	// orderSearchRetry is the interval of repeating searches which found
+	// nothing, zero disables retrying.
+	orderSearchRetry        time.Duration
+	orderSearchRetryTimeout time.Duration
+	orderSearchSort         string
+	orderSearchReverse      bool
 )
 
+// orderCompareFunc returns a negative number when the first order goes
+// before the second one, a positive one when after, and zero on ties.
+type orderCompareFunc func(a, b *pb.Order) int
+
+var orderComparators = map[string]orderCompareFunc{
+	"price": func(a, b *pb.Order) int {
+		return parsePriceOrZero(a.GetPrice()).Cmp(parsePriceOrZero(b.GetPrice()))
+	},
+	"type": func(a, b *pb.Order) int {
+		return int(a.GetOrderType()) - int(b.GetOrderType())
+	},
+	"id": func(a, b *pb.Order) int {
+		return strings.Compare(a.GetId(), b.GetId())
+	},
+}
+
 func init() {
 	marketSearchCmd.PersistentFlags().StringVar(&orderSearchType, "type", "ANY",
 		"Orders type to search: ANY, BID or ASK")
 	marketSearchCmd.PersistentFlags().Uint64Var(&ordersSearchLimit, "limit", 10,
 		"Orders count to show")
+	marketSearchCmd.PersistentFlags().Uint64Var(&ordersSearchOffset, "offset", 0,
+		"Orders count to skip, more orders are requested from Marketplace to make up for them")
+
+	marketSearchCmd.PersistentFlags().StringVar(&orderSearchFilter, "filter", "",
+		"Filter expression, e.g. 'price < 1000 && cpu >= 4'")
+	marketSearchCmd.PersistentFlags().Uint64Var(&orderSearchMinCPU, "min-cpu", 0,
+		"Show only orders with at least the given number of CPU cores")
+	marketSearchCmd.PersistentFlags().StringVar(&orderSearchMinRAM, "min-ram", "",
+		"Show only orders with at least the given RAM size, e.g. 4GB")
+	marketSearchCmd.PersistentFlags().StringVar(&orderSearchGPU, "gpu", "",
+		"Show only orders with at least the given GPU count: SINGLE_GPU or MULTIPLE_GPU")
+	marketSearchCmd.PersistentFlags().Lookup("gpu").NoOptDefVal = "SINGLE_GPU"
+
+	marketSearchCmd.PersistentFlags().BoolVar(&orderSearchExplain, "explain", false,
+		"Show which slot constraints each found order satisfies")
+	marketSearchCmd.PersistentFlags().DurationVar(&orderSearchRetry, "retry-on-empty", 0,
+		"Repeat the search with the given interval until matching orders appear")
+	marketSearchCmd.PersistentFlags().Lookup("retry-on-empty").NoOptDefVal = "5s"
+	marketSearchCmd.PersistentFlags().DurationVar(&orderSearchRetryTimeout, "retry-timeout", time.Minute,
+		"Give up repeating the search after the given time")
+	commandTimeouts[marketSearchCmd] = func(timeout time.Duration) time.Duration {
+		// The retry is asked explicitly, so it extends the command timeout.
+		if orderSearchRetry > 0 {
+			return timeout + orderSearchRetryTimeout
+		}
+
+		return timeout
+	}
+	marketSearchCmd.PersistentFlags().StringVar(&orderSearchSort, "sort", "",
+		"Sort found orders by: "+strings.Join(sortedKeys(orderComparators), ", "))
+	marketSearchCmd.PersistentFlags().BoolVar(&orderSearchReverse, "reverse", false,
+		"Sort found orders in descending order")
+
+	marketSnapshotCmd.PersistentFlags().StringVar(&orderSearchType, "type", "ANY",
+		"Orders type to search: ANY, BID or ASK")
+	marketSnapshotCmd.PersistentFlags().Uint64Var(&ordersSearchLimit, "limit", 10,
+		"Orders count to save")
+	marketSnapshotCmd.PersistentFlags().StringVar(&orderSnapshotPath, "save", "",
+		"Path to file to save order-book snapshot into")
+
+	marketCreteCmd.PersistentFlags().DurationVar(&orderCreateWait, "wait", 0,
+		"Wait up to the given time for the order to result in a deal")
+	commandTimeouts[marketCreteCmd] = func(timeout time.Duration) time.Duration {
+		// The wait is asked explicitly, so it extends the command timeout.
+		return timeout + orderCreateWait
+	}
 
 	marketRootCmd.AddCommand(
 		marketSearchCmd,
@@ -24,6 +111,8 @@ func init() {
 		marketCreteCmd,
 		marketCancelCmd,
 		marketProcessingCmd,
+		marketSnapshotCmd,
+		marketDiffCmd,
 	)
 }
 
@@ -57,13 +146,202 @@ var marketSearchCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		thresholds, err := parseOrderThresholds(orderSearchMinCPU, orderSearchMinRAM, orderSearchGPU)
+		if err != nil {
+			showError(cmd, "Cannot parse resource thresholds", err)
+			os.Exit(1)
+		}
+
+		var compare orderCompareFunc
+		if orderSearchSort != "" {
+			if compare, err = orderComparator(orderSearchSort); err != nil {
+				showError(cmd, "Cannot sort orders", err)
+				os.Exit(1)
+			}
+		}
+
+		// Pagination is applied locally after filtering and sorting, so the
+		// skipped orders are requested too.
+		count := ordersSearchLimit
+		if count > 0 {
+			count += ordersSearchOffset
+		}
+
+		search := func() ([]*pb.Order, error) {
+			orders, err := market.GetOrders(slot, ordType, count)
+			if err != nil {
+				return nil, fmt.Errorf("cannot get orders: %v", err)
+			}
+
+			orders, err = filterOrders(orderSearchFilter, orders)
+			if err != nil {
+				return nil, fmt.Errorf("cannot apply filter: %v", err)
+			}
+
+			return filterOrdersByThresholds(thresholds, orders), nil
+		}
+
+		var orders []*pb.Order
+		if orderSearchRetry > 0 {
+			ctx, cancel := context.WithTimeout(commandCtx, orderSearchRetryTimeout)
+			defer cancel()
+
+			orders, err = searchOrdersUntilFound(ctx, cmd, search, orderSearchRetry)
+		} else {
+			orders, err = search()
+		}
+		if err != nil {
+			showError(cmd, "Cannot search orders", err)
+			os.Exit(1)
+		}
+
+		if compare != nil {
+			sortOrders(orders, compare, orderSearchReverse)
+		}
+
+		page := newPagination(len(orders), ordersSearchOffset, ordersSearchLimit)
+		from, to := page.bounds()
+		orders = orders[from:to]
+
+		if orderSearchExplain {
+			explanations := make([]*matchExplanation, 0, len(orders))
+			for _, order := range orders {
+				explanations = append(explanations, explainMatch(slot.Unwrap(), order))
+			}
+
+			printMatchExplanation(cmd, explanations)
+			return
+		}
+
+		printSearchResults(cmd, orders, page)
+	},
+}
+
+func orderComparator(key string) (orderCompareFunc, error) {
+	compare, ok := orderComparators[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort key %q, expected one of: %s",
+			key, strings.Join(sortedKeys(orderComparators), ", "))
+	}
+
+	return compare, nil
+}
+
+// sortOrders sorts orders in place. Ties are ordered by ID, which is not
+// affected by reversing, so the output is stable between runs.
+func sortOrders(orders []*pb.Order, compare orderCompareFunc, reverse bool) {
+	sort.Slice(orders, func(i, j int) bool {
+		c := compare(orders[i], orders[j])
+		if reverse {
+			c = -c
+		}
+		if c == 0 {
+			return orders[i].GetId() < orders[j].GetId()
+		}
+
+		return c < 0
+	})
+}
+
+// searchOrdersUntilFound repeats the search every tick until it finds
+// something. When the context expires an empty result is returned, not an
+// error, because finding nothing is a valid result of the search. The
+// waiting line is shown only on terminals, so JSON and piped output get
+// the result only.
+func searchOrdersUntilFound(ctx context.Context, cmd *cobra.Command, search func() ([]*pb.Order, error), tick time.Duration) ([]*pb.Order, error) {
+	progress := isSimpleFormat() && isatty.IsTerminal(os.Stdout.Fd())
+	if progress {
+		defer cmd.Printf("\r\x1b[K")
+	}
+
+	started := time.Now()
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		orders, err := search()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(orders) > 0 {
+			return orders, nil
+		}
+
+		if progress {
+			cmd.Printf("\rwaiting for matching orders... %s elapsed\x1b[K", time.Since(started).Truncate(time.Second))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return []*pb.Order{}, nil
+		}
+	}
+}
+
+var marketSnapshotCmd = &cobra.Command{
+	Use:    "snapshot <slot.yaml> --save <file>",
+	Short:  "Save matching orders from Marketplace into a file",
+	PreRun: loadKeyStoreWrapper,
+	Args:   cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if orderSnapshotPath == "" {
+			showError(cmd, "Snapshot file path is required", nil)
+			os.Exit(1)
+		}
+
+		market, err := NewMarketInteractor(nodeAddressFlag, timeoutFlag)
+		if err != nil {
+			showError(cmd, "Cannot connect to Node", err)
+			os.Exit(1)
+		}
+
+		ordType, err := structs.ParseOrderType(orderSearchType)
+		if err != nil {
+			showError(cmd, "Cannot parse order type", err)
+			os.Exit(1)
+		}
+
+		slot, err := loadSlotFile(args[0])
+		if err != nil {
+			showError(cmd, "Cannot parse slot file", err)
+			os.Exit(1)
+		}
+
 		orders, err := market.GetOrders(slot, ordType, ordersSearchLimit)
 		if err != nil {
 			showError(cmd, "Cannot get orders", err)
 			os.Exit(1)
 		}
 
-		printSearchResults(cmd, orders)
+		if err := saveOrderBookSnapshot(orderSnapshotPath, orders); err != nil {
+			showError(cmd, "Cannot save snapshot", err)
+			os.Exit(1)
+		}
+
+		printSearchResults(cmd, orders, nil)
+	},
+}
+
+var marketDiffCmd = &cobra.Command{
+	Use:   "diff <old.json> <new.json>",
+	Short: "Show difference between two order-book snapshots",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		old, err := loadOrderBookSnapshot(args[0])
+		if err != nil {
+			showError(cmd, "Cannot load old snapshot", err)
+			os.Exit(1)
+		}
+
+		new, err := loadOrderBookSnapshot(args[1])
+		if err != nil {
+			showError(cmd, "Cannot load new snapshot", err)
+			os.Exit(1)
+		}
+
+		printOrderBookDiff(cmd, diffOrderBooks(old, new))
 	},
 }
 
@@ -135,10 +413,73 @@ var marketCreteCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		printID(cmd, created.Id)
+		if orderCreateWait <= 0 {
+			printID(cmd, created.Id)
+			return
+		}
+
+		deals, err := NewDealsInteractor(nodeAddressFlag, timeoutFlag)
+		if err != nil {
+			showError(cmd, "Cannot connect to Node", err)
+			os.Exit(1)
+		}
+
+		ctx, cancel := context.WithTimeout(commandCtx, orderCreateWait)
+		defer cancel()
+
+		deal, err := waitForDeal(ctx, cmd, market, deals, created.Id, time.Second)
+		if err != nil {
+			showError(cmd, "Cannot wait for deal", err)
+			os.Exit(1)
+		}
+
+		printDealInfo(cmd, deal)
 	},
 }
 
+// waitForDeal polls processing status of the given order until it results
+// in a deal. Progress is rendered in place only on terminals, so JSON and
+// piped output stay clean.
+func waitForDeal(ctx context.Context, cmd *cobra.Command, market NodeMarketInteractor, deals DealsInteractor, orderID string, tick time.Duration) (*pb.Deal, error) {
+	progress := isSimpleFormat() && isatty.IsTerminal(os.Stdout.Fd())
+	if progress {
+		// Clear the progress line whatever the result is.
+		defer cmd.Printf("\r\x1b[K")
+	}
+
+	started := time.Now()
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		reply, err := market.GetProcessing()
+		if err != nil {
+			return nil, err
+		}
+
+		order, ok := reply.GetOrders()[orderID]
+		if ok {
+			switch order.GetStatus() {
+			case node.HandlerStatusDone:
+				return deals.Status(order.GetDealID())
+			case node.HandlerStatusFailed:
+				return nil, fmt.Errorf("order processing failed: %s", order.GetExtra())
+			}
+		}
+
+		if progress {
+			cmd.Printf("\rwaiting for deal... %s elapsed (%s)\x1b[K",
+				time.Since(started).Truncate(time.Second), node.HandlerStatusString(uint8(order.GetStatus())))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, errDealWaitTimeout
+		}
+	}
+}
+
 var marketCancelCmd = &cobra.Command{
 	Use:    "cancel <order_id>",
 	Short:  "Cancel order on Marketplace",
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	"github.com/sonm-io/core/insonmnia/node"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// fakeProcessingMarket reports the order as being searched for the given
+// number of polls, then as done.
+type fakeProcessingMarket struct {
+	NodeMarketInteractor
+	pending int
+}
+
+func (m *fakeProcessingMarket) GetProcessing() (*pb.GetProcessingReply, error) {
+	order := &pb.GetProcessingReply_ProcessedOrder{Id: "order-1", Status: 1}
+	if m.pending == 0 {
+		order.Status = node.HandlerStatusDone
+		order.DealID = "42"
+	} else {
+		m.pending--
+	}
+
+	return &pb.GetProcessingReply{Orders: map[string]*pb.GetProcessingReply_ProcessedOrder{"order-1": order}}, nil
+}
+
+type fakeDeals struct {
+	DealsInteractor
+}
+
+func (d *fakeDeals) Status(id string) (*pb.Deal, error) {
+	return &pb.Deal{Id: id, Status: pb.DealStatus_ACCEPTED}, nil
+}
+
+func TestWaitForDeal(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	deal, err := waitForDeal(context.Background(), rootCmd, &fakeProcessingMarket{pending: 2}, &fakeDeals{}, "order-1", time.Millisecond)
+	require.NoError(t, err)
+	printDealInfo(rootCmd, deal)
+
+	assert.Contains(t, buf.String(), "ID:       "+formatDealID("42")+"\r\n")
+	assert.NotContains(t, buf.String(), "waiting for deal")
+}
+
+func TestWaitForDealTimeout(t *testing.T) {
+	initRootCmd(t, config.OutputModeSimple)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := waitForDeal(ctx, rootCmd, &fakeProcessingMarket{pending: 1000}, &fakeDeals{}, "order-1", time.Millisecond)
+	assert.Equal(t, errDealWaitTimeout, err)
+}
+
+// fakeSearch finds nothing for the given number of searches, then finds
+// an order.
+type fakeSearch struct {
+	empty    int
+	searches int
+}
+
+func (s *fakeSearch) search() ([]*pb.Order, error) {
+	s.searches++
+	if s.searches <= s.empty {
+		return nil, nil
+	}
+
+	return []*pb.Order{{Id: "order-1", OrderType: pb.OrderType_ASK, Price: "100"}}, nil
+}
+
+func TestSearchOrdersUntilFound(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	search := &fakeSearch{empty: 2}
+	orders, err := searchOrdersUntilFound(context.Background(), rootCmd, search.search, time.Millisecond)
+	require.NoError(t, err)
+	printSearchResults(rootCmd, orders, nil)
+
+	assert.Equal(t, 3, search.searches)
+	assert.Equal(t, "1) ASK order-1 | price = 100\r\n", buf.String())
+}
+
+func TestSearchOrdersUntilFoundTimeoutJSON(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	search := &fakeSearch{empty: 1000}
+	orders, err := searchOrdersUntilFound(ctx, rootCmd, search.search, time.Millisecond)
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+
+	printSearchResults(rootCmd, orders, nil)
+	assert.Equal(t, "{\"orders\":[]}\r\n", buf.String())
+}
+
+func TestSearchOrdersUntilFoundError(t *testing.T) {
+	initRootCmd(t, config.OutputModeSimple)
+
+	_, err := searchOrdersUntilFound(context.Background(), rootCmd, func() ([]*pb.Order, error) {
+		return nil, errors.New("connection refused")
+	}, time.Millisecond)
+	assert.Error(t, err)
+}
+
+func sortedOrderIDs(t *testing.T, key string, reverse bool, orders []*pb.Order) []string {
+	compare, err := orderComparator(key)
+	require.NoError(t, err)
+
+	sortOrders(orders, compare, reverse)
+
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.Id)
+	}
+	return ids
+}
+
+func TestSortOrdersByPrice(t *testing.T) {
+	orders := func() []*pb.Order {
+		return []*pb.Order{
+			{Id: "d", Price: "100000000000000000000"},
+			{Id: "c", Price: "9"},
+			{Id: "b", Price: "10"},
+			{Id: "a", Price: "10"},
+		}
+	}
+
+	// Prices are compared as numbers, not as strings.
+	assert.Equal(t, []string{"c", "a", "b", "d"}, sortedOrderIDs(t, "price", false, orders()))
+	// Ties keep the ID order when reversed.
+	assert.Equal(t, []string{"d", "a", "b", "c"}, sortedOrderIDs(t, "price", true, orders()))
+}
+
+func TestSortOrdersByType(t *testing.T) {
+	orders := []*pb.Order{
+		{Id: "3", OrderType: pb.OrderType_ASK},
+		{Id: "2", OrderType: pb.OrderType_BID},
+		{Id: "1", OrderType: pb.OrderType_ASK},
+	}
+
+	assert.Equal(t, []string{"2", "1", "3"}, sortedOrderIDs(t, "type", false, orders))
+}
+
+func TestSortOrdersByID(t *testing.T) {
+	orders := []*pb.Order{{Id: "b"}, {Id: "c"}, {Id: "a"}}
+
+	assert.Equal(t, []string{"c", "b", "a"}, sortedOrderIDs(t, "id", true, orders))
+}
+
+func TestOrderComparatorUnknown(t *testing.T) {
+	_, err := orderComparator("cpu")
+	assert.Error(t, err)
+}
@@ -9,7 +9,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var askPlanCountry string
+
 func init() {
+	hubOrderListCmd.PersistentFlags().StringVar(&askPlanCountry, "country", "",
+		"Show only ask plans advertised in the given country, e.g. RU")
+
 	hubOrderRootCmd.AddCommand(
 		hubOrderListCmd,
 		hubOrderCreateCmd,
@@ -39,6 +44,7 @@ var hubOrderListCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		asks.Slots = filterSlotsByCountry(askPlanCountry, asks.GetSlots())
 		printAskList(cmd, asks)
 	},
 }
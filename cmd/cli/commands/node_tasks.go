@@ -7,8 +7,12 @@ import (
 
 	"bufio"
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"os/signal"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gosuri/uiprogress"
 	"github.com/sonm-io/core/cmd/cli/task_config"
@@ -30,6 +34,27 @@ func init() {
 
 	taskPullCmd.Flags().StringVar(&taskPullOutput, "output", "", "file to output")
 
+	taskStatusCmd.Flags().BoolVar(&taskStatusWatch, "watch", false, "Refresh the status until the task finishes")
+	taskStatusCmd.Flags().DurationVar(&taskStatusInterval, "interval", 2*time.Second, "Refresh interval for --watch")
+	commandTimeouts[taskStatusCmd] = func(timeout time.Duration) time.Duration {
+		// Watching never ends by itself, so the timeout bounds each request
+		// only.
+		if taskStatusWatch {
+			return 0
+		}
+
+		return timeout
+	}
+	commandTimeouts[taskLogsCmd] = func(timeout time.Duration) time.Duration {
+		// Following never ends by itself, so the timeout makes sense only
+		// for fetching the tail.
+		if follow {
+			return 0
+		}
+
+		return timeout
+	}
+
 	taskRootCmd.AddCommand(
 		taskListCmd,
 		taskStartCmd,
@@ -41,7 +66,11 @@ func init() {
 	)
 }
 
-var taskPullOutput string
+var (
+	taskPullOutput     string
+	taskStatusWatch    bool
+	taskStatusInterval time.Duration
+)
 
 var taskRootCmd = &cobra.Command{
 	Use:   "tasks",
@@ -86,7 +115,12 @@ var taskStartCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		dealID := args[0]
+		dealID, err := parseDealID(args[0])
+		if err != nil {
+			showError(cmd, "Invalid deal id", err)
+			os.Exit(1)
+		}
+
 		taskFile := args[1]
 
 		taskDef, err := task_config.LoadConfig(taskFile)
@@ -133,6 +167,33 @@ var taskStatusCmd = &cobra.Command{
 
 		hubAddr := args[0]
 		taskID := args[1]
+
+		if taskStatusWatch {
+			ctx, cancel := context.WithCancel(commandCtx)
+			defer cancel()
+
+			go func() {
+				c := make(chan os.Signal, 1)
+				signal.Notify(c, os.Interrupt)
+				defer signal.Stop(c)
+
+				select {
+				case <-c:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+
+			status := func() (*pb.TaskStatusReply, error) {
+				return node.Status(taskID, hubAddr)
+			}
+			if err := watchTaskStatus(ctx, cmd, taskID, status, taskStatusInterval); err != nil {
+				showError(cmd, "Cannot get task status", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		status, err := node.Status(taskID, hubAddr)
 		if err != nil {
 			showError(cmd, "Cannot get task status", err)
@@ -143,6 +204,42 @@ var taskStatusCmd = &cobra.Command{
 	},
 }
 
+// watchTaskStatus prints the task status every interval until the task
+// finishes or the context is canceled, which is not treated as an error.
+// The screen is redrawn in simple mode, while JSON objects are streamed one
+// per line.
+func watchTaskStatus(ctx context.Context, cmd *cobra.Command, id string, status func() (*pb.TaskStatusReply, error), interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		reply, err := status()
+		if err != nil {
+			if ctx.Err() == context.Canceled {
+				return nil
+			}
+			return err
+		}
+
+		if isSimpleFormat() && formatFlag == "" {
+			// Move the cursor home and clear the screen.
+			cmd.Print("\x1b[H\x1b[2J")
+		}
+		printTaskStatus(cmd, id, reply)
+
+		switch reply.GetStatus() {
+		case pb.TaskStatusReply_FINISHED, pb.TaskStatusReply_BROKEN:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
 var taskLogsCmd = &cobra.Command{
 	Use:    "logs <hub_addr> <task_id>",
 	Short:  "Retrieve task logs",
@@ -158,6 +255,7 @@ var taskLogsCmd = &cobra.Command{
 		hubAddr := args[0]
 		taskID := args[1]
 		req := &pb.TaskLogsRequest{
+			Type:          convertLogType(logType),
 			Id:            taskID,
 			HubAddr:       hubAddr,
 			Since:         since,
@@ -167,28 +265,253 @@ var taskLogsCmd = &cobra.Command{
 			Details:       details,
 		}
 
-		logClient, err := node.Logs(req)
-		if err != nil {
+		ctx, cancel := context.WithCancel(commandCtx)
+		defer cancel()
+
+		go func() {
+			c := make(chan os.Signal, 1)
+			signal.Notify(c, os.Interrupt)
+			defer signal.Stop(c)
+
+			select {
+			case <-c:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		if err := followTaskLogs(ctx, cmd, node.Logs, req); err != nil {
 			showError(cmd, "Cannot get task logs", err)
 			os.Exit(1)
 		}
+	},
+}
 
-		for {
-			chunk, err := logClient.Recv()
-			if err == io.EOF {
-				return
-			}
+// taskLogsReconnectDelay is the pause before reopening a broken log stream.
+var taskLogsReconnectDelay = time.Second
+
+type taskLogsOpener func(ctx context.Context, req *pb.TaskLogsRequest) (pb.TaskManagement_LogsClient, error)
+
+// followTaskLogs prints task logs until they end or the context is canceled.
+// When following, a broken stream is reopened since the last line received,
+// so nothing logged while reconnecting is lost and nothing printed already is
+// repeated.
+func followTaskLogs(ctx context.Context, cmd *cobra.Command, open taskLogsOpener, req *pb.TaskLogsRequest) error {
+	printer := newTaskLogsPrinter(cmd)
+	defer printer.flush()
+
+	var sink taskLogsSink = printer
+	var cursor *taskLogsCursor
+	if req.Follow {
+		cursor = newTaskLogsCursor(printer, req.AddTimestamps)
+		defer cursor.flush()
+
+		// Timestamps tell where to resume from, the cursor strips them again
+		// if they were not asked for.
+		timestampedReq := *req
+		timestampedReq.AddTimestamps = true
+		req = &timestampedReq
+		sink = cursor
+	}
+
+	for {
+		logClient, err := open(ctx, req)
+		if err == nil {
+			err = streamTaskLogs(ctx, sink, logClient)
+		}
+		if err == nil || !req.Follow {
+			return err
+		}
+		if ctx.Err() == context.Canceled {
+			return nil
+		}
 
-			if err != nil {
-				if err != nil {
-					showError(cmd, "Cannot fetch log chunk", err)
-					os.Exit(1)
-				}
+		if isSimpleFormat() {
+			fmt.Fprintf(cmd.OutOrStderr(), "Warning: log stream broken, reconnecting: %v\r\n", err)
+		}
+
+		req = cursor.resume(req)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(taskLogsReconnectDelay):
+		}
+	}
+}
+
+// taskLogsSink consumes log chunks as they arrive.
+type taskLogsSink interface {
+	printTaskLogs(chunk []byte)
+}
+
+// streamTaskLogs prints log chunks as they arrive until the stream ends or
+// the context is canceled, which is not treated as an error.
+func streamTaskLogs(ctx context.Context, sink taskLogsSink, logClient pb.TaskManagement_LogsClient) error {
+	for {
+		chunk, err := logClient.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			if ctx.Err() == context.Canceled {
+				return nil
 			}
+			return err
+		}
+
+		sink.printTaskLogs(chunk.Data)
+	}
+}
+
+// taskLogsHeaderSize is the size of the header Docker puts before each line
+// when multiplexing stdout and stderr of a container without a TTY.
+const taskLogsHeaderSize = 8
+
+// taskLogsCursor remembers the timestamp of the last log line received, so
+// that a followed stream can be reopened right from it. Docker includes lines
+// logged at the "since" moment, hence as many lines with that timestamp as
+// were printed already are skipped after reopening.
+type taskLogsCursor struct {
+	printer    *taskLogsPrinter
+	timestamps bool
+	pending    []byte
+
+	last      time.Time
+	lastCount int
+	resuming  bool
+	skip      int
+}
 
-			cmd.Print(string(chunk.Data))
+func newTaskLogsCursor(printer *taskLogsPrinter, timestamps bool) *taskLogsCursor {
+	return &taskLogsCursor{printer: printer, timestamps: timestamps}
+}
+
+// printTaskLogs prints every complete line of the chunk, holding back the
+// incomplete one until the rest of it arrives.
+func (c *taskLogsCursor) printTaskLogs(chunk []byte) {
+	c.pending = append(c.pending, chunk...)
+	for {
+		header, line, rest, ok := nextTaskLogLine(c.pending)
+		if !ok {
+			return
 		}
-	},
+
+		c.pending = rest
+		c.printLine(header, line)
+	}
+}
+
+// nextTaskLogLine cuts the first complete line off the data, along with its
+// multiplexing header if there is one.
+func nextTaskLogLine(data []byte) (header, line, rest []byte, ok bool) {
+	// Multiplexed lines start with the stream number, 0 to 2, while
+	// timestamped ones start with a digit.
+	if len(data) > 0 && data[0] <= 2 {
+		if len(data) < taskLogsHeaderSize {
+			return nil, nil, nil, false
+		}
+
+		size := taskLogsHeaderSize + int(binary.BigEndian.Uint32(data[4:taskLogsHeaderSize]))
+		if len(data) < size {
+			return nil, nil, nil, false
+		}
+
+		return data[:taskLogsHeaderSize], data[taskLogsHeaderSize:size], data[size:], true
+	}
+
+	pos := bytes.IndexByte(data, '\n')
+	if pos < 0 {
+		return nil, nil, nil, false
+	}
+
+	return nil, data[:pos+1], data[pos+1:], true
+}
+
+func (c *taskLogsCursor) printLine(header, line []byte) {
+	pos := bytes.IndexByte(line, ' ')
+	if pos < 0 {
+		c.emit(header, line)
+		return
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, string(line[:pos]))
+	if err != nil {
+		c.emit(header, line)
+		return
+	}
+
+	if c.resuming {
+		if timestamp.Before(c.last) {
+			return
+		}
+		if timestamp.Equal(c.last) && c.skip > 0 {
+			c.skip--
+			return
+		}
+		c.resuming = false
+	}
+
+	switch {
+	case timestamp.Equal(c.last):
+		c.lastCount++
+	case timestamp.After(c.last):
+		c.last = timestamp
+		c.lastCount = 1
+	}
+
+	if !c.timestamps {
+		line = line[pos+1:]
+	}
+	c.emit(header, line)
+}
+
+// emit passes the line on to the printer, fixing the size in its header if
+// the timestamp was stripped.
+func (c *taskLogsCursor) emit(header, line []byte) {
+	if header != nil {
+		frame := make([]byte, taskLogsHeaderSize, taskLogsHeaderSize+len(line))
+		copy(frame, header)
+		binary.BigEndian.PutUint32(frame[4:], uint32(len(line)))
+		line = append(frame, line...)
+	}
+
+	c.printer.printTaskLogs(line)
+}
+
+// resume returns the request to reopen the stream with. The incomplete line
+// is dropped, since the reopened stream repeats it in whole.
+func (c *taskLogsCursor) resume(req *pb.TaskLogsRequest) *pb.TaskLogsRequest {
+	c.pending = nil
+	if c.last.IsZero() {
+		return req
+	}
+
+	c.resuming = true
+	c.skip = c.lastCount
+
+	resumeReq := *req
+	resumeReq.Since = fmt.Sprintf("%d.%09d", c.last.Unix(), c.last.Nanosecond())
+	resumeReq.Tail = "all"
+	return &resumeReq
+}
+
+// flush prints the incomplete line left at the end of logs.
+func (c *taskLogsCursor) flush() {
+	if len(c.pending) > 0 {
+		c.printLine(nil, c.pending)
+		c.pending = nil
+	}
+}
+
+func convertLogType(s string) pb.TaskLogsRequest_Type {
+	id, ok := pb.TaskLogsRequest_Type_value[strings.ToUpper(s)]
+	if !ok {
+		return pb.TaskLogsRequest_BOTH
+	}
+
+	return pb.TaskLogsRequest_Type(id)
 }
 
 var taskStopCmd = &cobra.Command{
@@ -221,11 +544,15 @@ var taskPullCmd = &cobra.Command{
 	PreRun: loadKeyStoreWrapper,
 	Args:   cobra.MinimumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		dealID := args[0]
+		dealID, err := parseDealID(args[0])
+		if err != nil {
+			showError(cmd, "Invalid deal id", err)
+			os.Exit(1)
+		}
+
 		taskID := args[1]
 
 		var wr io.Writer
-		var err error
 		if taskPullOutput == "" {
 			wr = os.Stdout
 		} else {
@@ -321,7 +648,12 @@ var taskPushCmd = &cobra.Command{
 	PreRun: loadKeyStoreWrapper,
 	Args:   cobra.MinimumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		dealID := args[0]
+		dealID, err := parseDealID(args[0])
+		if err != nil {
+			showError(cmd, "Invalid deal id", err)
+			os.Exit(1)
+		}
+
 		path := args[1]
 
 		file, err := os.Open(path)
@@ -400,7 +732,7 @@ var taskPushCmd = &cobra.Command{
 							os.Exit(1)
 						}
 
-						showJSON(cmd, map[string]interface{}{"status": status})
+						showJSON(cmd, "task_push", map[string]interface{}{"status": status})
 						return
 					}
 				}
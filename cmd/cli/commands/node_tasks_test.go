@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type fakeLogsClient struct {
+	grpc.ClientStream
+	chunks []string
+	err    error
+}
+
+func (f *fakeLogsClient) Recv() (*pb.TaskLogsChunk, error) {
+	if len(f.chunks) == 0 {
+		return nil, f.err
+	}
+
+	chunk := f.chunks[0]
+	f.chunks = f.chunks[1:]
+	return &pb.TaskLogsChunk{Data: []byte(chunk)}, nil
+}
+
+func TestStreamTaskLogs(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	stream := &fakeLogsClient{chunks: []string{"line 1\n", "line 2\n"}, err: io.EOF}
+	err := streamTaskLogs(context.Background(), newTaskLogsPrinter(rootCmd), stream)
+	assert.NoError(t, err)
+	assert.Equal(t, "line 1\nline 2\n", buf.String())
+}
+
+func TestStreamTaskLogsError(t *testing.T) {
+	initRootCmd(t, config.OutputModeSimple)
+
+	stream := &fakeLogsClient{chunks: []string{"line 1\n"}, err: errors.New("connection reset")}
+	err := streamTaskLogs(context.Background(), newTaskLogsPrinter(rootCmd), stream)
+	assert.EqualError(t, err, "connection reset")
+}
+
+func TestStreamTaskLogsInterrupted(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	stream := &fakeLogsClient{chunks: []string{"line 1\n"}, err: context.Canceled}
+	err := streamTaskLogs(ctx, newTaskLogsPrinter(rootCmd), stream)
+	assert.NoError(t, err)
+	assert.Equal(t, "line 1\n", buf.String())
+}
+
+func TestStreamTaskLogsJSON(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	stream := &fakeLogsClient{chunks: []string{"line 1\nli", "ne 2\r\n", "partial"}, err: io.EOF}
+	err := followTaskLogs(context.Background(), rootCmd, openFakeLogs(stream), &pb.TaskLogsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"log":"line 1"}`+"\r\n"+`{"log":"line 2"}`+"\r\n"+`{"log":"partial"}`+"\r\n", buf.String())
+}
+
+func openFakeLogs(streams ...*fakeLogsClient) taskLogsOpener {
+	return func(ctx context.Context, req *pb.TaskLogsRequest) (pb.TaskManagement_LogsClient, error) {
+		stream := streams[0]
+		streams = streams[1:]
+		return stream, nil
+	}
+}
+
+func TestFollowTaskLogsReconnects(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+	defer func(delay time.Duration) { taskLogsReconnectDelay = delay }(taskLogsReconnectDelay)
+	taskLogsReconnectDelay = time.Millisecond
+
+	var requests []*pb.TaskLogsRequest
+	streams := openFakeLogs(
+		&fakeLogsClient{
+			chunks: []string{"2018-01-15T10:00:00.000000001Z line 1\n", "2018-01-15T10:00:00.000000002Z line 2\n2018-01-15T10:00:00.0000"},
+			err:    errors.New("connection reset"),
+		},
+		&fakeLogsClient{
+			chunks: []string{"2018-01-15T10:00:00.000000002Z line 2\n", "2018-01-15T10:00:00.000000003Z line 3\n"},
+			err:    io.EOF,
+		},
+	)
+	open := func(ctx context.Context, req *pb.TaskLogsRequest) (pb.TaskManagement_LogsClient, error) {
+		requests = append(requests, req)
+		return streams(ctx, req)
+	}
+
+	err := followTaskLogs(context.Background(), rootCmd, open, &pb.TaskLogsRequest{Follow: true, Tail: "50"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"log":"line 1"}`+"\r\n"+`{"log":"line 2"}`+"\r\n"+`{"log":"line 3"}`+"\r\n", buf.String())
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "50", requests[0].Tail)
+	assert.True(t, requests[0].AddTimestamps)
+	assert.Equal(t, "all", requests[1].Tail)
+	assert.Equal(t, "1516010400.000000002", requests[1].Since)
+	assert.True(t, requests[1].Follow)
+}
+
+func TestFollowTaskLogsSameTimestampAtBoundary(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+	defer func(delay time.Duration) { taskLogsReconnectDelay = delay }(taskLogsReconnectDelay)
+	taskLogsReconnectDelay = time.Millisecond
+
+	open := openFakeLogs(
+		&fakeLogsClient{
+			chunks: []string{"2018-01-15T10:00:00Z a\n2018-01-15T10:00:00Z b\n"},
+			err:    errors.New("connection reset"),
+		},
+		&fakeLogsClient{
+			chunks: []string{"2018-01-15T10:00:00Z a\n2018-01-15T10:00:00Z b\n2018-01-15T10:00:00Z c\n"},
+			err:    io.EOF,
+		},
+	)
+
+	req := &pb.TaskLogsRequest{Follow: true, AddTimestamps: true}
+	err := followTaskLogs(context.Background(), rootCmd, open, req)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"log":"2018-01-15T10:00:00Z a"}`+"\r\n"+
+		`{"log":"2018-01-15T10:00:00Z b"}`+"\r\n"+
+		`{"log":"2018-01-15T10:00:00Z c"}`+"\r\n", buf.String())
+}
+
+func TestFollowTaskLogsMultiplexed(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	frame := func(stream byte, line string) string {
+		header := []byte{stream, 0, 0, 0, 0, 0, 0, byte(len(line))}
+		return string(header) + line
+	}
+
+	// Split the frame to check that it is reassembled.
+	data := frame(1, "2018-01-15T10:00:00Z out\n") + frame(2, "2018-01-15T10:00:01Z err\n")
+	stream := &fakeLogsClient{chunks: []string{data[:5], data[5:]}, err: io.EOF}
+
+	err := followTaskLogs(context.Background(), rootCmd, openFakeLogs(stream), &pb.TaskLogsRequest{Follow: true})
+	assert.NoError(t, err)
+	assert.Equal(t, frame(1, "out\n")+frame(2, "err\n"), buf.String())
+}
+
+func TestFollowTaskLogsWithoutFollowDoesNotReconnect(t *testing.T) {
+	initRootCmd(t, config.OutputModeSimple)
+
+	open := openFakeLogs(&fakeLogsClient{err: errors.New("connection reset")})
+	err := followTaskLogs(context.Background(), rootCmd, open, &pb.TaskLogsRequest{})
+	assert.EqualError(t, err, "connection reset")
+}
+
+func TestConvertLogType(t *testing.T) {
+	assert.Equal(t, pb.TaskLogsRequest_STDERR, convertLogType("stderr"))
+	assert.Equal(t, pb.TaskLogsRequest_STDOUT, convertLogType("STDOUT"))
+	assert.Equal(t, pb.TaskLogsRequest_BOTH, convertLogType("both"))
+	assert.Equal(t, pb.TaskLogsRequest_BOTH, convertLogType("garbage"))
+}
+
+func statusSequence(statuses ...pb.TaskStatusReply_Status) func() (*pb.TaskStatusReply, error) {
+	return func() (*pb.TaskStatusReply, error) {
+		reply := &pb.TaskStatusReply{Status: statuses[0], MinerID: "miner-1"}
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		return reply, nil
+	}
+}
+
+func TestWatchTaskStatusUntilFinished(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	status := statusSequence(pb.TaskStatusReply_SPAWNING, pb.TaskStatusReply_RUNNING, pb.TaskStatusReply_FINISHED)
+	err := watchTaskStatus(context.Background(), rootCmd, "task-1", status, time.Millisecond)
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Equal(t, 3, strings.Count(out, "\x1b[H\x1b[2J"))
+	assert.Contains(t, out, "SPAWNING")
+	assert.True(t, strings.HasSuffix(out, "  Last exit: 0\r\n"))
+}
+
+func TestWatchTaskStatusJSONStream(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	status := statusSequence(pb.TaskStatusReply_RUNNING, pb.TaskStatusReply_BROKEN)
+	err := watchTaskStatus(context.Background(), rootCmd, "task-1", status, time.Millisecond)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"status":"RUNNING"`)
+	assert.Contains(t, lines[1], `"status":"BROKEN"`)
+}
+
+func TestWatchTaskStatusCanceled(t *testing.T) {
+	initRootCmd(t, config.OutputModeJSON)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	polls := 0
+	status := func() (*pb.TaskStatusReply, error) {
+		if polls++; polls == 2 {
+			cancel()
+		}
+		return &pb.TaskStatusReply{Status: pb.TaskStatusReply_RUNNING}, nil
+	}
+
+	err := watchTaskStatus(ctx, rootCmd, "task-1", status, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, polls)
+}
+
+func TestWatchTaskStatusError(t *testing.T) {
+	initRootCmd(t, config.OutputModeJSON)
+
+	status := func() (*pb.TaskStatusReply, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	err := watchTaskStatus(context.Background(), rootCmd, "task-1", status, time.Millisecond)
+	assert.EqualError(t, err, "connection refused")
+}
@@ -1,12 +1,23 @@
 package commands
 
 import (
+	"errors"
 	"os"
 
+	"github.com/sonm-io/core/insonmnia/hardware"
 	"github.com/spf13/cobra"
 )
 
+var (
+	workerFingerprintFlag bool
+
+	errNoCapabilities = errors.New("worker reports no hardware capabilities")
+)
+
 func init() {
+	hubWorkerStatusCmd.Flags().BoolVar(&workerFingerprintFlag, "fingerprint", false,
+		"Show only the fingerprint of the worker hardware")
+
 	hubWorkerRootCmd.AddCommand(
 		hubWorkerListCmd,
 		hubWorkerStatusCmd,
@@ -58,6 +69,16 @@ var hubWorkerStatusCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if workerFingerprintFlag {
+			if status.GetCapabilities() == nil {
+				showError(cmd, "Cannot compute fingerprint", errNoCapabilities)
+				os.Exit(1)
+			}
+
+			printFingerprint(cmd, hardware.Fingerprint(status.GetCapabilities()))
+			return
+		}
+
 		printWorkerStatus(cmd, workerID, status)
 	},
 }
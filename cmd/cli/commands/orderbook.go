@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+)
+
+// orderBookSnapshot describes order-book state saved to disk.
+//
+// The format is the same as `market search` produces in JSON mode, so its
+// output can also be used as a snapshot, enveloped or not.
+type orderBookSnapshot struct {
+	Orders []*pb.Order `json:"orders"`
+}
+
+// orderPriceChange describes an order whose price has been changed between
+// two snapshots.
+type orderPriceChange struct {
+	ID       string `json:"id"`
+	OldPrice string `json:"old_price"`
+	NewPrice string `json:"new_price"`
+}
+
+// orderBookDiff describes the difference between two order-book snapshots.
+type orderBookDiff struct {
+	Added   []*pb.Order         `json:"added"`
+	Removed []*pb.Order         `json:"removed"`
+	Changed []*orderPriceChange `json:"changed"`
+}
+
+var errNotOrderBookSnapshot = errors.New("document has no orders, it is not an order-book snapshot")
+
+func (d *orderBookDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+func saveOrderBookSnapshot(path string, orders []*pb.Order) error {
+	data, err := json.Marshal(&orderBookSnapshot{Orders: orders})
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func loadOrderBookSnapshot(path string) ([]*pb.Order, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data = unwrapEnvelope(data)
+
+	// Documents of other kinds would be decoded as empty snapshots, making
+	// the diff report all orders as added or removed.
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["orders"]; !ok {
+		return nil, errNotOrderBookSnapshot
+	}
+
+	snapshot := &orderBookSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot.Orders, nil
+}
+
+// diffOrderBooks computes added, removed and price-changed orders keyed by
+// their IDs. The result follows the orders sequence of given snapshots.
+func diffOrderBooks(old, new []*pb.Order) *orderBookDiff {
+	diff := &orderBookDiff{
+		Added:   []*pb.Order{},
+		Removed: []*pb.Order{},
+		Changed: []*orderPriceChange{},
+	}
+
+	oldByID := make(map[string]*pb.Order, len(old))
+	for _, order := range old {
+		oldByID[order.GetId()] = order
+	}
+
+	newByID := make(map[string]*pb.Order, len(new))
+	for _, order := range new {
+		newByID[order.GetId()] = order
+
+		prev, ok := oldByID[order.GetId()]
+		if !ok {
+			diff.Added = append(diff.Added, order)
+			continue
+		}
+
+		if !equalPrices(prev.GetPrice(), order.GetPrice()) {
+			diff.Changed = append(diff.Changed, &orderPriceChange{
+				ID:       order.GetId(),
+				OldPrice: prev.GetPrice(),
+				NewPrice: order.GetPrice(),
+			})
+		}
+	}
+
+	for _, order := range old {
+		if _, ok := newByID[order.GetId()]; !ok {
+			diff.Removed = append(diff.Removed, order)
+		}
+	}
+
+	return diff
+}
+
+// equalPrices compares prices as token amounts, falling back to the raw
+// representation when any of them cannot be parsed.
+func equalPrices(a, b string) bool {
+	bigA, errA := util.ParseBigInt(a)
+	bigB, errB := util.ParseBigInt(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return bigA.Cmp(bigB) == 0
+}
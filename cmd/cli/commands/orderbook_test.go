@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffOrderBooksAdded(t *testing.T) {
+	old := []*pb.Order{{Id: "1", Price: "100"}}
+	new := []*pb.Order{{Id: "1", Price: "100"}, {Id: "2", Price: "200"}}
+
+	diff := diffOrderBooks(old, new)
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, "2", diff.Added[0].Id)
+	assert.Empty(t, diff.Removed)
+	assert.Empty(t, diff.Changed)
+}
+
+func TestDiffOrderBooksRemoved(t *testing.T) {
+	old := []*pb.Order{{Id: "1", Price: "100"}, {Id: "2", Price: "200"}}
+	new := []*pb.Order{{Id: "2", Price: "200"}}
+
+	diff := diffOrderBooks(old, new)
+	assert.Empty(t, diff.Added)
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, "1", diff.Removed[0].Id)
+	assert.Empty(t, diff.Changed)
+}
+
+func TestDiffOrderBooksPriceChanged(t *testing.T) {
+	old := []*pb.Order{{Id: "1", Price: "100"}, {Id: "2", Price: "0200"}}
+	new := []*pb.Order{{Id: "1", Price: "150"}, {Id: "2", Price: "200"}}
+
+	diff := diffOrderBooks(old, new)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	// "0200" and "200" are the same amount.
+	assert.Len(t, diff.Changed, 1)
+	assert.Equal(t, &orderPriceChange{ID: "1", OldPrice: "100", NewPrice: "150"}, diff.Changed[0])
+}
+
+func TestOrderBookSnapshotRoundTrip(t *testing.T) {
+	p := makeTestFilePath()
+	defer deleteTestYamlFile(p)
+
+	orders := []*pb.Order{{Id: "1", Price: "100", OrderType: pb.OrderType_ASK}}
+	assert.NoError(t, saveOrderBookSnapshot(p, orders))
+
+	loaded, err := loadOrderBookSnapshot(p)
+	assert.NoError(t, err)
+	assert.Equal(t, orders, loaded)
+}
+
+func TestPrintOrderBookDiffSimple(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printOrderBookDiff(rootCmd, diffOrderBooks(
+		[]*pb.Order{{Id: "1", Price: "100", OrderType: pb.OrderType_ASK}},
+		[]*pb.Order{{Id: "1", Price: "150", OrderType: pb.OrderType_ASK}},
+	))
+	assert.Equal(t, "Price changed:\r\n  ~ 1 | price = 100 -> 150\r\n", buf.String())
+}
+
+func TestMarketDiffSearchResults(t *testing.T) {
+	saveSearchResults := func(orders []*pb.Order) string {
+		buf := initRootCmd(t, config.OutputModeJSON)
+		printSearchResults(rootCmd, orders, nil)
+
+		p := makeTestFilePath()
+		require.NoError(t, ioutil.WriteFile(p, buf.Bytes(), 0600))
+		return p
+	}
+
+	old := saveSearchResults([]*pb.Order{{Id: "1", Price: "100", OrderType: pb.OrderType_ASK}})
+	defer deleteTestYamlFile(old)
+
+	envelopeFlag = true
+	defer func() { envelopeFlag = false }()
+	new := saveSearchResults([]*pb.Order{{Id: "1", Price: "150", OrderType: pb.OrderType_ASK}})
+	defer deleteTestYamlFile(new)
+	envelopeFlag = false
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	marketDiffCmd.SetOutput(buf)
+	defer marketDiffCmd.SetOutput(nil)
+
+	marketDiffCmd.Run(marketDiffCmd, []string{old, new})
+	assert.Equal(t, "Price changed:\r\n  ~ 1 | price = 100 -> 150\r\n", buf.String())
+}
+
+func TestLoadOrderBookSnapshotOtherDocument(t *testing.T) {
+	p := makeTestFilePath()
+	defer deleteTestYamlFile(p)
+
+	require.NoError(t, ioutil.WriteFile(p, []byte(`{"deals":[{"id":"1"}]}`), 0600))
+	_, err := loadOrderBookSnapshot(p)
+	assert.Equal(t, errNotOrderBookSnapshot, err)
+}
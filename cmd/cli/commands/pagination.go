@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+	"math"
+)
+
+// pagination describes which part of a list is printed, and is shown as is
+// in JSON mode.
+type pagination struct {
+	Offset int `json:"offset"`
+	// Limit is the page size, zero means no limit.
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
+
+// newPagination makes a page of the list of the given length. Offsets past
+// the end of the list make an empty page.
+func newPagination(total int, offset, limit uint64) *pagination {
+	if offset > uint64(total) {
+		offset = uint64(total)
+	}
+	if limit > math.MaxInt32 {
+		limit = 0
+	}
+
+	return &pagination{
+		Offset: int(offset),
+		Limit:  int(limit),
+		Total:  total,
+	}
+}
+
+// bounds returns bounds of the page within the list.
+func (p *pagination) bounds() (from, to int) {
+	from, to = p.Offset, p.Total
+	if p.Limit > 0 && from+p.Limit < to {
+		to = from + p.Limit
+	}
+
+	return from, to
+}
+
+func (p *pagination) footer() string {
+	from, to := p.bounds()
+	if from == to {
+		return fmt.Sprintf("Showing none of %d", p.Total)
+	}
+
+	return fmt.Sprintf("Showing %d–%d of %d", from+1, to, p.Total)
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginationBounds(t *testing.T) {
+	cases := []struct {
+		total         int
+		offset, limit uint64
+		from, to      int
+	}{
+		{total: 10, offset: 0, limit: 0, from: 0, to: 10},
+		{total: 10, offset: 0, limit: 3, from: 0, to: 3},
+		{total: 10, offset: 8, limit: 3, from: 8, to: 10},
+		{total: 10, offset: 10, limit: 3, from: 10, to: 10},
+		{total: 10, offset: 100, limit: 3, from: 10, to: 10},
+		{total: 10, offset: math.MaxUint64, limit: math.MaxUint64, from: 10, to: 10},
+		{total: 0, offset: 5, limit: 5, from: 0, to: 0},
+	}
+
+	for _, c := range cases {
+		from, to := newPagination(c.total, c.offset, c.limit).bounds()
+		assert.Equal(t, c.from, from, "%+v", c)
+		assert.Equal(t, c.to, to, "%+v", c)
+	}
+}
+
+func TestPrintSearchResultsPage(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	orders := []*pb.Order{
+		{Id: "1", OrderType: pb.OrderType_ASK, Price: "10"},
+		{Id: "2", OrderType: pb.OrderType_ASK, Price: "20"},
+		{Id: "3", OrderType: pb.OrderType_BID, Price: "30"},
+	}
+	page := newPagination(len(orders), 1, 1)
+	from, to := page.bounds()
+	printSearchResults(rootCmd, orders[from:to], page)
+
+	assert.Equal(t, "2) ASK 2 | price = 20\r\nShowing 2–2 of 3\r\n", buf.String())
+}
+
+func TestPrintSearchResultsPagePastEnd(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	page := newPagination(3, 5, 10)
+	printSearchResults(rootCmd, nil, page)
+
+	assert.Equal(t, "No matching orders found\r\nShowing none of 3\r\n", buf.String())
+}
+
+func TestPrintDealsListPageJSON(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	deals := []*pb.Deal{{Id: "1"}, {Id: "2"}, {Id: "3"}}
+	page := newPagination(len(deals), 0, 2)
+	from, to := page.bounds()
+	printDealsList(rootCmd, deals[from:to], page)
+
+	assert.Equal(t, `{"deals":[{"id":"1"},{"id":"2"}],"pagination":{"offset":0,"limit":2,"total":3}}`+"\r\n", buf.String())
+}
+
+func TestPrintDealsListPageTable(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeTable)
+
+	deals := []*pb.Deal{{Id: "1"}, {Id: "2"}, {Id: "3"}}
+	page := newPagination(len(deals), 2, 0)
+	from, to := page.bounds()
+	printDealsList(rootCmd, deals[from:to], page)
+
+	assert.Contains(t, buf.String(), formatDealID("3"))
+	assert.NotContains(t, buf.String(), formatDealID("2"))
+	assert.True(t, strings.HasSuffix(buf.String(), "\r\nShowing 3–3 of 3\r\n"))
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/sonm-io/core/util"
+)
+
+// Prices are integer amounts of the smallest token unit, which are hard to
+// read, so printers may show them scaled to a larger unit chosen by the
+// "--price-unit" flag. JSON and CSV output is never scaled.
+
+// priceUnits maps unit names to their decimal exponents.
+var priceUnits = map[string]int64{
+	"wei":   0,
+	"gwei":  9,
+	"ether": 18,
+}
+
+// checkPriceUnit validates the "--price-unit" flag value, an empty unit means
+// raw amounts.
+func checkPriceUnit(unit string) error {
+	if _, ok := priceUnits[unit]; unit != "" && !ok {
+		return fmt.Errorf("--price-unit must be one of: %s", strings.Join(sortedKeys(priceUnits), ", "))
+	}
+
+	return nil
+}
+
+// formatPrice scales the price to the unit given by the "--price-unit" flag
+// and labels it. Raw and malformed prices are printed as is.
+func formatPrice(price string) string {
+	exp, ok := priceUnits[priceUnitFlag]
+	if !ok {
+		return price
+	}
+
+	amount, err := util.ParseBigInt(price)
+	if err != nil {
+		return price
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil)
+	value := new(big.Rat).SetFrac(amount, divisor).FloatString(int(exp))
+	if strings.Contains(value, ".") {
+		value = strings.TrimRight(strings.TrimRight(value, "0"), ".")
+	}
+
+	return value + " " + priceUnitFlag
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func withPriceUnit(unit string) func() {
+	priceUnitFlag = unit
+	return func() { priceUnitFlag = "" }
+}
+
+func TestFormatPrice(t *testing.T) {
+	cases := []struct {
+		unit     string
+		price    string
+		expected string
+	}{
+		{"", "1500000000000000000", "1500000000000000000"},
+		{"wei", "1500000000000000000", "1500000000000000000 wei"},
+		{"gwei", "1500000000000000000", "1500000000 gwei"},
+		{"ether", "1500000000000000000", "1.5 ether"},
+		{"ether", "1000000000000000000", "1 ether"},
+		{"ether", "1", "0.000000000000000001 ether"},
+		{"ether", "0", "0 ether"},
+		{"gwei", "12345678901", "12.345678901 gwei"},
+		{"ether", "not a number", "not a number"},
+	}
+
+	for _, c := range cases {
+		restore := withPriceUnit(c.unit)
+		assert.Equal(t, c.expected, formatPrice(c.price), "%s in %q", c.price, c.unit)
+		restore()
+	}
+}
+
+func TestCheckPriceUnit(t *testing.T) {
+	assert.NoError(t, checkPriceUnit(""))
+	assert.NoError(t, checkPriceUnit("ether"))
+	assert.EqualError(t, checkPriceUnit("finney"), "--price-unit must be one of: ether, gwei, wei")
+}
+
+func TestPrintDealInfoPriceUnit(t *testing.T) {
+	defer withPriceUnit("ether")()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printDealInfo(rootCmd, &pb.Deal{Id: "42", Price: "2500000000000000000"})
+	assert.Contains(t, buf.String(), "Price:    2.5 ether\r\n")
+
+	// Machine-readable output keeps raw amounts.
+	buf = initRootCmd(t, config.OutputModeCSV)
+	printDealsList(rootCmd, []*pb.Deal{{Id: "42", Price: "2500000000000000000"}}, nil)
+	assert.Contains(t, buf.String(), ",2500000000000000000,")
+}
@@ -1,39 +1,60 @@
 package commands
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"syscall"
 	"time"
 
 	ds "github.com/c2h5oh/datasize"
-	"github.com/docker/go-connections/nat"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/mattn/go-isatty"
 	"github.com/sonm-io/core/insonmnia/node"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/spf13/cobra"
 )
 
 func printTaskStatus(cmd *cobra.Command, id string, taskStatus *pb.TaskStatusReply) {
+	view := BuildTaskStatusView(id, taskStatus)
+
+	if formatFlag != "" {
+		showTemplate(cmd, taskStatusJSON(view))
+		return
+	}
+
 	if isSimpleFormat() {
-		portsParsedOK := false
-		ports := nat.PortMap{}
-		if len(taskStatus.GetPorts()) > 0 {
-			err := json.Unmarshal([]byte(taskStatus.GetPorts()), &ports)
-			portsParsedOK = err == nil
+		if view.PortsParseError != nil {
+			fmt.Fprintf(cmd.OutOrStderr(), "Warning: cannot parse task ports: %v\r\n", view.PortsParseError)
 		}
 
-		cmd.Printf("Task %s (on %s):\r\n", id, taskStatus.MinerID)
-		cmd.Printf("  Image:  %s\r\n", taskStatus.GetImageName())
-		cmd.Printf("  Status: %s\r\n", taskStatus.GetStatus().String())
-		cmd.Printf("  Uptime: %s\r\n", time.Duration(taskStatus.GetUptime()).String())
+		cmd.Printf("Task %s (on %s):\r\n", view.ID, view.Miner)
+		cmd.Printf("  Image:  %s\r\n", view.Image)
+		cmd.Printf("  Status: %s\r\n", formatTaskStatus(taskStatus.GetStatus()))
+		cmd.Printf("  Uptime: %s\r\n", formatUptime(uint64(view.Uptime)))
+		if view.Restarts > 0 {
+			cmd.Printf("  Restarts: %d\r\n", view.Restarts)
+		}
+		if view.HasExitCode {
+			line := fmt.Sprintf("  Last exit: %s", formatExitCode(view.ExitCode))
+			if view.ExitCode != 0 {
+				line = colorRed(line)
+			}
+			cmd.Printf("%s\r\n", line)
+		}
 
-		if taskStatus.GetUsage() != nil {
+		if view.Usage != nil {
 			cmd.Println("  Resources:")
-			cmd.Printf("    CPU: %d\r\n", taskStatus.Usage.GetCpu().GetTotal())
-			cmd.Printf("    MEM: %s\r\n", ds.ByteSize(taskStatus.Usage.GetMemory().GetMaxUsage()).HR())
-			if taskStatus.GetUsage().GetNetwork() != nil {
+			cmd.Printf("    CPU: %d\r\n", view.Usage.GetCpu().GetTotal())
+			cmd.Printf("    MEM: %s\r\n", formatBytes(view.Usage.GetMemory().GetMaxUsage()))
+			if view.Usage.GetNetwork() != nil {
 				cmd.Printf("    NET:\r\n")
-				for i, net := range taskStatus.GetUsage().GetNetwork() {
+				network := view.Usage.GetNetwork()
+				for _, i := range sortedKeys(network) {
+					net := network[i]
 					cmd.Printf("      %s:\r\n", i)
 					cmd.Printf("        Tx/Rx bytes: %d/%d\r\n", net.TxBytes, net.RxBytes)
 					cmd.Printf("        Tx/Rx packets: %d/%d\r\n", net.TxPackets, net.RxPackets)
@@ -43,38 +64,285 @@ func printTaskStatus(cmd *cobra.Command, id string, taskStatus *pb.TaskStatusRep
 			}
 		}
 
-		if portsParsedOK && len(ports) > 0 {
+		if len(view.Ports) > 0 {
 			cmd.Printf("  Ports:\r\n")
-			for containerPort, host := range ports {
-				if len(host) > 0 {
-					cmd.Printf("    %s: %s:%s\r\n", containerPort, host[0].HostIP, host[0].HostPort)
+			for _, port := range view.Ports {
+				if port.Bound {
+					cmd.Printf("    %s: %s:%s\r\n", port.ContainerPort, port.HostIP, port.HostPort)
 				} else {
-					cmd.Printf("    %s\r\n", containerPort)
+					cmd.Printf("    %s\r\n", port.ContainerPort)
 				}
 			}
 		}
 	} else {
-		v := map[string]interface{}{
-			"id":     id,
-			"miner":  taskStatus.MinerID,
-			"status": taskStatus.Status.String(),
-			"image":  taskStatus.GetImageName(),
-			"ports":  taskStatus.GetPorts(),
-			"uptime": fmt.Sprintf("%d", time.Duration(taskStatus.GetUptime())),
+		showJSON(cmd, "task_status", taskStatusJSON(view))
+	}
+}
+
+// taskStatusJSON is the task status as printed in JSON mode.
+func taskStatusJSON(view *TaskStatusView) map[string]interface{} {
+	v := map[string]interface{}{
+		"id":     view.ID,
+		"miner":  view.Miner,
+		"status": view.Status,
+		"image":  view.Image,
+		"ports":  view.PortsRaw,
+		"uptime": fmt.Sprintf("%d", view.Uptime),
+	}
+	if view.Usage != nil {
+		v["cpu"] = fmt.Sprintf("%d", view.Usage.GetCpu().GetTotal())
+		v["mem"] = fmt.Sprintf("%d", view.Usage.GetMemory().GetMaxUsage())
+		v["net"] = view.Usage.GetNetwork()
+	}
+	if view.Restarts > 0 {
+		v["restarts"] = view.Restarts
+	}
+	if view.HasExitCode {
+		v["exit_code"] = view.ExitCode
+	}
+	if view.PortsParseError != nil {
+		v["ports_parse_error"] = view.PortsParseError.Error()
+	}
+
+	return v
+}
+
+// taskLogsPrinter prints task logs as they arrive. Chunks are not aligned
+// to lines, so in JSON mode the trailing incomplete line is held back until
+// the rest of it arrives or the logs end.
+type taskLogsPrinter struct {
+	cmd     *cobra.Command
+	pending []byte
+}
+
+func newTaskLogsPrinter(cmd *cobra.Command) *taskLogsPrinter {
+	return &taskLogsPrinter{cmd: cmd}
+}
+
+// printTaskLogs prints the chunk as is in simple mode, and each complete
+// line as a {"log": "..."} object in JSON mode.
+func (p *taskLogsPrinter) printTaskLogs(chunk []byte) {
+	if isSimpleFormat() {
+		p.cmd.OutOrStdout().Write(chunk)
+		return
+	}
+
+	p.pending = append(p.pending, chunk...)
+	for {
+		pos := bytes.IndexByte(p.pending, '\n')
+		if pos < 0 {
+			return
 		}
-		if taskStatus.GetUsage() != nil {
-			v["cpu"] = fmt.Sprintf("%d", taskStatus.GetUsage().GetCpu().GetTotal())
-			v["mem"] = fmt.Sprintf("%d", taskStatus.GetUsage().GetMemory().GetMaxUsage())
-			v["net"] = taskStatus.GetUsage().GetNetwork()
+
+		p.printLine(p.pending[:pos])
+		p.pending = p.pending[pos+1:]
+	}
+}
+
+// flush prints the incomplete line left at the end of logs.
+func (p *taskLogsPrinter) flush() {
+	if len(p.pending) > 0 {
+		p.printLine(p.pending)
+		p.pending = nil
+	}
+}
+
+func (p *taskLogsPrinter) printLine(line []byte) {
+	showJSON(p.cmd, "task_log", map[string]string{"log": strings.TrimSuffix(string(line), "\r")})
+}
+
+// hasExitCode reports whether the task has exited at least once, so its
+// exit code makes sense to show.
+func hasExitCode(taskStatus *pb.TaskStatusReply) bool {
+	switch taskStatus.GetStatus() {
+	case pb.TaskStatusReply_FINISHED, pb.TaskStatusReply_BROKEN:
+		return true
+	}
+
+	return taskStatus.GetExitCode() != 0
+}
+
+// formatBytes renders the given size in human-readable form, keeping as
+// many decimal places as set by the "--byte-precision" flag.
+func formatBytes(size uint64) string {
+	precision := bytePrecisionFlag
+	if precision < 0 {
+		precision = 0
+	}
+
+	b := ds.ByteSize(size)
+	switch {
+	case b > ds.EB:
+		return fmt.Sprintf("%.*f EB", precision, b.EBytes())
+	case b > ds.PB:
+		return fmt.Sprintf("%.*f PB", precision, b.PBytes())
+	case b > ds.TB:
+		return fmt.Sprintf("%.*f TB", precision, b.TBytes())
+	case b > ds.GB:
+		return fmt.Sprintf("%.*f GB", precision, b.GBytes())
+	case b > ds.MB:
+		return fmt.Sprintf("%.*f MB", precision, b.MBytes())
+	case b > ds.KB:
+		return fmt.Sprintf("%.*f KB", precision, b.KBytes())
+	default:
+		return fmt.Sprintf("%d B", b)
+	}
+}
+
+// formatUptime renders uptime reported in nanoseconds. Both hub and task
+// uptimes are rounded to seconds, which is precise enough to read.
+func formatUptime(uptime uint64) string {
+	return humanizeDuration(time.Duration(uptime))
+}
+
+// humanizeDuration renders the duration rounded to seconds, omitting zero
+// components, e.g. "2h" or "3m 12s".
+func humanizeDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < 0 {
+		return "-" + humanizeDuration(-d)
+	}
+	if d == 0 {
+		return "0s"
+	}
+
+	var parts []string
+	for _, unit := range []struct {
+		duration time.Duration
+		suffix   string
+	}{{time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}} {
+		if n := d / unit.duration; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit.suffix))
+			d -= n * unit.duration
 		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// formatTimestamp renders the timestamp in the time zone chosen by the
+// "--timezone" flag.
+func formatTimestamp(ts *pb.Timestamp) string {
+	return time.Unix(ts.GetSeconds(), int64(ts.GetNanos())).In(timeLocation).Format(time.RFC3339)
+}
+
+// orUnknown replaces absent values with "unknown" to avoid gaps in the
+// output.
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+
+	return s
+}
+
+// sortedKeys returns keys of the given map with string keys in ascending
+// order. Printers iterate maps through it to keep the output deterministic.
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, key.String())
+	}
+	sort.Strings(result)
 
-		showJSON(cmd, v)
+	return result
+}
+
+// colorEnabled reports whether the output may be colored. Unless forced by
+// the "--color" flag, stdout must be a terminal and NO_COLOR must not be
+// set. Overridden in tests.
+var colorEnabled = func() bool {
+	switch colorModeFlag {
+	case colorModeAlways:
+		return true
+	case colorModeNever:
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+func colorize(code, s string) string {
+	if !colorEnabled() {
+		return s
+	}
+
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+// colorRed paints the given string red when the output is colored.
+func colorRed(s string) string {
+	return colorize("31", s)
+}
+
+// formatDealStatus decorates the deal status with a symbol and a color,
+// keeping it as is for plain output.
+func formatDealStatus(status pb.DealStatus) string {
+	if !colorEnabled() {
+		return status.String()
+	}
+
+	switch status {
+	case pb.DealStatus_ACCEPTED:
+		return colorize("32", "✓ "+status.String())
+	case pb.DealStatus_PENDING:
+		return colorize("33", "⏳ "+status.String())
+	case pb.DealStatus_CLOSED:
+		return colorize("31", "✗ "+status.String())
+	default:
+		return status.String()
+	}
+}
+
+// formatTaskStatus colors the task status: green for running tasks, red
+// for broken ones and yellow for those still starting.
+func formatTaskStatus(status pb.TaskStatusReply_Status) string {
+	switch status {
+	case pb.TaskStatusReply_RUNNING:
+		return colorize("32", status.String())
+	case pb.TaskStatusReply_BROKEN:
+		return colorize("31", status.String())
+	case pb.TaskStatusReply_SPOOLING, pb.TaskStatusReply_SPAWNING:
+		return colorize("33", status.String())
+	default:
+		return status.String()
 	}
 }
 
+// formatProcessingStatus colors the status of an order handler: green when
+// done, red when failed and yellow while in progress.
+func formatProcessingStatus(status uint32) string {
+	s := node.HandlerStatusString(uint8(status))
+	switch status {
+	case node.HandlerStatusDone:
+		return colorize("32", s)
+	case node.HandlerStatusFailed:
+		return colorize("31", s)
+	default:
+		return colorize("33", s)
+	}
+}
+
+// formatExitCode appends the name of the killing signal for codes
+// reported by the shell as 128+N.
+func formatExitCode(code int32) string {
+	if code > 128 {
+		return fmt.Sprintf("%d (%s)", code, syscall.Signal(code-128))
+	}
+
+	return fmt.Sprintf("%d", code)
+}
+
 func printNodeTaskStatus(cmd *cobra.Command, tasksMap map[string]*pb.TaskListReply_TaskInfo) {
 	if isSimpleFormat() {
-		for worker, tasks := range tasksMap {
+		for _, worker := range sortedKeys(tasksMap) {
+			tasks := tasksMap[worker]
 			if len(tasks.GetTasks()) == 0 {
 				cmd.Printf("Worker \"%s\" has no tasks\r\n", worker)
 				continue
@@ -82,50 +350,113 @@ func printNodeTaskStatus(cmd *cobra.Command, tasksMap map[string]*pb.TaskListRep
 
 			cmd.Printf("Worker \"%s\":\r\n", worker)
 			i := 1
-			for ID, status := range tasks.GetTasks() {
-				up := time.Duration(status.GetUptime())
+			for _, ID := range sortedKeys(tasks.GetTasks()) {
+				status := tasks.GetTasks()[ID]
 				cmd.Printf("  %d) %s \r\n     %s  %s (up: %v)\r\n",
-					i, ID, status.Status.String(), status.ImageName, up.String())
+					i, ID, status.Status.String(), status.ImageName, formatUptime(status.GetUptime()))
 				i++
 			}
 		}
 	} else {
-		showJSON(cmd, tasksMap)
+		showJSON(cmd, "task_list", tasksMap)
 	}
 }
 
 func printWorkerList(cmd *cobra.Command, lr *pb.ListReply) {
+	if quietFlag {
+		showIDs(cmd, sortedKeys(lr.Info))
+		return
+	}
+
+	summary := summarizeWorkers(lr)
 	if isSimpleFormat() {
 		if len(lr.Info) == 0 {
 			cmd.Printf("No workers connected\r\n")
 			return
 		}
 
-		for addr, meta := range lr.Info {
-			cmd.Printf("Worker: %s", addr)
+		if isTableFormat() {
+			printWorkerTable(cmd, lr)
+		} else {
+			for _, addr := range sortedKeys(lr.Info) {
+				meta := lr.Info[addr]
+				cmd.Printf("Worker: %s", addr)
 
-			taskCount := len(meta.Values)
-			if taskCount == 0 {
-				cmd.Printf("\t\tIdle\r\n")
-			} else {
-				cmd.Printf("\t\t%d active task(s)\r\n", taskCount)
+				taskCount := len(meta.Values)
+				if taskCount == 0 {
+					cmd.Printf("\t\tIdle\r\n")
+				} else {
+					cmd.Printf("\t\t%d active task(s)\r\n", taskCount)
+				}
 			}
 		}
+
+		cmd.Printf("Total: %d worker(s), %d active task(s), %d idle\r\n",
+			summary.Workers, summary.ActiveTasks, summary.Idle)
 	} else {
-		showJSON(cmd, lr)
+		showJSON(cmd, "worker_list", map[string]interface{}{"info": lr.Info, "summary": summary})
 	}
 }
 
+// workersSummary is the aggregate capacity of the listed workers.
+type workersSummary struct {
+	Workers     int `json:"workers"`
+	ActiveTasks int `json:"active_tasks"`
+	Idle        int `json:"idle"`
+}
+
+func summarizeWorkers(lr *pb.ListReply) *workersSummary {
+	summary := &workersSummary{Workers: len(lr.GetInfo())}
+	for _, meta := range lr.GetInfo() {
+		summary.ActiveTasks += len(meta.GetValues())
+		if len(meta.GetValues()) == 0 {
+			summary.Idle++
+		}
+	}
+
+	return summary
+}
+
+func printWorkerTable(cmd *cobra.Command, lr *pb.ListReply) {
+	w := newTable(cmd)
+	fmt.Fprintf(w, "ADDRESS\tTASKS\tSTATE\r\n")
+	for _, addr := range sortedKeys(lr.Info) {
+		taskCount := len(lr.Info[addr].Values)
+		state := "Idle"
+		if taskCount > 0 {
+			state = "Busy"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\r\n", addr, taskCount, state)
+	}
+	w.Flush()
+}
+
+// printCpuInfo prints CPUs of the Worker. Hardware probes on the Worker's
+// side may fail independently, so any of the Capabilities sub-fields may be
+// missing, which this and the neighbouring printers report as unknown.
 func printCpuInfo(cmd *cobra.Command, cap *pb.Capabilities) {
-	for i, cpu := range cap.Cpu {
+	if len(cap.GetCpu()) == 0 {
+		cmd.Printf("    CPU: unknown\r\n")
+		return
+	}
+
+	for i, cpu := range cap.GetCpu() {
+		if cpu == nil {
+			cmd.Printf("    CPU%d: unknown\r\n", i)
+			continue
+		}
 		cmd.Printf("    CPU%d: %d x %s\r\n", i, cpu.GetCores(), cpu.GetModelName())
 	}
 }
 
 func printGpuInfo(cmd *cobra.Command, cap *pb.Capabilities) {
-	if len(cap.Gpu) > 0 {
-		for i, gpu := range cap.Gpu {
-			cmd.Printf("    GPU%d: %s %s\r\n", i, gpu.VendorName, gpu.Name)
+	if len(cap.GetGpu()) > 0 {
+		for i, gpu := range cap.GetGpu() {
+			if gpu == nil {
+				cmd.Printf("    GPU%d: unknown\r\n", i)
+				continue
+			}
+			cmd.Printf("    GPU%d: %s %s\r\n", i, gpu.GetVendorName(), gpu.GetName())
 		}
 	} else {
 		cmd.Println("    GPU: None")
@@ -133,12 +464,43 @@ func printGpuInfo(cmd *cobra.Command, cap *pb.Capabilities) {
 }
 
 func printMemInfo(cmd *cobra.Command, cap *pb.Capabilities) {
+	if cap.GetMem() == nil {
+		cmd.Printf("    RAM: unknown\r\n")
+		return
+	}
+
 	cmd.Println("    RAM:")
-	cmd.Printf("      Total: %s\r\n", ds.ByteSize(cap.Mem.GetTotal()).HR())
-	cmd.Printf("      Used:  %s\r\n", ds.ByteSize(cap.Mem.GetUsed()).HR())
+	cmd.Printf("      Total: %s\r\n", formatBytes(cap.GetMem().GetTotal()))
+	cmd.Printf("      Used:  %s\r\n", formatBytes(cap.GetMem().GetUsed()))
+}
+
+// workerStatusView is the worker status as printed in JSON mode.
+type workerStatusView struct {
+	*pb.InfoReply
+	GPUHealthWarning string `json:"gpu_health_warning,omitempty"`
+}
+
+// gpuHealthWarning tells when the worker reports fewer or more GPUs than
+// it advertises, which usually means a failed card. Without telemetry
+// nothing can be told.
+func gpuHealthWarning(metrics *pb.InfoReply) string {
+	telemetry := metrics.GetGpuTelemetry()
+	if telemetry == nil {
+		return ""
+	}
+
+	advertised := len(metrics.GetCapabilities().GetGpu())
+	reporting := int(telemetry.GetReportingCount())
+	if advertised == reporting {
+		return ""
+	}
+
+	return fmt.Sprintf("%d GPUs advertised, %d reporting", advertised, reporting)
 }
 
 func printWorkerStatus(cmd *cobra.Command, workerID string, metrics *pb.InfoReply) {
+	warning := gpuHealthWarning(metrics)
+
 	if isSimpleFormat() {
 		cmd.Printf("Worker \"%s\":\r\n", workerID)
 
@@ -149,82 +511,135 @@ func printWorkerStatus(cmd *cobra.Command, workerID string, metrics *pb.InfoRepl
 			printMemInfo(cmd, metrics.Capabilities)
 		}
 
+		if warning != "" {
+			cmd.Printf("  %s\r\n", colorize("33", "⚠ "+warning))
+		}
+
 		if len(metrics.GetUsage()) == 0 {
 			cmd.Println("  No active tasks")
 		} else {
 			cmd.Println("  Tasks:")
 			i := 1
-			for task := range metrics.Usage {
+			for _, task := range sortedKeys(metrics.Usage) {
 				cmd.Printf("    %d) %s\r\n", i, task)
 				i++
 			}
 		}
 	} else {
-		showJSON(cmd, metrics)
+		showJSON(cmd, "worker_status", &workerStatusView{InfoReply: metrics, GPUHealthWarning: warning})
+	}
+}
+
+func printFingerprint(cmd *cobra.Command, fingerprint string) {
+	if isSimpleFormat() {
+		cmd.Printf("Fingerprint: %s\r\n", fingerprint)
+	} else {
+		showJSON(cmd, "fingerprint", map[string]string{"fingerprint": fingerprint})
 	}
 }
 
 func printHubStatus(cmd *cobra.Command, stat *pb.HubStatusReply) {
 	if isSimpleFormat() {
 		cmd.Printf("Connected miners: %d\r\n", stat.MinerCount)
-		cmd.Printf("Uptime:           %s\r\n", (time.Second * time.Duration(stat.Uptime)).String())
-		cmd.Printf("Version:          %s %s\r\n", stat.Version, stat.Platform)
+		cmd.Printf("Uptime:           %s\r\n", formatUptime(stat.GetUptime()))
+		cmd.Printf("Version:          %s %s\r\n", orUnknown(stat.GetVersion()), orUnknown(stat.GetPlatform()))
 		cmd.Printf("Eth address:      %s\r\n", stat.EthAddr)
 	} else {
-		showJSON(cmd, stat)
+		showJSON(cmd, "hub_status", stat)
 	}
 }
 
-func printDeviceList(cmd *cobra.Command, devices *pb.DevicesReply) {
+func printLocatorStats(cmd *cobra.Command, stats *pb.LocatorStatsReply) {
+	if isSimpleFormat() {
+		cmd.Printf("Nodes:           %d\r\n", stats.GetNodes())
+		if stats.GetNodes() > 0 {
+			cmd.Printf("Oldest announce: %s\r\n", formatTimestamp(stats.GetOldestAnnounce()))
+			cmd.Printf("Newest announce: %s\r\n", formatTimestamp(stats.GetNewestAnnounce()))
+		}
+		cmd.Printf("Node TTL:        %s\r\n", time.Duration(stats.GetNodeTTLSeconds())*time.Second)
+	} else {
+		showJSON(cmd, "locator_stats", stats)
+	}
+}
+
+// printDeviceList prints devices sorted by their ids, limited to the given
+// class if deviceType is either "cpu" or "gpu".
+func printDeviceList(cmd *cobra.Command, devices *pb.DevicesReply, deviceType string) {
+	switch deviceType {
+	case deviceTypeCPU:
+		devices = &pb.DevicesReply{CPUs: devices.GetCPUs()}
+	case deviceTypeGPU:
+		devices = &pb.DevicesReply{GPUs: devices.GetGPUs()}
+	}
+
 	if isSimpleFormat() {
 		CPUs := devices.GetCPUs()
 		GPUs := devices.GetGPUs()
 
-		if len(CPUs) == 0 && len(GPUs) == 0 {
+		if deviceType == "" && len(CPUs) == 0 && len(GPUs) == 0 {
 			cmd.Printf("No devices detected.\r\n")
 			return
 		}
 
-		if len(CPUs) > 0 {
-			cmd.Printf("CPUs:\r\n")
-			for id, cpu := range CPUs {
-				cmd.Printf(" %s: %s\r\n", id, cpu.Device.ModelName)
+		if deviceType != deviceTypeGPU {
+			if len(CPUs) > 0 {
+				cmd.Printf("CPUs:\r\n")
+				for _, id := range sortedKeys(CPUs) {
+					cpu := CPUs[id]
+					cmd.Printf(" %s: %s\r\n", id, cpu.Device.ModelName)
+				}
+			} else {
+				cmd.Printf("No CPUs detected.\r\n")
 			}
-		} else {
-			cmd.Printf("No CPUs detected.\r\n")
 		}
 
-		if len(GPUs) > 0 {
-			cmd.Printf("GPUs:\r\n")
-			for id, gpu := range GPUs {
-				cmd.Printf(" %s: %s\r\n", id, gpu.Device.Name)
+		if deviceType != deviceTypeCPU {
+			if len(GPUs) > 0 {
+				cmd.Printf("GPUs:\r\n")
+				for _, id := range sortedKeys(GPUs) {
+					gpu := GPUs[id]
+					cmd.Printf(" %s: %s\r\n", id, gpu.Device.Name)
+				}
+			} else {
+				cmd.Printf("No GPUs detected.\r\n")
 			}
-		} else {
-			cmd.Printf("No GPUs detected.\r\n")
 		}
 	} else {
-		showJSON(cmd, devices)
+		showJSON(cmd, "device_list", devices)
 	}
 }
 
-func printDevicesProps(cmd *cobra.Command, props map[string]float64) {
+func printDevicesProps(cmd *cobra.Command, props *DeviceProps) {
 	if isSimpleFormat() {
-		for k, v := range props {
+		for _, k := range props.Keys() {
+			v, _ := props.Get(k)
 			cmd.Printf("%s = %f\r\n", k, v)
 		}
 	} else {
-		showJSON(cmd, props)
+		showJSON(cmd, "device_properties", props.Map())
 	}
 }
 
 func printWorkerAclList(cmd *cobra.Command, list *pb.GetRegisteredWorkersReply) {
 	if isSimpleFormat() {
-		for i, id := range list.GetIds() {
-			cmd.Printf("%d) %s\r\n", i+1, id.GetId())
+		// Hubs of older versions send ids only.
+		if len(list.GetWorkers()) == 0 {
+			for i, id := range list.GetIds() {
+				cmd.Printf("%d) %s\r\n", i+1, id.GetId())
+			}
+			return
 		}
 
+		for i, worker := range list.GetWorkers() {
+			if ts := worker.GetRegisteredAt(); ts != nil {
+				registeredAt := time.Unix(ts.GetSeconds(), int64(ts.GetNanos())).UTC()
+				cmd.Printf("%d) %s  registered at %s\r\n", i+1, worker.GetId().GetId(), registeredAt.Format(time.RFC3339))
+			} else {
+				cmd.Printf("%d) %s\r\n", i+1, worker.GetId().GetId())
+			}
+		}
 	} else {
-		showJSON(cmd, list)
+		showJSON(cmd, "worker_acl_list", list)
 	}
 }
 
@@ -237,7 +652,7 @@ func printTransactionInfo(cmd *cobra.Command, tx *types.Transaction) {
 		cmd.Printf("Gas:       %d\r\n", tx.Gas().Uint64())
 		cmd.Printf("Gas price: %d\r\n", tx.GasPrice().Uint64())
 	} else {
-		showJSON(cmd, convertTransactionInfo(tx))
+		showJSON(cmd, "transaction", convertTransactionInfo(tx))
 	}
 }
 
@@ -252,61 +667,176 @@ func convertTransactionInfo(tx *types.Transaction) map[string]interface{} {
 	}
 }
 
-func printSearchResults(cmd *cobra.Command, orders []*pb.Order) {
+// recordsCSVHeader are the columns of deals and orders exported as CSV, so
+// both can be loaded into the same spreadsheet.
+var recordsCSVHeader = []string{"id", "type", "price", "buyer", "supplier", "start", "end"}
+
+// printSearchResults prints found orders, with a page footer and metadata
+// unless the page is nil.
+func printSearchResults(cmd *cobra.Command, orders []*pb.Order, page *pagination) {
+	if quietFlag {
+		ids := make([]string, 0, len(orders))
+		for _, order := range orders {
+			ids = append(ids, order.GetId())
+		}
+
+		showIDs(cmd, ids)
+		return
+	}
+
 	if isSimpleFormat() {
-		if len(orders) == 0 {
-			cmd.Printf("No matching orders found")
+		if isCSVFormat() {
+			printOrdersCSV(cmd, orders)
 			return
 		}
 
+		if len(orders) == 0 {
+			cmd.Printf("No matching orders found\r\n")
+		}
+
+		offset := 0
+		if page != nil {
+			offset = page.Offset
+		}
+
 		for i, order := range orders {
-			cmd.Printf("%d) %s %s | price = %s\r\n", i+1, order.OrderType.String(), order.Id, order.Price)
+			cmd.Printf("%d) %s %s | price = %s\r\n", offset+i+1, order.OrderType.String(), order.Id, formatPrice(order.Price))
+		}
+
+		printPageFooter(cmd, page)
+	} else {
+		showJSON(cmd, "order_list", listJSON("orders", orders, page))
+	}
+}
+
+// printOrdersCSV exports orders, which have no start and end time.
+func printOrdersCSV(cmd *cobra.Command, orders []*pb.Order) {
+	rows := make([][]string, 0, len(orders))
+	for _, order := range orders {
+		rows = append(rows, []string{
+			order.GetId(),
+			order.GetOrderType().String(),
+			order.GetPrice(),
+			order.GetByuerID(),
+			order.GetSupplierID(),
+			"",
+			"",
+		})
+	}
+
+	showCSV(cmd, recordsCSVHeader, rows)
+}
+
+func printMatchExplanation(cmd *cobra.Command, explanations []*matchExplanation) {
+	if isSimpleFormat() {
+		for i, explanation := range explanations {
+			order := explanation.Order
+			cmd.Printf("%d) %s %s | price = %s\r\n", i+1, order.OrderType.String(), order.Id, formatPrice(order.Price))
+			if explanation.Matched() {
+				cmd.Printf("   All constraints satisfied\r\n")
+				continue
+			}
+
+			for _, check := range explanation.Failed() {
+				cmd.Printf("   %s: want %s, have %s\r\n", check.Dimension, check.Want, check.Have)
+			}
+		}
+	} else {
+		showJSON(cmd, "match_explanation", map[string]interface{}{"orders": explanations})
+	}
+}
+
+func printOrderBookDiff(cmd *cobra.Command, diff *orderBookDiff) {
+	if isSimpleFormat() {
+		if diff.Empty() {
+			cmd.Printf("No changes found\r\n")
+			return
+		}
+
+		if len(diff.Added) > 0 {
+			cmd.Printf("Added:\r\n")
+			for _, order := range diff.Added {
+				cmd.Printf("  + %s %s | price = %s\r\n", order.OrderType.String(), order.Id, formatPrice(order.Price))
+			}
+		}
+
+		if len(diff.Removed) > 0 {
+			cmd.Printf("Removed:\r\n")
+			for _, order := range diff.Removed {
+				cmd.Printf("  - %s %s | price = %s\r\n", order.OrderType.String(), order.Id, formatPrice(order.Price))
+			}
+		}
+
+		if len(diff.Changed) > 0 {
+			cmd.Printf("Price changed:\r\n")
+			for _, change := range diff.Changed {
+				cmd.Printf("  ~ %s | price = %s -> %s\r\n", change.ID, formatPrice(change.OldPrice), formatPrice(change.NewPrice))
+			}
 		}
 	} else {
-		showJSON(cmd, map[string]interface{}{"orders": orders})
+		showJSON(cmd, "order_book_diff", diff)
 	}
 }
 
+// noResourceInfo is printed instead of resources of malformed orders and
+// slots received from the server.
+const noResourceInfo = "(no resource info)"
+
 func printOrderDetails(cmd *cobra.Command, order *pb.Order) {
+	if formatFlag != "" {
+		showTemplate(cmd, order)
+		return
+	}
+
 	if isSimpleFormat() {
 		cmd.Printf("ID:             %s\r\n", order.Id)
 		cmd.Printf("Type:           %s\r\n", order.OrderType.String())
-		cmd.Printf("Price:          %s\r\n", order.Price)
+		cmd.Printf("Price:          %s\r\n", formatPrice(order.Price))
 
 		cmd.Printf("SupplierID:     %s\r\n", order.SupplierID)
-		cmd.Printf("SupplierRating: %d\r\n", order.Slot.SupplierRating)
+		cmd.Printf("SupplierRating: %d\r\n", order.GetSlot().GetSupplierRating())
 		cmd.Printf("BuyerID:        %s\r\n", order.ByuerID)
-		cmd.Printf("BuyerRating:    %d\r\n", order.Slot.BuyerRating)
+		cmd.Printf("BuyerRating:    %d\r\n", order.GetSlot().GetBuyerRating())
+
+		rs := order.GetSlot().GetResources()
+		if rs == nil {
+			cmd.Printf("Resources:      %s\r\n", noResourceInfo)
+			return
+		}
 
-		rs := order.Slot.Resources
 		cmd.Printf("Resources:\r\n")
 		cmd.Printf("  CPU:     %d\r\n", rs.CpuCores)
 		cmd.Printf("  GPU:     %d\r\n", rs.GpuCount)
-		cmd.Printf("  RAM:     %s\r\n", ds.ByteSize(rs.RamBytes).HR())
-		cmd.Printf("  Storage: %s\r\n", ds.ByteSize(rs.Storage).HR())
+		cmd.Printf("  RAM:     %s\r\n", formatBytes(rs.RamBytes))
+		cmd.Printf("  Storage: %s\r\n", formatBytes(rs.Storage))
 		cmd.Printf("  Network: %s\r\n", rs.NetworkType.String())
-		cmd.Printf("    In:   %s\r\n", ds.ByteSize(rs.NetTrafficIn).HR())
-		cmd.Printf("    Out:  %s\r\n", ds.ByteSize(rs.NetTrafficOut).HR())
+		cmd.Printf("    In:   %s\r\n", formatBytes(rs.NetTrafficIn))
+		cmd.Printf("    Out:  %s\r\n", formatBytes(rs.NetTrafficOut))
 	} else {
-		showJSON(cmd, order)
+		showJSON(cmd, "order", order)
 	}
 }
 
 func printProcessingOrders(cmd *cobra.Command, tasks *pb.GetProcessingReply) {
+	if quietFlag {
+		showIDs(cmd, sortedKeys(tasks.GetOrders()))
+		return
+	}
+
 	if isSimpleFormat() {
 		if len(tasks.GetOrders()) == 0 {
 			cmd.Printf("No processing orders\r\n")
 			return
 		}
 
-		for id, order := range tasks.GetOrders() {
-			t := time.Unix(order.Timestamp.Seconds, 0)
-			s := node.HandlerStatusString(uint8(order.Status))
-			cmd.Printf("%s %s %s %s\r\n", t, id, s, order.Extra)
+		for _, id := range sortedKeys(tasks.GetOrders()) {
+			order := tasks.GetOrders()[id]
+			t := time.Unix(order.Timestamp.Seconds, 0).In(timeLocation)
+			cmd.Printf("%s %s %s %s\r\n", t, id, formatProcessingStatus(order.Status), order.Extra)
 		}
 
 	} else {
-		showJSON(cmd, tasks)
+		showJSON(cmd, "processing_orders", tasks)
 	}
 }
 
@@ -318,65 +848,193 @@ func printAskList(cmd *cobra.Command, slots *pb.SlotsReply) {
 			return
 		}
 
-		for id, slot := range slots {
+		if isTableFormat() {
+			printAskTable(cmd, slots)
+			return
+		}
+
+		for _, id := range sortedKeys(slots) {
+			slot := slots[id]
 			cmd.Printf(" ID:  %s", id)
-			cmd.Printf(" CPU: %d Cores\r\n", slot.Resources.CpuCores)
-			cmd.Printf(" GPU: %d Devices\r\n", slot.Resources.GpuCount)
-			cmd.Printf(" RAM: %s\r\n", ds.ByteSize(slot.Resources.RamBytes).HR())
-			cmd.Printf(" Net: %s\r\n", slot.Resources.NetworkType.String())
-			cmd.Printf("     %s IN\r\n", ds.ByteSize(slot.Resources.NetTrafficIn).HR())
-			cmd.Printf("     %s OUT\r\n", ds.ByteSize(slot.Resources.NetTrafficOut).HR())
-
-			if slot.Geo != nil && slot.Geo.City != "" && slot.Geo.Country != "" {
-				cmd.Printf(" Geo: %s, %s\r\n", slot.Geo.City, slot.Geo.Country)
+
+			rs := slot.GetResources()
+			if rs == nil {
+				cmd.Printf(" %s\r\n", noResourceInfo)
+			} else {
+				cmd.Printf(" CPU: %d Cores\r\n", rs.CpuCores)
+				cmd.Printf(" GPU: %d Devices\r\n", rs.GpuCount)
+				cmd.Printf(" RAM: %s\r\n", formatBytes(rs.RamBytes))
+				cmd.Printf(" Net: %s\r\n", rs.NetworkType.String())
+				cmd.Printf("     %s IN\r\n", formatBytes(rs.NetTrafficIn))
+				cmd.Printf("     %s OUT\r\n", formatBytes(rs.NetTrafficOut))
+			}
+
+			if geo := formatSlotGeo(slot.GetGeo()); geo != "" {
+				cmd.Printf(" Geo: %s\r\n", geo)
 			}
 			cmd.Println("")
 		}
 	} else {
-		showJSON(cmd, slots)
+		showJSON(cmd, "ask_plan_list", slots)
+	}
+}
+
+func printAskTable(cmd *cobra.Command, slots map[string]*pb.Slot) {
+	w := newTable(cmd)
+	fmt.Fprintf(w, "ID\tCPU\tGPU\tRAM\tNET\tIN\tOUT\tGEO\r\n")
+	for _, id := range sortedKeys(slots) {
+		slot := slots[id]
+		rs := slot.GetResources()
+		if rs == nil {
+			fmt.Fprintf(w, "%s\t%s\t\t\t\t\t\t%s\r\n", id, noResourceInfo, formatSlotGeo(slot.GetGeo()))
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\r\n", id,
+			rs.CpuCores,
+			rs.GpuCount,
+			formatBytes(rs.RamBytes),
+			rs.NetworkType.String(),
+			formatBytes(rs.NetTrafficIn),
+			formatBytes(rs.NetTrafficOut),
+			formatSlotGeo(slot.GetGeo()))
+	}
+	w.Flush()
+}
+
+// formatSlotGeo formats the known parts of the location, e.g. "Moscow, RU"
+// or just "RU".
+func formatSlotGeo(geo *pb.Geo) string {
+	parts := make([]string, 0, 2)
+	for _, part := range []string{geo.GetCity(), geo.GetCountry()} {
+		if part != "" {
+			parts = append(parts, part)
+		}
 	}
+
+	return strings.Join(parts, ", ")
 }
 
 func printVersion(cmd *cobra.Command, v string) {
 	if isSimpleFormat() {
 		cmd.Printf("Version: %s\r\n", v)
 	} else {
-		showJSON(cmd, map[string]string{"version": v})
+		showJSON(cmd, "version", map[string]string{"version": v})
 	}
 
 }
 
-func printDealsList(cmd *cobra.Command, deals []*pb.Deal) {
+// printDealsList prints deals, with a page footer and metadata unless the
+// page is nil.
+func printDealsList(cmd *cobra.Command, deals []*pb.Deal, page *pagination) {
+	if quietFlag {
+		ids := make([]string, 0, len(deals))
+		for _, deal := range deals {
+			ids = append(ids, formatDealID(deal.GetId()))
+		}
+
+		showIDs(cmd, ids)
+		return
+	}
+
 	if isSimpleFormat() {
-		if len(deals) == 0 {
-			cmd.Println("No deals found")
+		if isCSVFormat() {
+			printDealsCSV(cmd, deals)
 			return
 		}
 
-		for _, deal := range deals {
-			printDealInfo(cmd, deal)
-			cmd.Println()
+		switch {
+		case len(deals) == 0:
+			cmd.Println("No deals found")
+		case isTableFormat():
+			printDealsTable(cmd, deals)
+		default:
+			for _, deal := range deals {
+				printDealInfo(cmd, deal)
+				cmd.Println()
+			}
 		}
+
+		printPageFooter(cmd, page)
 	} else {
-		showJSON(cmd, map[string]interface{}{"deals": deals})
+		showJSON(cmd, "deal_list", listJSON("deals", deals, page))
+	}
+}
+
+// printPageFooter tells which part of a non-empty list has been printed.
+func printPageFooter(cmd *cobra.Command, page *pagination) {
+	if page == nil || page.Total == 0 {
+		return
+	}
+
+	cmd.Printf("%s\r\n", page.footer())
+}
+
+// listJSON puts the list under the given key along with the page metadata
+// if any.
+func listJSON(key string, list interface{}, page *pagination) map[string]interface{} {
+	result := map[string]interface{}{key: list}
+	if page != nil {
+		result["pagination"] = page
 	}
 
+	return result
+}
+
+// printDealsTable prints deals one per row. Statuses are never colorized
+// here, because escape sequences would break the alignment.
+func printDealsTable(cmd *cobra.Command, deals []*pb.Deal) {
+	w := newTable(cmd)
+	fmt.Fprintf(w, "ID\tPRICE\tSTATUS\tBUYER\tSUPPLIER\tSTART\tEND\r\n")
+	for _, deal := range deals {
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\r\n",
+			formatDealID(deal.GetId()),
+			formatPrice(deal.GetPrice()),
+			deal.GetStatus().String(),
+			deal.GetBuyerID(),
+			deal.GetSupplierID(),
+			formatTimestamp(deal.GetStartTime()),
+			formatTimestamp(deal.GetEndTime()))
+	}
+	w.Flush()
+}
+
+// printDealsCSV exports deals with plain ids, as in JSON.
+func printDealsCSV(cmd *cobra.Command, deals []*pb.Deal) {
+	rows := make([][]string, 0, len(deals))
+	for _, deal := range deals {
+		rows = append(rows, []string{
+			deal.GetId(),
+			"DEAL",
+			deal.GetPrice(),
+			deal.GetBuyerID(),
+			deal.GetSupplierID(),
+			formatTimestamp(deal.GetStartTime()),
+			formatTimestamp(deal.GetEndTime()),
+		})
+	}
+
+	showCSV(cmd, recordsCSVHeader, rows)
 }
 
 func printDealInfo(cmd *cobra.Command, deal *pb.Deal) {
+	if formatFlag != "" {
+		showTemplate(cmd, deal)
+		return
+	}
+
 	if isSimpleFormat() {
-		start := time.Unix(deal.GetStartTime().GetSeconds(), int64(deal.GetStartTime().GetNanos()))
-		end := time.Unix(deal.GetEndTime().GetSeconds(), int64(deal.GetEndTime().GetNanos()))
 
-		cmd.Printf("ID:       %s\r\n", deal.GetId())
-		cmd.Printf("Price:    %s\r\n", deal.GetPrice())
-		cmd.Printf("Status:   %s\r\n", deal.GetStatus())
+		cmd.Printf("ID:       %s\r\n", formatDealID(deal.GetId()))
+		cmd.Printf("Price:    %s\r\n", formatPrice(deal.GetPrice()))
+		cmd.Printf("Status:   %s\r\n", formatDealStatus(deal.GetStatus()))
 		cmd.Printf("Buyer:    %s\r\n", deal.GetBuyerID())
 		cmd.Printf("Supplier: %s\r\n", deal.GetSupplierID())
-		cmd.Printf("Start at: %s\r\n", start.Format(time.RFC3339))
-		cmd.Printf("End at:   %s\r\n", end.Format(time.RFC3339))
+		cmd.Printf("Start at: %s\r\n", formatTimestamp(deal.GetStartTime()))
+		cmd.Printf("End at:   %s\r\n", formatTimestamp(deal.GetEndTime()))
 	} else {
-		showJSON(cmd, deal)
+		showJSON(cmd, "deal", deal)
 	}
 
 }
@@ -385,7 +1043,7 @@ func printID(cmd *cobra.Command, id string) {
 	if isSimpleFormat() {
 		cmd.Printf("ID = %s\r\n", id)
 	} else {
-		showJSON(cmd, map[string]string{"id": id})
+		showJSON(cmd, "id", map[string]string{"id": id})
 	}
 }
 
@@ -397,7 +1055,7 @@ func printTaskStart(cmd *cobra.Command, start *pb.HubStartTaskReply) {
 			cmd.Printf("  Endpoint:    %s\r\n", end)
 		}
 	} else {
-		showJSON(cmd, start)
+		showJSON(cmd, "task_start", start)
 	}
 
 }
@@ -0,0 +1,719 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	"github.com/sonm-io/core/insonmnia/node"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintTaskStatusCrashedSimple(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{
+		Status:   pb.TaskStatusReply_BROKEN,
+		MinerID:  "miner-1",
+		Restarts: 3,
+		ExitCode: 137,
+	})
+
+	assert.Contains(t, buf.String(), "  Restarts: 3\r\n")
+	assert.Contains(t, buf.String(), "  Last exit: 137 (killed)\r\n")
+}
+
+func TestPrintTaskStatusCrashedJSON(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{
+		Status:   pb.TaskStatusReply_BROKEN,
+		Restarts: 3,
+		ExitCode: 137,
+	})
+
+	v := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+	assert.Equal(t, float64(3), v["restarts"])
+	assert.Equal(t, float64(137), v["exit_code"])
+}
+
+func TestPrintTaskStatusRunningOmitsExit(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{Status: pb.TaskStatusReply_RUNNING})
+
+	assert.NotContains(t, buf.String(), "Restarts")
+	assert.NotContains(t, buf.String(), "Last exit")
+}
+
+func TestPrintWorkerStatusPartialCapabilities(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	assert.NotPanics(t, func() {
+		printWorkerStatus(rootCmd, "worker-1", &pb.InfoReply{
+			Capabilities: &pb.Capabilities{
+				Cpu: []*pb.CPUDevice{{ModelName: "Intel(R) Core(TM) i7", Cores: 4}},
+				Mem: nil,
+				Gpu: []*pb.GPUDevice{nil},
+			},
+		})
+	})
+
+	assert.Contains(t, buf.String(), "    CPU0: 4 x Intel(R) Core(TM) i7\r\n")
+	assert.Contains(t, buf.String(), "    GPU0: unknown\r\n")
+	assert.Contains(t, buf.String(), "    RAM: unknown\r\n")
+}
+
+func TestPrintWorkerStatusNoCpu(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printWorkerStatus(rootCmd, "worker-1", &pb.InfoReply{
+		Capabilities: &pb.Capabilities{Mem: &pb.RAMDevice{Total: 1024}},
+	})
+
+	assert.Contains(t, buf.String(), "    CPU: unknown\r\n")
+	assert.Contains(t, buf.String(), "    GPU: None")
+}
+
+func TestFormatBytesPrecision(t *testing.T) {
+	defer func(precision int) { bytePrecisionFlag = precision }(bytePrecisionFlag)
+
+	// 976.5625 KB
+	size := uint64(1000 * 1000)
+
+	assert.Equal(t, "976.6 KB", formatBytes(size))
+
+	bytePrecisionFlag = 0
+	assert.Equal(t, "977 KB", formatBytes(size))
+	assert.Equal(t, "512 B", formatBytes(512))
+
+	bytePrecisionFlag = 2
+	assert.Equal(t, "976.56 KB", formatBytes(size))
+	assert.Equal(t, "1.50 GB", formatBytes(3*1024*1024*1024/2))
+}
+
+// setColorEnabled forces the color mode, returning a function restoring it.
+func setColorEnabled(enabled bool) func() {
+	prev := colorEnabled
+	colorEnabled = func() bool { return enabled }
+	return func() { colorEnabled = prev }
+}
+
+func TestPrintDealsListStatusColored(t *testing.T) {
+	defer setColorEnabled(true)()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printDealsList(rootCmd, []*pb.Deal{
+		{Id: "1", Status: pb.DealStatus_ACCEPTED},
+		{Id: "2", Status: pb.DealStatus_PENDING},
+		{Id: "3", Status: pb.DealStatus_CLOSED},
+	}, nil)
+
+	assert.Contains(t, buf.String(), "✓ ACCEPTED")
+	assert.Contains(t, buf.String(), "⏳ PENDING")
+	assert.Contains(t, buf.String(), "✗ CLOSED")
+}
+
+func TestPrintDealsListStatusPlain(t *testing.T) {
+	defer setColorEnabled(false)()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printDealsList(rootCmd, []*pb.Deal{{Id: "1", Status: pb.DealStatus_ACCEPTED}}, nil)
+
+	assert.Contains(t, buf.String(), "Status:   ACCEPTED\r\n")
+	assert.NotContains(t, buf.String(), "✓")
+	assert.NotContains(t, buf.String(), "\x1b[")
+}
+
+func TestPrintDealsListStatusJSON(t *testing.T) {
+	defer setColorEnabled(true)()
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	printDealsList(rootCmd, []*pb.Deal{{Id: "1", Status: pb.DealStatus_ACCEPTED}}, nil)
+
+	assert.NotContains(t, buf.String(), "✓")
+}
+
+func TestColorEnabledFlag(t *testing.T) {
+	defer func(prev string) { colorModeFlag = prev }(colorModeFlag)
+
+	colorModeFlag = colorModeAlways
+	assert.True(t, colorEnabled())
+
+	colorModeFlag = colorModeNever
+	assert.False(t, colorEnabled())
+
+	// Tests do not run on a terminal.
+	colorModeFlag = colorModeAuto
+	assert.False(t, colorEnabled())
+}
+
+func TestPrintTaskStatusColored(t *testing.T) {
+	defer setColorEnabled(true)()
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{Status: pb.TaskStatusReply_RUNNING})
+	assert.Contains(t, buf.String(), "  Status: \x1b[32mRUNNING\x1b[0m\r\n")
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{Status: pb.TaskStatusReply_BROKEN})
+	assert.Contains(t, buf.String(), "  Status: \x1b[31mBROKEN\x1b[0m\r\n")
+
+	buf = initRootCmd(t, config.OutputModeJSON)
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{Status: pb.TaskStatusReply_RUNNING})
+	assert.NotContains(t, buf.String(), "\x1b[")
+}
+
+func TestPrintProcessingOrdersColored(t *testing.T) {
+	defer setColorEnabled(true)()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printProcessingOrders(rootCmd, &pb.GetProcessingReply{Orders: map[string]*pb.GetProcessingReply_ProcessedOrder{
+		"order-1": {Status: node.HandlerStatusDone, Timestamp: &pb.Timestamp{}},
+		"order-2": {Status: node.HandlerStatusFailed, Timestamp: &pb.Timestamp{}},
+		"order-3": {Status: 1, Timestamp: &pb.Timestamp{}},
+	}})
+
+	assert.Contains(t, buf.String(), "order-1 \x1b[32mDone\x1b[0m")
+	assert.Contains(t, buf.String(), "order-2 \x1b[31mFailed\x1b[0m")
+	assert.Contains(t, buf.String(), "order-3 \x1b[33m")
+}
+
+func TestPrintProcessingOrdersPlain(t *testing.T) {
+	defer setColorEnabled(false)()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printProcessingOrders(rootCmd, &pb.GetProcessingReply{Orders: map[string]*pb.GetProcessingReply_ProcessedOrder{
+		"order-1": {Status: node.HandlerStatusDone, Timestamp: &pb.Timestamp{}},
+	}})
+
+	assert.Contains(t, buf.String(), "order-1 Done")
+	assert.NotContains(t, buf.String(), "\x1b[")
+}
+
+func TestPrintDealInfoTimezone(t *testing.T) {
+	deal := &pb.Deal{
+		Id:        "1",
+		StartTime: &pb.Timestamp{Seconds: 1514764800},
+		EndTime:   &pb.Timestamp{Seconds: 1514851200},
+	}
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printDealInfo(rootCmd, deal)
+	assert.Contains(t, buf.String(), "Start at: 2018-01-01T00:00:00Z\r\n")
+	assert.Contains(t, buf.String(), "End at:   2018-01-02T00:00:00Z\r\n")
+
+	defer func(prev *time.Location) { timeLocation = prev }(timeLocation)
+	timeLocation = time.FixedZone("MSK", 3*60*60)
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printDealInfo(rootCmd, deal)
+	assert.Contains(t, buf.String(), "Start at: 2018-01-01T03:00:00+03:00\r\n")
+}
+
+func TestPrintTaskStatusMalformedPortsSimple(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{
+		Status:    pb.TaskStatusReply_RUNNING,
+		ImageName: "httpd:latest",
+		Ports:     `{"80/tcp": [`,
+	})
+
+	assert.Contains(t, buf.String(), "Warning: cannot parse task ports")
+	assert.Contains(t, buf.String(), "  Image:  httpd:latest\r\n")
+	assert.Contains(t, buf.String(), "  Status: RUNNING\r\n")
+	assert.NotContains(t, buf.String(), "Ports:")
+}
+
+func TestPrintTaskStatusMalformedPortsJSON(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{
+		Status:    pb.TaskStatusReply_RUNNING,
+		ImageName: "httpd:latest",
+		Ports:     `{"80/tcp": [`,
+	})
+
+	v := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+	assert.Equal(t, "httpd:latest", v["image"])
+	assert.NotEmpty(t, v["ports_parse_error"])
+}
+
+func TestBuildTaskStatusViewPorts(t *testing.T) {
+	view := BuildTaskStatusView("task-1", &pb.TaskStatusReply{
+		Ports: `{"443/tcp":[{"HostIp":"0.0.0.0","HostPort":"32769"}],"80/tcp":null}`,
+	})
+
+	require.NoError(t, view.PortsParseError)
+	assert.Equal(t, []TaskPortView{
+		{ContainerPort: "443/tcp", HostIP: "0.0.0.0", HostPort: "32769", Bound: true},
+		{ContainerPort: "80/tcp"},
+	}, view.Ports)
+}
+
+func TestPrintWorkerAclListWithTimestamps(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printWorkerAclList(rootCmd, &pb.GetRegisteredWorkersReply{
+		Ids: []*pb.ID{{Id: "0x1"}, {Id: "0x2"}},
+		Workers: []*pb.RegisteredWorker{
+			{Id: &pb.ID{Id: "0x1"}, RegisteredAt: &pb.Timestamp{Seconds: 1514764800}},
+			{Id: &pb.ID{Id: "0x2"}},
+		},
+	})
+
+	assert.Equal(t, "1) 0x1  registered at 2018-01-01T00:00:00Z\r\n2) 0x2\r\n", buf.String())
+}
+
+func TestPrintWorkerAclListIdsOnly(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printWorkerAclList(rootCmd, &pb.GetRegisteredWorkersReply{
+		Ids: []*pb.ID{{Id: "0x1"}, {Id: "0x2"}},
+	})
+
+	assert.Equal(t, "1) 0x1\r\n2) 0x2\r\n", buf.String())
+}
+
+func TestSortedKeys(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, sortedKeys(map[string]int{"c": 3, "a": 1, "b": 2}))
+	assert.Empty(t, sortedKeys(map[string]*pb.Slot{}))
+}
+
+func TestPrintWorkerStatusTasksSorted(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printWorkerStatus(rootCmd, "worker-1", &pb.InfoReply{
+		Usage: map[string]*pb.ResourceUsage{
+			"task-c": {},
+			"task-a": {},
+			"task-b": {},
+		},
+	})
+
+	assert.Equal(t, "Worker \"worker-1\":\r\n"+
+		"  Tasks:\n"+
+		"    1) task-a\r\n"+
+		"    2) task-b\r\n"+
+		"    3) task-c\r\n", buf.String())
+}
+
+func TestPrintNodeTaskStatusSorted(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printNodeTaskStatus(rootCmd, map[string]*pb.TaskListReply_TaskInfo{
+		"worker-2": {},
+		"worker-1": {Tasks: map[string]*pb.TaskStatusReply{
+			"task-b": {Status: pb.TaskStatusReply_RUNNING, ImageName: "nginx"},
+			"task-a": {Status: pb.TaskStatusReply_RUNNING, ImageName: "httpd"},
+		}},
+	})
+
+	assert.Equal(t, "Worker \"worker-1\":\r\n"+
+		"  1) task-a \r\n     RUNNING  httpd (up: 0s)\r\n"+
+		"  2) task-b \r\n     RUNNING  nginx (up: 0s)\r\n"+
+		"Worker \"worker-2\" has no tasks\r\n", buf.String())
+}
+
+func TestPrintWorkerListSorted(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printWorkerList(rootCmd, &pb.ListReply{Info: map[string]*pb.ListReply_ListValue{
+		"0xC": {},
+		"0xA": {Values: []string{"task-1"}},
+		"0xB": {},
+	}})
+
+	assert.Equal(t, "Worker: 0xA\t\t1 active task(s)\r\n"+
+		"Worker: 0xB\t\tIdle\r\n"+
+		"Worker: 0xC\t\tIdle\r\n"+
+		"Total: 3 worker(s), 1 active task(s), 2 idle\r\n", buf.String())
+}
+
+func TestPrintProcessingOrdersSorted(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printProcessingOrders(rootCmd, &pb.GetProcessingReply{Orders: map[string]*pb.GetProcessingReply_ProcessedOrder{
+		"order-2": {Timestamp: &pb.Timestamp{}},
+		"order-3": {Timestamp: &pb.Timestamp{}},
+		"order-1": {Timestamp: &pb.Timestamp{}},
+	}})
+
+	output := buf.String()
+	first := strings.Index(output, "order-1")
+	second := strings.Index(output, "order-2")
+	third := strings.Index(output, "order-3")
+	assert.True(t, first >= 0 && first < second && second < third, output)
+}
+
+func TestPrintDeviceListSorted(t *testing.T) {
+	devices := &pb.DevicesReply{
+		CPUs: map[string]*pb.CPUDeviceInfo{
+			"cpu1": {Device: &pb.CPUDevice{ModelName: "Xeon"}},
+			"cpu0": {Device: &pb.CPUDevice{ModelName: "Core i7"}},
+		},
+		GPUs: map[string]*pb.GPUDeviceInfo{
+			"gpu2": {Device: &pb.GPUDevice{Name: "Radeon RX 580"}},
+			"gpu0": {Device: &pb.GPUDevice{Name: "GeForce GTX 1080"}},
+			"gpu1": {Device: &pb.GPUDevice{Name: "GeForce GTX 1070"}},
+		},
+	}
+
+	expected := "CPUs:\r\n" +
+		" cpu0: Core i7\r\n" +
+		" cpu1: Xeon\r\n" +
+		"GPUs:\r\n" +
+		" gpu0: GeForce GTX 1080\r\n" +
+		" gpu1: GeForce GTX 1070\r\n" +
+		" gpu2: Radeon RX 580\r\n"
+
+	// Map iteration order is randomized, so a few rounds catch flaky output.
+	for i := 0; i < 10; i++ {
+		buf := initRootCmd(t, config.OutputModeSimple)
+		printDeviceList(rootCmd, devices, "")
+		assert.Equal(t, expected, buf.String())
+	}
+}
+
+func TestPrintDeviceListByType(t *testing.T) {
+	devices := &pb.DevicesReply{
+		CPUs: map[string]*pb.CPUDeviceInfo{
+			"cpu0": {Device: &pb.CPUDevice{ModelName: "Core i7"}},
+		},
+		GPUs: map[string]*pb.GPUDeviceInfo{
+			"gpu1": {Device: &pb.GPUDevice{Name: "GeForce GTX 1070"}},
+			"gpu0": {Device: &pb.GPUDevice{Name: "GeForce GTX 1080"}},
+		},
+	}
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printDeviceList(rootCmd, devices, deviceTypeGPU)
+	assert.Equal(t, "GPUs:\r\n gpu0: GeForce GTX 1080\r\n gpu1: GeForce GTX 1070\r\n", buf.String())
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printDeviceList(rootCmd, devices, deviceTypeCPU)
+	assert.Equal(t, "CPUs:\r\n cpu0: Core i7\r\n", buf.String())
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printDeviceList(rootCmd, &pb.DevicesReply{CPUs: devices.CPUs}, deviceTypeGPU)
+	assert.Equal(t, "No GPUs detected.\r\n", buf.String())
+
+	buf = initRootCmd(t, config.OutputModeJSON)
+	printDeviceList(rootCmd, devices, deviceTypeGPU)
+	reply := &pb.DevicesReply{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), reply))
+	assert.Empty(t, reply.GetCPUs())
+	assert.Len(t, reply.GetGPUs(), 2)
+}
+
+func TestUptimeConsistentBetweenHubAndTask(t *testing.T) {
+	uptime := uint64(90*time.Minute + 15*time.Second + 300*time.Millisecond)
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printHubStatus(rootCmd, &pb.HubStatusReply{Uptime: uptime, Version: "0.3.3", Platform: "linux/amd64"})
+	assert.Contains(t, buf.String(), "Uptime:           1h 30m 15s\r\n")
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{Status: pb.TaskStatusReply_RUNNING, Uptime: uptime})
+	assert.Contains(t, buf.String(), "  Uptime: 1h 30m 15s\r\n")
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printNodeTaskStatus(rootCmd, map[string]*pb.TaskListReply_TaskInfo{
+		"worker-1": {Tasks: map[string]*pb.TaskStatusReply{
+			"task-1": {Status: pb.TaskStatusReply_RUNNING, Uptime: uptime},
+		}},
+	})
+	assert.Contains(t, buf.String(), "(up: 1h 30m 15s)")
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	assert.Equal(t, "2h", humanizeDuration(2*time.Hour+123))
+	assert.Equal(t, "3m 12s", humanizeDuration(3*time.Minute+12*time.Second))
+	assert.Equal(t, "26h 1s", humanizeDuration(26*time.Hour+time.Second))
+	assert.Equal(t, "1s", humanizeDuration(500*time.Millisecond))
+	assert.Equal(t, "0s", humanizeDuration(0))
+	assert.Equal(t, "-1m", humanizeDuration(-time.Minute))
+}
+
+func TestPrintHubStatusUnknownVersion(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printHubStatus(rootCmd, &pb.HubStatusReply{MinerCount: 2})
+
+	assert.Contains(t, buf.String(), "Uptime:           0s\r\n")
+	assert.Contains(t, buf.String(), "Version:          unknown unknown\r\n")
+}
+
+func TestPrintWorkerStatusGPUMismatch(t *testing.T) {
+	status := &pb.InfoReply{
+		Capabilities: &pb.Capabilities{
+			Gpu: []*pb.GPUDevice{{Name: "GTX 1080"}, {Name: "GTX 1080"}, {Name: "GTX 1080"}, {Name: "GTX 1080"}},
+		},
+		GpuTelemetry: &pb.GPUTelemetry{ReportingCount: 3},
+	}
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printWorkerStatus(rootCmd, "worker-1", status)
+	assert.Contains(t, buf.String(), "  ⚠ 4 GPUs advertised, 3 reporting\r\n")
+
+	buf = initRootCmd(t, config.OutputModeJSON)
+	printWorkerStatus(rootCmd, "worker-1", status)
+
+	v := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+	assert.Equal(t, "4 GPUs advertised, 3 reporting", v["gpu_health_warning"])
+	assert.Contains(t, v, "capabilities")
+}
+
+func TestPrintWorkerStatusGPUNoWarning(t *testing.T) {
+	capabilities := &pb.Capabilities{Gpu: []*pb.GPUDevice{{Name: "GTX 1080"}, {Name: "GTX 1080"}}}
+
+	// Without telemetry.
+	buf := initRootCmd(t, config.OutputModeJSON)
+	printWorkerStatus(rootCmd, "worker-1", &pb.InfoReply{Capabilities: capabilities})
+	assert.NotContains(t, buf.String(), "gpu_health_warning")
+
+	// All GPUs are reporting.
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printWorkerStatus(rootCmd, "worker-1", &pb.InfoReply{
+		Capabilities: capabilities,
+		GpuTelemetry: &pb.GPUTelemetry{ReportingCount: 2},
+	})
+	assert.NotContains(t, buf.String(), "⚠")
+}
+
+func TestPrintFingerprint(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printFingerprint(rootCmd, "0123456789abcdef")
+	assert.Equal(t, "Fingerprint: 0123456789abcdef\r\n", buf.String())
+
+	buf = initRootCmd(t, config.OutputModeJSON)
+	printFingerprint(rootCmd, "0123456789abcdef")
+	assert.Equal(t, "{\"fingerprint\":\"0123456789abcdef\"}\r\n", buf.String())
+}
+
+func TestPrintWorkerListTable(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeTable)
+
+	printWorkerList(rootCmd, &pb.ListReply{Info: map[string]*pb.ListReply_ListValue{
+		"0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD": {Values: []string{"task-1", "task-2"}},
+		"0xB8ae": {},
+	}})
+
+	assert.Equal(t, "ADDRESS                                     TASKS  STATE\r\n"+
+		"0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD  2      Busy\r\n"+
+		"0xB8ae                                      0      Idle\r\n"+
+		"Total: 2 worker(s), 2 active task(s), 1 idle\r\n", buf.String())
+}
+
+func TestPrintWorkerListJSONSummary(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	printWorkerList(rootCmd, &pb.ListReply{Info: map[string]*pb.ListReply_ListValue{
+		"0xA": {Values: []string{"task-1", "task-2"}},
+		"0xB": {Values: []string{"task-3"}},
+		"0xC": {},
+	}})
+
+	assert.Equal(t, `{"info":{"0xA":{"values":["task-1","task-2"]},"0xB":{"values":["task-3"]},"0xC":{}},`+
+		`"summary":{"workers":3,"active_tasks":3,"idle":1}}`+"\r\n", buf.String())
+}
+
+func TestPrintWorkerListTableEmpty(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeTable)
+
+	printWorkerList(rootCmd, &pb.ListReply{})
+
+	assert.Equal(t, "No workers connected\r\n", buf.String())
+}
+
+func TestPrintAskListTable(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeTable)
+
+	printAskList(rootCmd, &pb.SlotsReply{Slots: map[string]*pb.Slot{
+		"1": {Resources: &pb.Resources{CpuCores: 4, GpuCount: 2, RamBytes: 1 << 30}},
+		"2": {
+			Resources: &pb.Resources{CpuCores: 16, RamBytes: 1 << 20},
+			Geo:       &pb.Geo{City: "Moscow", Country: "RU"},
+		},
+	}})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "ID  CPU  GPU  RAM"))
+	assert.True(t, strings.HasPrefix(lines[1], "1   4    2    "))
+	assert.True(t, strings.HasPrefix(lines[2], "2   16   0    "))
+	assert.True(t, strings.HasSuffix(lines[2], "Moscow, RU"))
+	// All the columns are aligned to the header.
+	assert.Equal(t, strings.Index(lines[0], "NET"), strings.Index(lines[1], pb.NetworkType_NO_NETWORK.String()))
+}
+
+func TestPrintAskListCountryOnlyGeo(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printAskList(rootCmd, &pb.SlotsReply{Slots: map[string]*pb.Slot{
+		"1": {Resources: &pb.Resources{}, Geo: &pb.Geo{Country: "RU"}},
+	}})
+
+	assert.Contains(t, buf.String(), " Geo: RU\r\n")
+}
+
+func TestPrintOrdersWithoutResources(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printOrderDetails(rootCmd, &pb.Order{Id: "1", Price: "1000"})
+	printOrderDetails(rootCmd, &pb.Order{Id: "2", Price: "1000", Slot: &pb.Slot{SupplierRating: 5}})
+	assert.Equal(t, 2, strings.Count(buf.String(), "Resources:      (no resource info)\r\n"))
+	assert.Contains(t, buf.String(), "SupplierRating: 5\r\n")
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printSearchResults(rootCmd, []*pb.Order{{Id: "1", Price: "1000"}}, nil)
+	assert.Equal(t, "1) ANY 1 | price = 1000\r\n", buf.String())
+}
+
+func TestPrintAskListWithoutResources(t *testing.T) {
+	slots := &pb.SlotsReply{Slots: map[string]*pb.Slot{
+		"1": {Geo: &pb.Geo{Country: "RU"}},
+		"2": nil,
+	}}
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printAskList(rootCmd, slots)
+	assert.Contains(t, buf.String(), " ID:  1 (no resource info)\r\n Geo: RU\r\n")
+	assert.Contains(t, buf.String(), " ID:  2 (no resource info)\r\n")
+
+	buf = initRootCmd(t, config.OutputModeTable)
+	printAskList(rootCmd, slots)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[1], "1   (no resource info)"))
+	assert.True(t, strings.HasSuffix(lines[1], "RU"))
+}
+
+func TestPrintDealsListTable(t *testing.T) {
+	defer setColorEnabled(true)()
+	buf := initRootCmd(t, config.OutputModeTable)
+
+	printDealsList(rootCmd, []*pb.Deal{
+		{Id: "1", Price: "1000", Status: pb.DealStatus_ACCEPTED},
+		{Id: "42", Price: "5", Status: pb.DealStatus_PENDING},
+	}, nil)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "ID"))
+	assert.Equal(t, strings.Index(lines[0], "STATUS"), strings.Index(lines[1], "ACCEPTED"))
+	assert.Equal(t, strings.Index(lines[0], "STATUS"), strings.Index(lines[2], "PENDING"))
+	assert.NotContains(t, buf.String(), "\x1b[")
+}
+
+func TestPrintDealsListCSV(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeCSV)
+
+	printDealsList(rootCmd, []*pb.Deal{
+		{
+			Id:         "1",
+			Price:      "1000",
+			BuyerID:    "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD",
+			SupplierID: "0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5",
+			StartTime:  &pb.Timestamp{Seconds: 1514764800},
+			EndTime:    &pb.Timestamp{Seconds: 1514851200},
+		},
+	}, nil)
+
+	assert.Equal(t, "id,type,price,buyer,supplier,start,end\r\n"+
+		"1,DEAL,1000,0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD,0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5,"+
+		"2018-01-01T00:00:00Z,2018-01-02T00:00:00Z\r\n", buf.String())
+}
+
+func TestPrintDealsListCSVEmpty(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeCSV)
+
+	printDealsList(rootCmd, nil, nil)
+
+	assert.Equal(t, "id,type,price,buyer,supplier,start,end\r\n", buf.String())
+}
+
+func TestPrintSearchResultsCSVQuoting(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeCSV)
+
+	printSearchResults(rootCmd, []*pb.Order{
+		{Id: "1", OrderType: pb.OrderType_ASK, Price: "1,000", SupplierID: "0xB8ae"},
+		{Id: "2", OrderType: pb.OrderType_BID, Price: "5", ByuerID: `say "hi"`},
+	}, nil)
+
+	assert.Equal(t, "id,type,price,buyer,supplier,start,end\r\n"+
+		"1,ASK,\"1,000\",,0xB8ae,,\r\n"+
+		"2,BID,5,\"say \"\"hi\"\"\",,,\r\n", buf.String())
+}
+
+func TestPrintListsQuiet(t *testing.T) {
+	quietFlag = true
+	defer func() { quietFlag = false }()
+
+	// Quiet mode takes precedence over the output mode.
+	buf := initRootCmd(t, config.OutputModeJSON)
+	printSearchResults(rootCmd, []*pb.Order{{Id: "1", Price: "1000"}, {Id: "2"}}, newPagination(5, 0, 2))
+	assert.Equal(t, "1\n2\n", buf.String())
+
+	buf = initRootCmd(t, config.OutputModeTable)
+	printDealsList(rootCmd, []*pb.Deal{{Id: "42", Price: "1000"}}, nil)
+	assert.Equal(t, formatDealID("42")+"\n", buf.String())
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printWorkerList(rootCmd, &pb.ListReply{Info: map[string]*pb.ListReply_ListValue{
+		"worker-2": {Values: []string{"task-1"}},
+		"worker-1": {},
+	}})
+	assert.Equal(t, "worker-1\nworker-2\n", buf.String())
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printProcessingOrders(rootCmd, &pb.GetProcessingReply{Orders: map[string]*pb.GetProcessingReply_ProcessedOrder{
+		"order-1": {Timestamp: &pb.Timestamp{Seconds: 1514764800}},
+	}})
+	assert.Equal(t, "order-1\n", buf.String())
+}
+
+func TestPrintListsQuietEmpty(t *testing.T) {
+	quietFlag = true
+	defer func() { quietFlag = false }()
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printSearchResults(rootCmd, nil, newPagination(0, 0, 10))
+	printDealsList(rootCmd, nil, nil)
+	printWorkerList(rootCmd, &pb.ListReply{})
+	printProcessingOrders(rootCmd, &pb.GetProcessingReply{})
+
+	assert.Empty(t, buf.String())
+}
+
+func TestPrintLocatorStats(t *testing.T) {
+	stats := &pb.LocatorStatsReply{
+		Nodes:          42,
+		OldestAnnounce: &pb.Timestamp{Seconds: 1514764800},
+		NewestAnnounce: &pb.Timestamp{Seconds: 1514768400},
+		NodeTTLSeconds: 3600,
+	}
+
+	buf := initRootCmd(t, config.OutputModeSimple)
+	printLocatorStats(rootCmd, stats)
+	assert.Equal(t, "Nodes:           42\r\n"+
+		"Oldest announce: 2018-01-01T00:00:00Z\r\n"+
+		"Newest announce: 2018-01-01T01:00:00Z\r\n"+
+		"Node TTL:        1h0m0s\r\n", buf.String())
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	printLocatorStats(rootCmd, &pb.LocatorStatsReply{NodeTTLSeconds: 3600})
+	assert.Equal(t, "Nodes:           0\r\nNode TTL:        1h0m0s\r\n", buf.String())
+
+	buf = initRootCmd(t, config.OutputModeJSON)
+	printLocatorStats(rootCmd, stats)
+	assert.Contains(t, buf.String(), `"nodes":42`)
+	assert.Contains(t, buf.String(), `"nodeTTLSeconds":3600`)
+}
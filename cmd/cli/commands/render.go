@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renderTypeFlag string
+	renderIDFlag   string
+)
+
+// documentRenderer parses a JSON document saved from the output of some
+// command and prints it with the printer of that command.
+type documentRenderer func(cmd *cobra.Command, data []byte) error
+
+var documentRenderers = map[string]documentRenderer{
+	"deal":   renderDeal,
+	"order":  renderOrder,
+	"task":   renderTaskStatus,
+	"worker": renderWorkerStatus,
+}
+
+func init() {
+	renderCmd.Flags().StringVar(&renderTypeFlag, "type", "", "Document type: "+strings.Join(documentTypes(), ", "))
+	renderCmd.Flags().StringVar(&renderIDFlag, "id", "", "Object ID, for documents that do not contain it")
+}
+
+func documentTypes() []string {
+	return sortedKeys(documentRenderers)
+}
+
+var renderCmd = &cobra.Command{
+	Use:   "render <file>",
+	Short: "Render a saved JSON output in human-readable form",
+	Long:  "Render a JSON document saved from the output of another command without repeating the request. Use \"-\" to read the document from stdin.",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := readDocument(args[0])
+		if err != nil {
+			showError(cmd, "Cannot read document", err)
+			os.Exit(1)
+		}
+
+		if err := renderDocument(cmd, renderTypeFlag, data); err != nil {
+			showError(cmd, "Cannot render document", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func readDocument(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+
+	return ioutil.ReadFile(path)
+}
+
+func renderDocument(cmd *cobra.Command, docType string, data []byte) error {
+	renderer, ok := documentRenderers[docType]
+	if !ok {
+		return fmt.Errorf("unknown document type %q, expected one of: %s", docType, strings.Join(documentTypes(), ", "))
+	}
+
+	return renderer(cmd, unwrapEnvelope(data))
+}
+
+// unwrapEnvelope extracts the payload of documents saved with the
+// "--envelope" flag, keeping other documents as is.
+func unwrapEnvelope(data []byte) []byte {
+	envelope := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return data
+	}
+
+	_, hasMeta := envelope["meta"]
+	payload, hasData := envelope["data"]
+	if !hasMeta || !hasData {
+		return data
+	}
+
+	// Envelopes saved by older versions lack the version and the type.
+	for key := range envelope {
+		switch key {
+		case "meta", "data", "version", "type":
+		default:
+			return data
+		}
+	}
+
+	return payload
+}
+
+// decodeDocument decodes the document rejecting unknown fields, which
+// means that it has been saved from the output of another command.
+func decodeDocument(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("document does not match the requested type: %v", err)
+	}
+
+	return nil
+}
+
+func renderDeal(cmd *cobra.Command, data []byte) error {
+	deal := &pb.Deal{}
+	if err := decodeDocument(data, deal); err != nil {
+		return err
+	}
+
+	printDealInfo(cmd, deal)
+	return nil
+}
+
+func renderOrder(cmd *cobra.Command, data []byte) error {
+	order := &pb.Order{}
+	if err := decodeDocument(data, order); err != nil {
+		return err
+	}
+	// Printing an order without a slot would panic.
+	if order.GetSlot().GetResources() == nil {
+		return errors.New("order has no resources")
+	}
+
+	printOrderDetails(cmd, order)
+	return nil
+}
+
+func renderWorkerStatus(cmd *cobra.Command, data []byte) error {
+	// The warning is computed again from the status itself.
+	view := &workerStatusView{InfoReply: &pb.InfoReply{}}
+	if err := decodeDocument(data, view); err != nil {
+		return err
+	}
+
+	printWorkerStatus(cmd, renderIDFlag, view.InfoReply)
+	return nil
+}
+
+// taskStatusDocument describes the task status as printed by
+// printTaskStatus in JSON mode.
+type taskStatusDocument struct {
+	ID              string                      `json:"id"`
+	Miner           string                      `json:"miner"`
+	Status          string                      `json:"status"`
+	Image           string                      `json:"image"`
+	Ports           string                      `json:"ports"`
+	Uptime          string                      `json:"uptime"`
+	CPU             string                      `json:"cpu"`
+	Mem             string                      `json:"mem"`
+	Net             map[string]*pb.NetworkUsage `json:"net"`
+	Restarts        uint32                      `json:"restarts"`
+	ExitCode        int32                       `json:"exit_code"`
+	PortsParseError string                      `json:"ports_parse_error"`
+}
+
+func renderTaskStatus(cmd *cobra.Command, data []byte) error {
+	doc := &taskStatusDocument{}
+	if err := decodeDocument(data, doc); err != nil {
+		return err
+	}
+
+	status, ok := pb.TaskStatusReply_Status_value[doc.Status]
+	if !ok {
+		return fmt.Errorf("unknown task status %q", doc.Status)
+	}
+
+	taskStatus := &pb.TaskStatusReply{
+		Status:    pb.TaskStatusReply_Status(status),
+		MinerID:   doc.Miner,
+		ImageName: doc.Image,
+		Ports:     doc.Ports,
+		Restarts:  doc.Restarts,
+		ExitCode:  doc.ExitCode,
+	}
+
+	var err error
+	if taskStatus.Uptime, err = parseDocumentUint("uptime", doc.Uptime); err != nil {
+		return err
+	}
+
+	// Usage is present only along with the CPU and memory fields.
+	if doc.CPU != "" || doc.Mem != "" {
+		cpu, err := parseDocumentUint("cpu", doc.CPU)
+		if err != nil {
+			return err
+		}
+		mem, err := parseDocumentUint("mem", doc.Mem)
+		if err != nil {
+			return err
+		}
+
+		taskStatus.Usage = &pb.ResourceUsage{
+			Cpu:     &pb.CPUUsage{Total: cpu},
+			Memory:  &pb.MemoryUsage{MaxUsage: mem},
+			Network: doc.Net,
+		}
+	}
+
+	id := doc.ID
+	if renderIDFlag != "" {
+		id = renderIDFlag
+	}
+
+	printTaskStatus(cmd, id, taskStatus)
+	return nil
+}
+
+// parseDocumentUint parses numbers printed as strings, treating empty
+// strings as zero.
+func parseDocumentUint(name, value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed %s: %v", name, err)
+	}
+
+	return v, nil
+}
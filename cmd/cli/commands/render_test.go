@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renderRoundTrip prints the object in JSON mode with the given printer, and
+// returns both the simple output of the same printer and the output of
+// rendering the JSON back.
+func renderRoundTrip(t *testing.T, docType string, print func()) (string, string) {
+	buf := initRootCmd(t, config.OutputModeJSON)
+	print()
+	data := buf.Bytes()
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	print()
+	expected := buf.String()
+
+	buf = initRootCmd(t, config.OutputModeSimple)
+	require.NoError(t, renderDocument(rootCmd, docType, data))
+
+	return expected, buf.String()
+}
+
+func TestRenderDeal(t *testing.T) {
+	deal := &pb.Deal{
+		Id:         "42",
+		BuyerID:    "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD",
+		SupplierID: "0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5",
+		Status:     pb.DealStatus_ACCEPTED,
+		Price:      "1000",
+		StartTime:  &pb.Timestamp{Seconds: 1514764800},
+		EndTime:    &pb.Timestamp{Seconds: 1514851200},
+	}
+
+	expected, rendered := renderRoundTrip(t, "deal", func() { printDealInfo(rootCmd, deal) })
+	assert.Equal(t, expected, rendered)
+	assert.Contains(t, rendered, "Price:    1000\r\n")
+}
+
+func TestRenderTaskStatus(t *testing.T) {
+	status := &pb.TaskStatusReply{
+		Status:    pb.TaskStatusReply_BROKEN,
+		MinerID:   "miner-1",
+		ImageName: "httpd:latest",
+		Ports:     `{"80/tcp":[{"HostIp":"0.0.0.0","HostPort":"32768"}]}`,
+		Uptime:    90000000000,
+		Restarts:  3,
+		ExitCode:  137,
+		Usage: &pb.ResourceUsage{
+			Cpu:     &pb.CPUUsage{Total: 1000},
+			Memory:  &pb.MemoryUsage{MaxUsage: 1048576},
+			Network: map[string]*pb.NetworkUsage{"eth0": {TxBytes: 10, RxBytes: 20}},
+		},
+	}
+
+	expected, rendered := renderRoundTrip(t, "task", func() { printTaskStatus(rootCmd, "task-1", status) })
+	assert.Equal(t, expected, rendered)
+	assert.Contains(t, rendered, "Task task-1 (on miner-1):\r\n")
+	assert.Contains(t, rendered, "  Last exit: 137 (killed)\r\n")
+}
+
+func TestRenderEnvelope(t *testing.T) {
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	data := []byte(`{"meta":{"command":"sonm deals status"},"data":{"id":"42","price":"1000"}}`)
+	require.NoError(t, renderDocument(rootCmd, "deal", data))
+	assert.Contains(t, buf.String(), "ID:       "+formatDealID("42")+"\r\n")
+
+	buf.Reset()
+	data = []byte(`{"version":1,"type":"deal","meta":{"command":"sonm deals status"},"data":{"id":"42","price":"1000"}}`)
+	require.NoError(t, renderDocument(rootCmd, "deal", data))
+	assert.Contains(t, buf.String(), "ID:       "+formatDealID("42")+"\r\n")
+}
+
+func TestRenderMismatchedType(t *testing.T) {
+	initRootCmd(t, config.OutputModeSimple)
+
+	data := []byte(`{"id":"42","status":2,"price":"1000","startTime":{"seconds":1514764800}}`)
+	assert.Error(t, renderDocument(rootCmd, "task", data))
+	assert.Error(t, renderDocument(rootCmd, "order", data))
+	assert.Error(t, renderDocument(rootCmd, "deal", []byte(`{"id":"task-1","miner":"miner-1"}`)))
+}
+
+func TestRenderInvalidDocument(t *testing.T) {
+	initRootCmd(t, config.OutputModeSimple)
+
+	assert.Error(t, renderDocument(rootCmd, "deal", []byte(`{"id":`)))
+	assert.Error(t, renderDocument(rootCmd, "unknown", []byte(`{}`)))
+	assert.Error(t, renderDocument(rootCmd, "task", []byte(`{"status":"SLEEPING"}`)))
+}
+
+func TestRenderWorkerStatusWithWarning(t *testing.T) {
+	status := &pb.InfoReply{
+		Capabilities: &pb.Capabilities{Gpu: []*pb.GPUDevice{{Name: "GTX 1080"}, {Name: "GTX 1080"}}},
+		GpuTelemetry: &pb.GPUTelemetry{ReportingCount: 1},
+	}
+
+	expected, rendered := renderRoundTrip(t, "worker", func() { printWorkerStatus(rootCmd, "", status) })
+	assert.Equal(t, expected, rendered)
+	assert.Contains(t, rendered, "2 GPUs advertised, 1 reporting")
+}
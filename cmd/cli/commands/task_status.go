@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	pb "github.com/sonm-io/core/proto"
+)
+
+// TaskPortView is a single container port, optionally bound to the host.
+type TaskPortView struct {
+	ContainerPort string
+	HostIP        string
+	HostPort      string
+	Bound         bool
+}
+
+// TaskStatusView is a task status prepared for rendering, with ports
+// parsed once for all output formats.
+type TaskStatusView struct {
+	ID       string
+	Miner    string
+	Image    string
+	Status   string
+	Uptime   time.Duration
+	Restarts uint32
+	// HasExitCode is true when ExitCode makes sense to show.
+	HasExitCode bool
+	ExitCode    int32
+	Usage       *pb.ResourceUsage
+	// PortsRaw is the ports description as reported by the worker.
+	PortsRaw string
+	Ports    []TaskPortView
+	// PortsParseError is set when PortsRaw is malformed, in this case Ports
+	// are empty.
+	PortsParseError error
+}
+
+// BuildTaskStatusView converts the task status reply into a view.
+func BuildTaskStatusView(id string, taskStatus *pb.TaskStatusReply) *TaskStatusView {
+	view := &TaskStatusView{
+		ID:          id,
+		Miner:       taskStatus.GetMinerID(),
+		Image:       taskStatus.GetImageName(),
+		Status:      taskStatus.GetStatus().String(),
+		Uptime:      time.Duration(taskStatus.GetUptime()),
+		Restarts:    taskStatus.GetRestarts(),
+		HasExitCode: hasExitCode(taskStatus),
+		ExitCode:    taskStatus.GetExitCode(),
+		Usage:       taskStatus.GetUsage(),
+		PortsRaw:    taskStatus.GetPorts(),
+	}
+
+	view.Ports, view.PortsParseError = parseTaskPorts(view.PortsRaw)
+
+	return view
+}
+
+func parseTaskPorts(raw string) ([]TaskPortView, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	portMap := nat.PortMap{}
+	if err := json.Unmarshal([]byte(raw), &portMap); err != nil {
+		return nil, err
+	}
+
+	ports := make([]TaskPortView, 0, len(portMap))
+	for containerPort, bindings := range portMap {
+		port := TaskPortView{ContainerPort: string(containerPort)}
+		if len(bindings) > 0 {
+			port.HostIP = bindings[0].HostIP
+			port.HostPort = bindings[0].HostPort
+			port.Bound = true
+		}
+		ports = append(ports, port)
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].ContainerPort < ports[j].ContainerPort
+	})
+
+	return ports, nil
+}
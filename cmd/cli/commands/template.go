@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// renderTemplate executes the Go template on the data, which is the same
+// value printed in JSON mode, like "docker ps --format" does.
+func renderTemplate(cmd *cobra.Command, text string, data interface{}) error {
+	tmpl, err := template.New("format").Parse(text)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return err
+	}
+
+	cmd.Printf("%s\r\n", buf.String())
+	return nil
+}
+
+// showTemplate prints the data using the "--format" flag template.
+func showTemplate(cmd *cobra.Command, data interface{}) {
+	if err := renderTemplate(cmd, formatFlag, data); err != nil {
+		showError(cmd, "Cannot apply --format template", err)
+		osExit(1)
+	}
+}
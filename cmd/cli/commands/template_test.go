@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/sonm-io/core/cmd/cli/config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withFormat(format string) func() {
+	formatFlag = format
+	return func() { formatFlag = "" }
+}
+
+func TestPrintDealInfoTemplate(t *testing.T) {
+	defer withFormat("{{.Id}} {{.Price}} {{.Status}}")()
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	printDealInfo(rootCmd, &pb.Deal{Id: "42", Price: "1000", Status: pb.DealStatus_ACCEPTED})
+	assert.Equal(t, "42 1000 ACCEPTED\r\n", buf.String())
+}
+
+func TestPrintOrderDetailsTemplate(t *testing.T) {
+	defer withFormat("{{.Id}}: {{.Slot.Resources.CpuCores}} cores")()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printOrderDetails(rootCmd, &pb.Order{Id: "1", Slot: &pb.Slot{Resources: &pb.Resources{CpuCores: 4}}})
+	assert.Equal(t, "1: 4 cores\r\n", buf.String())
+}
+
+func TestPrintTaskStatusTemplate(t *testing.T) {
+	defer withFormat("{{.id}} {{.image}} {{.exit_code}}")()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	printTaskStatus(rootCmd, "task-1", &pb.TaskStatusReply{
+		Status:    pb.TaskStatusReply_FINISHED,
+		ImageName: "httpd:latest",
+		ExitCode:  1,
+	})
+	assert.Equal(t, "task-1 httpd:latest 1\r\n", buf.String())
+}
+
+func TestRenderTemplateErrors(t *testing.T) {
+	initRootCmd(t, config.OutputModeSimple)
+
+	assert.Error(t, renderTemplate(rootCmd, "{{.Id", &pb.Deal{}))
+	assert.Error(t, renderTemplate(rootCmd, "{{.Unknown}}", &pb.Deal{}))
+}
+
+func TestShowTemplateExitsOnError(t *testing.T) {
+	defer withFormat("{{.Id")()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	prevExit := osExit
+	defer func() { osExit = prevExit }()
+
+	exitCode := -1
+	osExit = func(code int) { exitCode = code }
+
+	printDealInfo(rootCmd, &pb.Deal{Id: "42"})
+	require.Equal(t, 1, exitCode)
+	assert.Contains(t, buf.String(), "[ERR] Cannot apply --format template: ")
+}
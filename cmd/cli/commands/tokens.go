@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"os"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/sonm-io/core/blockchain"
 	"github.com/sonm-io/core/blockchain/tsc"
@@ -11,6 +12,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
+func init() {
+	approveTokenCmd.PersistentFlags().BoolVar(&txWaitFlag, "wait", false,
+		"Wait until the transaction is mined, bounded by --timeout")
+}
+
 var approveTokenCmd = &cobra.Command{
 	Use:    "approve <amount>",
 	Short:  "Approve tokens (ERC20)",
@@ -45,12 +51,11 @@ var approveTokenCmd = &cobra.Command{
 			}
 		}
 
-		tx, err := bch.Approve(sessionKey, tsc.DealsAddress, amount)
+		err = submitAndReport(cmd, commandCtx, bch, "Cannot approve tokens", func() (*types.Transaction, error) {
+			return bch.Approve(sessionKey, tsc.DealsAddress, amount)
+		})
 		if err != nil {
-			showError(cmd, "Cannot approve tokens", err)
 			os.Exit(1)
 		}
-
-		printTransactionInfo(cmd, tx)
 	},
 }
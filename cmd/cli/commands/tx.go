@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+// txWaitFlag makes transaction-submitting commands wait for the receipt.
+var txWaitFlag bool
+
+// txReceiptWaiter waits for a submitted transaction to be mined.
+type txReceiptWaiter interface {
+	WaitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, error)
+}
+
+// submitAndReport runs the given transaction-submitting function and prints
+// the resulting transaction. With "--wait" it then blocks until the
+// transaction is mined and prints whether it is confirmed.
+//
+// Errors are shown to the user here, the caller only decides how to exit.
+func submitAndReport(cmd *cobra.Command, ctx context.Context, waiter txReceiptWaiter, message string, fn func() (*types.Transaction, error)) error {
+	tx, err := fn()
+	if err != nil {
+		showError(cmd, message, err)
+		return err
+	}
+
+	if !txWaitFlag {
+		printTransactionInfo(cmd, tx)
+		return nil
+	}
+
+	if isSimpleFormat() {
+		printTransactionInfo(cmd, tx)
+	}
+
+	receipt, err := waiter.WaitMined(ctx, tx)
+	if err != nil {
+		showError(cmd, "Cannot wait for transaction receipt", err)
+		return err
+	}
+
+	printTransactionReceipt(cmd, tx, receipt)
+	return nil
+}
+
+func formatReceiptStatus(receipt *types.Receipt) string {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return "confirmed"
+	}
+
+	return "failed"
+}
+
+// printTransactionReceipt completes the transaction info printed before
+// waiting in simple mode, while JSON mode gets a single object with both.
+func printTransactionReceipt(cmd *cobra.Command, tx *types.Transaction, receipt *types.Receipt) {
+	if isSimpleFormat() {
+		cmd.Printf("Status:    %s\r\n", formatReceiptStatus(receipt))
+		cmd.Printf("Gas used:  %d\r\n", receipt.GasUsed.Uint64())
+	} else {
+		info := convertTransactionInfo(tx)
+		info["status"] = formatReceiptStatus(receipt)
+		info["gas_used"] = receipt.GasUsed.Uint64()
+		showJSON(cmd, "transaction_receipt", info)
+	}
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sonm-io/core/cmd/cli/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+type fakeReceiptWaiter struct {
+	receipt *types.Receipt
+	err     error
+	waited  bool
+}
+
+func (w *fakeReceiptWaiter) WaitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	w.waited = true
+	return w.receipt, w.err
+}
+
+func makeTestTransaction() *types.Transaction {
+	return types.NewTransaction(1, common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD"),
+		big.NewInt(0), big.NewInt(21000), big.NewInt(20), nil)
+}
+
+func setTxWaitFlag(wait bool) func() {
+	prev := txWaitFlag
+	txWaitFlag = wait
+	return func() { txWaitFlag = prev }
+}
+
+func TestSubmitAndReportWait(t *testing.T) {
+	defer setTxWaitFlag(true)()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	tx := makeTestTransaction()
+	waiter := &fakeReceiptWaiter{
+		receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: big.NewInt(21000)},
+	}
+
+	err := submitAndReport(rootCmd, context.Background(), waiter, "Cannot approve tokens", func() (*types.Transaction, error) {
+		return tx, nil
+	})
+	require.NoError(t, err)
+
+	assert.True(t, waiter.waited)
+	assert.Contains(t, buf.String(), "Hash:      "+tx.Hash().String()+"\r\n")
+	assert.Contains(t, buf.String(), "Status:    confirmed\r\n")
+	assert.Contains(t, buf.String(), "Gas used:  21000\r\n")
+}
+
+func TestSubmitAndReportWaitJSON(t *testing.T) {
+	defer setTxWaitFlag(true)()
+	buf := initRootCmd(t, config.OutputModeJSON)
+
+	waiter := &fakeReceiptWaiter{
+		receipt: &types.Receipt{Status: types.ReceiptStatusFailed, GasUsed: big.NewInt(21000)},
+	}
+
+	err := submitAndReport(rootCmd, context.Background(), waiter, "Cannot approve tokens", func() (*types.Transaction, error) {
+		return makeTestTransaction(), nil
+	})
+	require.NoError(t, err)
+
+	v := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+	assert.Equal(t, "failed", v["status"])
+	assert.Equal(t, float64(21000), v["gas_used"])
+	assert.Contains(t, v, "hash")
+}
+
+func TestSubmitAndReportNoWait(t *testing.T) {
+	defer setTxWaitFlag(false)()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	waiter := &fakeReceiptWaiter{}
+	err := submitAndReport(rootCmd, context.Background(), waiter, "Cannot approve tokens", func() (*types.Transaction, error) {
+		return makeTestTransaction(), nil
+	})
+	require.NoError(t, err)
+
+	assert.False(t, waiter.waited)
+	assert.Contains(t, buf.String(), "Hash:      ")
+	assert.NotContains(t, buf.String(), "Status:")
+}
+
+func TestSubmitAndReportSubmitFailed(t *testing.T) {
+	defer setTxWaitFlag(true)()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	waiter := &fakeReceiptWaiter{}
+	err := submitAndReport(rootCmd, context.Background(), waiter, "Cannot approve tokens", func() (*types.Transaction, error) {
+		return nil, errors.New("insufficient funds")
+	})
+	require.Error(t, err)
+
+	assert.False(t, waiter.waited)
+	assert.Equal(t, "[ERR] Cannot approve tokens: insufficient funds\r\n", buf.String())
+}
+
+func TestSubmitAndReportWaitFailed(t *testing.T) {
+	defer setTxWaitFlag(true)()
+	buf := initRootCmd(t, config.OutputModeSimple)
+
+	waiter := &fakeReceiptWaiter{err: errors.New("connection refused")}
+	err := submitAndReport(rootCmd, context.Background(), waiter, "Cannot approve tokens", func() (*types.Transaction, error) {
+		return makeTestTransaction(), nil
+	})
+	require.Error(t, err)
+
+	assert.Contains(t, buf.String(), "Hash:      ")
+	assert.Contains(t, buf.String(), "[ERR] Cannot wait for transaction receipt: connection refused\r\n")
+}
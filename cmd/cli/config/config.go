@@ -12,6 +12,8 @@ import (
 const (
 	OutputModeSimple = "simple"
 	OutputModeJSON   = "json"
+	OutputModeTable  = "table"
+	OutputModeCSV    = "csv"
 	homeConfigPath   = ".sonm/cli.yaml"
 )
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	log "github.com/noxiouz/zapctx/ctxlog"
 	flag "github.com/ogier/pflag"
@@ -48,6 +50,14 @@ func main() {
 		log.G(ctx).Error("cannot start Locator service", zap.Error(err))
 		os.Exit(1)
 	}
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		<-c
+		log.G(ctx).Info("stopping Locator service")
+		lc.Close()
+	}()
+
 	log.G(ctx).Info("starting Locator service", zap.String("bind_addr", cfg.ListenAddr))
 	if err := lc.Serve(); err != nil {
 		log.G(ctx).Error("cannot start Locator service", zap.Error(err))
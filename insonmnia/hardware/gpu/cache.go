@@ -0,0 +1,116 @@
+package gpu
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultGPUCacheTTL is how long GetGPUDevices caches detected devices
+// unless configured otherwise.
+const DefaultGPUCacheTTL = 60 * time.Second
+
+var (
+	cachesMu sync.Mutex
+	cacheTTL = DefaultGPUCacheTTL
+	// caches are the package-level caches of GetGPUDevices by requested
+	// device types.
+	caches = map[DeviceType]*CachedEnumerator{}
+)
+
+// SetGPUCacheTTL sets how long GetGPUDevices caches detected devices, zero
+// disables caching. Devices cached so far are dropped.
+func SetGPUCacheTTL(ttl time.Duration) {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+
+	cacheTTL = ttl
+	caches = map[DeviceType]*CachedEnumerator{}
+}
+
+// InvalidateGPUCache makes the next GetGPUDevices call detect devices
+// again, for example after a hotplug event or a driver reload.
+func InvalidateGPUCache() {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+
+	for _, e := range caches {
+		e.Invalidate()
+	}
+}
+
+func cachedEnumerator(opts detectOptions) *CachedEnumerator {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+
+	e, ok := caches[opts.types]
+	if !ok {
+		e = newCachedEnumerator(cacheTTL, func() ([]Device, error) {
+			return detectGPUDevices(opts)
+		})
+		caches[opts.types] = e
+	}
+
+	return e
+}
+
+// CachedEnumerator remembers the result of GPU enumeration for some time,
+// because the hardware rarely changes while enumeration is expensive.
+type CachedEnumerator struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	enumerate BackendFunc
+	now       func() time.Time
+
+	devices   []Device
+	expiresAt time.Time
+	valid     bool
+}
+
+// NewCachedEnumerator constructs an enumerator caching results of
+// GetGPUDevices with the given options for the given duration, independently
+// of the package-level cache.
+func NewCachedEnumerator(ttl time.Duration, options ...DetectOption) *CachedEnumerator {
+	opts := newDetectOptions(options)
+	return newCachedEnumerator(ttl, func() ([]Device, error) {
+		return detectGPUDevices(opts)
+	})
+}
+
+func newCachedEnumerator(ttl time.Duration, enumerate BackendFunc) *CachedEnumerator {
+	return &CachedEnumerator{
+		ttl:       ttl,
+		enumerate: enumerate,
+		now:       time.Now,
+	}
+}
+
+// Devices returns cached devices, enumerating them again if the cache is
+// expired or invalidated. Errors are not cached, so the next call retries.
+func (e *CachedEnumerator) Devices() ([]Device, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.valid && e.now().Before(e.expiresAt) {
+		return e.devices, nil
+	}
+
+	devices, err := e.enumerate()
+	if err != nil {
+		return nil, err
+	}
+
+	e.devices = devices
+	e.expiresAt = e.now().Add(e.ttl)
+	e.valid = true
+
+	return devices, nil
+}
+
+// Invalidate drops the cached devices, for example after a hotplug event.
+func (e *CachedEnumerator) Invalidate() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.devices = nil
+	e.valid = false
+}
@@ -0,0 +1,97 @@
+package gpu
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedEnumeratorTTL(t *testing.T) {
+	d, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithVendorId(4098))
+	require.NoError(t, err)
+
+	calls := 0
+	e := newCachedEnumerator(time.Minute, func() ([]Device, error) {
+		calls++
+		return []Device{d}, nil
+	})
+
+	now := time.Now()
+	e.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		devices, err := e.Devices()
+		require.NoError(t, err)
+		assert.Len(t, devices, 1)
+	}
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(time.Minute)
+	_, err = e.Devices()
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	e.Invalidate()
+	_, err = e.Devices()
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestCachedEnumeratorDoesNotCacheErrors(t *testing.T) {
+	calls := 0
+	e := newCachedEnumerator(time.Minute, func() ([]Device, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("no platforms found")
+		}
+		return []Device{}, nil
+	})
+
+	_, err := e.Devices()
+	assert.Error(t, err)
+
+	_, err = e.Devices()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestGetGPUDevicesIsCached(t *testing.T) {
+	d, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithVendorId(4098))
+	require.NoError(t, err)
+
+	calls := 0
+	RegisterBackend("fake-counting", func() ([]Device, error) {
+		calls++
+		return []Device{d}, nil
+	})
+	defer unregisterBackend("fake-counting")
+
+	for i := 0; i < 3; i++ {
+		devices, err := GetGPUDevices()
+		require.NoError(t, err)
+		assert.Len(t, devices, 1)
+	}
+	assert.Equal(t, 1, calls)
+
+	// Other device types are cached separately.
+	_, err = GetGPUDevices(IncludeCPUDevices())
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	InvalidateGPUCache()
+	_, err = GetGPUDevices()
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	SetGPUCacheTTL(0)
+	defer SetGPUCacheTTL(DefaultGPUCacheTTL)
+
+	_, err = GetGPUDevices()
+	require.NoError(t, err)
+	_, err = GetGPUDevices()
+	require.NoError(t, err)
+	assert.Equal(t, 5, calls)
+}
@@ -19,8 +19,12 @@ package gpu
 import "C"
 
 import (
+	"context"
 	"fmt"
 	"unsafe"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"go.uber.org/zap"
 )
 
 const (
@@ -28,55 +32,21 @@ const (
 	maxDeviceCount = 64
 )
 
-// GetGPUDevicesUsingOpenCL returns a list of available GPU devices on the machine using OpenCL API.
+// GetGPUDevicesUsingOpenCL returns a list of available GPU devices on the
+// machine using OpenCL API. Devices of all platforms are returned, see
+// mergePlatformDevices for how they are merged.
 func GetGPUDevicesUsingOpenCL() ([]Device, error) {
 	platforms, err := getPlatforms()
 	if err != nil {
 		return nil, err
 	}
 
-	var result []Device
-
+	enumerators := make([]platformDevicesFunc, 0, len(platforms))
 	for _, platform := range platforms {
-		devices, err := platform.getGPUDevices()
-		if err != nil {
-			return nil, err
-		}
-
-		for _, d := range devices {
-			options := []Option{}
-			name, err := d.name()
-			if err != nil {
-				return nil, err
-			}
-			vendor, err := d.vendor()
-			if err != nil {
-				return nil, err
-			}
-			maxClockFrequency, err := d.deviceMaxClockFrequency()
-			if err != nil {
-				return nil, err
-			}
-			globalMemSize, err := d.globalMemSize()
-			if err != nil {
-				return nil, err
-			}
-			if vendorId, err := d.vendorId(); err == nil {
-				options = append(options, WithVendorId(vendorId))
-			}
-			if deviceVersion, err := d.deviceVersion(); err == nil {
-				options = append(options, WithOpenClDeviceVersion(deviceVersion))
-			}
-
-			device, err := NewDevice(name, vendor, uint64(maxClockFrequency), globalMemSize, options...)
-			if err != nil {
-				return nil, err
-			}
-			result = append(result, device)
-		}
+		enumerators = append(enumerators, platform.devices)
 	}
 
-	return result, nil
+	return mergePlatformDevices(context.Background(), enumerators)
 }
 
 type platform struct {
@@ -99,7 +69,59 @@ func getPlatforms() ([]*platform, error) {
 	return platforms, nil
 }
 
-func (p *platform) getGPUDevices() ([]*clDevice, error) {
+// devices returns all devices of the platform. Devices rejected by NewDevice,
+// for example reporting no memory, are skipped.
+func (p *platform) devices(ctx context.Context) ([]Device, error) {
+	devices, err := p.getDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Device
+	for _, d := range devices {
+		options := []Option{}
+		name, err := d.name()
+		if err != nil {
+			return nil, err
+		}
+		vendor, err := d.vendor()
+		if err != nil {
+			return nil, err
+		}
+		deviceType, err := d.deviceType()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, WithDeviceType(deviceType))
+		maxClockFrequency, err := d.deviceMaxClockFrequency()
+		if err != nil {
+			return nil, err
+		}
+		globalMemSize, err := d.globalMemSize()
+		if err != nil {
+			return nil, err
+		}
+		if vendorId, err := d.vendorId(); err == nil {
+			options = append(options, WithVendorId(vendorId))
+		}
+		if deviceVersion, err := d.deviceVersion(); err == nil {
+			options = append(options, WithOpenClDeviceVersion(deviceVersion))
+		}
+
+		device, err := NewDevice(name, vendor, uint64(maxClockFrequency), globalMemSize, options...)
+		if err != nil {
+			log.G(ctx).Warn("skipping unusable OpenCL device", zap.String("name", name), zap.Error(err))
+			continue
+		}
+		result = append(result, device)
+	}
+
+	return result, nil
+}
+
+// getDevices returns devices of all types, so CPUs and accelerators are
+// included and filtered out later if needed.
+func (p *platform) getDevices() ([]*clDevice, error) {
 	var ids [maxDeviceCount]C.cl_device_id
 	var num C.cl_uint
 
@@ -107,8 +129,8 @@ func (p *platform) getGPUDevices() ([]*clDevice, error) {
 		num = maxDeviceCount
 	}
 
-	if err := C.clGetDeviceIDs(p.id, C.cl_device_type(C.CL_DEVICE_TYPE_GPU), C.cl_uint(maxDeviceCount), &ids[0], &num); err != C.CL_SUCCESS {
-		return nil, fmt.Errorf("failed to obtain GPU devices for a platform: %s", err)
+	if err := C.clGetDeviceIDs(p.id, C.cl_device_type(C.CL_DEVICE_TYPE_ALL), C.cl_uint(maxDeviceCount), &ids[0], &num); err != C.CL_SUCCESS {
+		return nil, fmt.Errorf("failed to obtain devices for a platform: %s", err)
 	}
 
 	devices := make([]*clDevice, num)
@@ -166,6 +188,11 @@ func (d *clDevice) vendorId() (uint, error) {
 	return d.getInfoUint(C.CL_DEVICE_VENDOR_ID)
 }
 
+func (d *clDevice) deviceType() (DeviceType, error) {
+	deviceType, err := d.getInfoUint64(C.CL_DEVICE_TYPE)
+	return DeviceType(deviceType), err
+}
+
 func (d *clDevice) globalMemSize() (uint64, error) {
 	return d.getInfoUint64(C.CL_DEVICE_GLOBAL_MEM_SIZE)
 }
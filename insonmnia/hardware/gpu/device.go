@@ -1,8 +1,14 @@
 package gpu
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/cnf/structhash"
 	"github.com/sonm-io/core/proto"
@@ -10,6 +16,9 @@ import (
 
 var (
 	errMalformedOpenCLVersion = errors.New("malformed OpenCL device version string")
+	errMalformedPCIeLink      = errors.New("PCIe generation and link width must not be negative")
+	errZeroMemorySize         = errors.New("device max memory size must be positive")
+	errZeroClockFrequency     = errors.New("device max clock frequency must be positive")
 )
 
 // Device describes a GPU device.
@@ -33,10 +42,64 @@ type Device interface {
 	// OpenCLDeviceVersion returns the OpenCL minor version supported by the
 	// device.
 	OpenCLDeviceVersionMinor() int
+	// Type returns the device type, a GPU in most cases, but OpenCL
+	// platforms expose CPUs and accelerators too.
+	Type() DeviceType
+	// PCIeGeneration returns the generation of the PCIe link the device is
+	// attached to, or zero if unknown.
+	PCIeGeneration() int
+	// PCIeLinkWidth returns the number of lanes of the PCIe link the device
+	// is attached to, or zero if unknown.
+	PCIeLinkWidth() int
+	// UtilizationPercent returns the GPU utilization at the moment of
+	// detection, or UnknownSensorValue if the backend cannot read it.
+	UtilizationPercent() int
+	// TemperatureCelsius returns the GPU temperature at the moment of
+	// detection, or UnknownSensorValue if the backend cannot read it.
+	TemperatureCelsius() int
+	// ID returns an identifier that is the same for the same device
+	// detected by different backends.
+	ID() string
+	// Equal reports whether the other device has the same identity, i.e.
+	// the same hash.
+	Equal(other Device) bool
 
 	Hash() []byte
 }
 
+// UnknownSensorValue is returned by Device sensor readings not provided by
+// the backend, for example by OpenCL, to tell them from zero readings.
+const UnknownSensorValue = -1
+
+// DeviceType is a bit set of device types matching the OpenCL
+// CL_DEVICE_TYPE values.
+type DeviceType uint64
+
+const (
+	DeviceTypeCPU         DeviceType = 1 << 1
+	DeviceTypeGPU         DeviceType = 1 << 2
+	DeviceTypeAccelerator DeviceType = 1 << 3
+)
+
+func (t DeviceType) String() string {
+	var types []string
+	if t&DeviceTypeCPU != 0 {
+		types = append(types, "CPU")
+	}
+	if t&DeviceTypeGPU != 0 {
+		types = append(types, "GPU")
+	}
+	if t&DeviceTypeAccelerator != 0 {
+		types = append(types, "Accelerator")
+	}
+
+	if len(types) == 0 {
+		return "Unknown"
+	}
+
+	return strings.Join(types, "|")
+}
+
 type device struct {
 	d sonm.GPUDevice
 }
@@ -55,21 +118,105 @@ func WithVendorId(id uint) func(*sonm.GPUDevice) error {
 	}
 }
 
+// WithMaxMemorySize option sets the total memory size of the device in
+// bytes.
+func WithMaxMemorySize(size uint64) func(*sonm.GPUDevice) error {
+	return func(d *sonm.GPUDevice) error {
+		d.MaxMemorySize = size
+		return nil
+	}
+}
+
+// WithMaxClockFrequency option sets the max clock frequency of the device
+// in MHz.
+func WithMaxClockFrequency(frequency uint64) func(*sonm.GPUDevice) error {
+	return func(d *sonm.GPUDevice) error {
+		d.MaxClockFrequency = frequency
+		return nil
+	}
+}
+
 // WithOpenClDeviceVersion option sets OpenCL version.
 //
-// The format must be: `OpenCL <major.minor> <vendor-specific information>`.
+// The format must be: `OpenCL <major.minor> <vendor-specific information>`,
+// where the vendor-specific information is optional.
 func WithOpenClDeviceVersion(version string) func(*sonm.GPUDevice) error {
 	return func(d *sonm.GPUDevice) error {
-		var vendor string
-		n, err := fmt.Sscanf(version, "OpenCL %d.%d %s", &d.OpenCLDeviceVersionMajor, &d.OpenCLDeviceVersionMinor, &vendor)
-		if n < 2 {
-			return errMalformedOpenCLVersion
+		major, minor, err := parseOpenCLVersion(version)
+		if err != nil {
+			return err
 		}
 
-		if n == 2 && err != nil {
-			return nil
+		d.OpenCLDeviceVersionMajor = major
+		d.OpenCLDeviceVersionMinor = minor
+		return nil
+	}
+}
+
+func parseOpenCLVersion(version string) (major, minor int32, err error) {
+	fields := strings.Fields(version)
+	if len(fields) < 2 || fields[0] != "OpenCL" {
+		return 0, 0, malformedOpenCLVersion(version, `expected "OpenCL <major>.<minor>"`)
+	}
+
+	parts := strings.Split(fields[1], ".")
+	if len(parts) != 2 {
+		return 0, 0, malformedOpenCLVersion(version, "expected both major and minor versions")
+	}
+
+	v, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil || v < 0 {
+		return 0, 0, malformedOpenCLVersion(version, "invalid major version")
+	}
+	major = int32(v)
+
+	v, err = strconv.ParseInt(parts[1], 10, 32)
+	if err != nil || v < 0 {
+		return 0, 0, malformedOpenCLVersion(version, "invalid minor version")
+	}
+	minor = int32(v)
+
+	return major, minor, nil
+}
+
+func malformedOpenCLVersion(version, reason string) error {
+	return fmt.Errorf("%v %q: %s", errMalformedOpenCLVersion, version, reason)
+}
+
+// WithDeviceType option sets the device type. Devices are GPUs by default.
+func WithDeviceType(deviceType DeviceType) func(*sonm.GPUDevice) error {
+	return func(d *sonm.GPUDevice) error {
+		d.DeviceType = uint64(deviceType)
+		return nil
+	}
+}
+
+// WithPCIeLink option sets the PCIe generation and link width. Zero values
+// mean that the corresponding property is unknown.
+func WithPCIeLink(generation, width int) func(*sonm.GPUDevice) error {
+	return func(d *sonm.GPUDevice) error {
+		if generation < 0 || width < 0 {
+			return errMalformedPCIeLink
 		}
 
+		d.PcieGeneration = uint32(generation)
+		d.PcieLinkWidth = uint32(width)
+		return nil
+	}
+}
+
+// WithUtilization option sets the GPU utilization in percent.
+func WithUtilization(percent int) func(*sonm.GPUDevice) error {
+	return func(d *sonm.GPUDevice) error {
+		d.UtilizationPercent = int32(percent)
+		return nil
+	}
+}
+
+// WithTemperature option sets the GPU temperature in Celsius.
+func WithTemperature(celsius int) func(*sonm.GPUDevice) error {
+	return func(d *sonm.GPUDevice) error {
+		d.TemperatureCelsius = int32(celsius)
 		return nil
 	}
 }
@@ -82,12 +229,21 @@ func WithOpenClDeviceVersionSpec(major, minor int32) func(*sonm.GPUDevice) error
 	}
 }
 
+// NewDevice constructs a device with the given properties, which may be
+// overridden by options.
+//
+// Devices with unknown, i.e. zero, memory size or clock frequency are
+// rejected, because they cannot be scheduled properly.
 func NewDevice(name, vendorName string, maxClockFrequency, maxMemorySize uint64, options ...Option) (Device, error) {
 	d := sonm.GPUDevice{
 		Name:              name,
 		VendorName:        vendorName,
 		MaxClockFrequency: maxClockFrequency,
 		MaxMemorySize:     maxMemorySize,
+		DeviceType:        uint64(DeviceTypeGPU),
+		// Sensors are read by a few backends only.
+		UtilizationPercent: UnknownSensorValue,
+		TemperatureCelsius: UnknownSensorValue,
 	}
 
 	for _, option := range options {
@@ -96,6 +252,13 @@ func NewDevice(name, vendorName string, maxClockFrequency, maxMemorySize uint64,
 		}
 	}
 
+	if d.MaxMemorySize == 0 {
+		return nil, fmt.Errorf("%s: %v", name, errZeroMemorySize)
+	}
+	if d.MaxClockFrequency == 0 {
+		return nil, fmt.Errorf("%s: %v", name, errZeroClockFrequency)
+	}
+
 	return &device{d: d}, nil
 }
 
@@ -127,16 +290,337 @@ func (d *device) OpenCLDeviceVersionMinor() int {
 	return int(d.d.GetOpenCLDeviceVersionMinor())
 }
 
+func (d *device) Type() DeviceType {
+	return DeviceType(d.d.GetDeviceType())
+}
+
+func (d *device) PCIeGeneration() int {
+	return int(d.d.GetPcieGeneration())
+}
+
+func (d *device) PCIeLinkWidth() int {
+	return int(d.d.GetPcieLinkWidth())
+}
+
+func (d *device) UtilizationPercent() int {
+	return int(d.d.GetUtilizationPercent())
+}
+
+func (d *device) TemperatureCelsius() int {
+	return int(d.d.GetTemperatureCelsius())
+}
+
+// String renders the device in a human-readable form, for example
+// "GeForce GTX 1080 (NVIDIA, 8192 MB, 1733 MHz, PCIe Gen3 x16)". PCIe
+// properties are omitted when unknown.
+func (d *device) String() string {
+	props := []string{
+		d.VendorName(),
+		fmt.Sprintf("%d MB", d.MaxMemorySize()/(1<<20)),
+		fmt.Sprintf("%d MHz", d.MaxClockFrequency()),
+	}
+
+	var link []string
+	if d.PCIeGeneration() != 0 {
+		link = append(link, fmt.Sprintf("Gen%d", d.PCIeGeneration()))
+	}
+	if d.PCIeLinkWidth() != 0 {
+		link = append(link, fmt.Sprintf("x%d", d.PCIeLinkWidth()))
+	}
+	if len(link) != 0 {
+		props = append(props, "PCIe "+strings.Join(link, " "))
+	}
+
+	return fmt.Sprintf("%s (%s)", d.Name(), strings.Join(props, ", "))
+}
+
+func (d *device) ID() string {
+	return hex.EncodeToString(d.Hash())
+}
+
+func (d *device) Equal(other Device) bool {
+	return other != nil && bytes.Equal(d.Hash(), other.Hash())
+}
+
+// DiffDevices compares two lists of devices, for example capabilities
+// reported by a worker at different times, and returns devices missing in
+// the previous list and devices missing in the current one. Identical cards
+// are counted, so losing one of two similar GPUs is reported.
+func DiffDevices(previous, current []Device) (added, removed []Device) {
+	count := map[string]int{}
+	for _, d := range previous {
+		count[d.ID()]++
+	}
+
+	for _, d := range current {
+		if count[d.ID()] > 0 {
+			count[d.ID()]--
+		} else {
+			added = append(added, d)
+		}
+	}
+
+	for _, d := range previous {
+		if count[d.ID()] > 0 {
+			count[d.ID()]--
+			removed = append(removed, d)
+		}
+	}
+
+	return added, removed
+}
+
+// deviceIdentity are the properties of a physical device, which are hashed.
+// Sensor readings and the current PCIe link, which may be downgraded to save
+// power, change at runtime, so they are left out to keep the hash constant.
+type deviceIdentity struct {
+	VendorId                 uint64
+	Name                     string
+	MaxMemorySize            uint64
+	MaxClockFrequency        uint64
+	OpenCLDeviceVersionMajor int32
+	OpenCLDeviceVersionMinor int32
+	DeviceType               uint64
+}
+
 func (d *device) Hash() []byte {
-	return structhash.Md5(d.d, 1)
+	return HashDevice(&d.d)
+}
+
+// HashDevice returns the hash of a marshalled device, the same as its Hash
+// method returns.
+func HashDevice(d *sonm.GPUDevice) []byte {
+	return structhash.Md5(deviceIdentity{
+		VendorId:                 d.GetVendorId(),
+		Name:                     d.GetName(),
+		MaxMemorySize:            d.GetMaxMemorySize(),
+		MaxClockFrequency:        d.GetMaxClockFrequency(),
+		OpenCLDeviceVersionMajor: d.GetOpenCLDeviceVersionMajor(),
+		OpenCLDeviceVersionMinor: d.GetOpenCLDeviceVersionMinor(),
+		DeviceType:               d.GetDeviceType(),
+	}, 1)
+}
+
+// BackendFunc detects GPU devices using some specific API.
+type BackendFunc func() ([]Device, error)
+
+type backend struct {
+	name string
+	fn   BackendFunc
+}
+
+var (
+	backendsMu sync.Mutex
+	backends   []backend
+)
+
+func init() {
+	RegisterBackend("nvml", GetGPUDevicesUsingNVML)
+	RegisterBackend("opencl", GetGPUDevicesUsingOpenCL)
+	RegisterBackend("rocm-smi", GetGPUDevicesUsingROCmSMI)
+}
+
+// RegisterBackend makes a GPU detection backend available to GetGPUDevices.
+//
+// Registering a backend with the name of an already registered one
+// replaces it. Devices cached by GetGPUDevices are dropped.
+func RegisterBackend(name string, fn BackendFunc) {
+	// Invalidating waits for running detections, which lock the backends.
+	defer InvalidateGPUCache()
+
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	for id := range backends {
+		if backends[id].name == name {
+			backends[id].fn = fn
+			return
+		}
+	}
+
+	backends = append(backends, backend{name: name, fn: fn})
+}
+
+type detectOptions struct {
+	types DeviceType
+}
+
+// DetectOption configures GetGPUDevices.
+type DetectOption func(*detectOptions)
+
+// IncludeCPUDevices makes GetGPUDevices also return CPUs exposed by OpenCL
+// platforms.
+func IncludeCPUDevices() DetectOption {
+	return func(o *detectOptions) {
+		o.types |= DeviceTypeCPU
+	}
 }
 
 // GetGPUDevices returns a list of available GPU devices on the machine.
-func GetGPUDevices() ([]Device, error) {
-	devices, err := GetGPUDevicesUsingOpenCL()
+//
+// All registered backends are queried in order, and their results are
+// merged with duplicates removed. Backends registered earlier take
+// precedence: devices of a vendor already detected by a previous backend
+// are skipped, so for example OpenCL serves only as a fallback for NVIDIA
+// cards found by NVML. An error is returned only if no backend succeeded.
+//
+// Only GPUs and accelerators are returned unless asked otherwise.
+//
+// Results are cached for some time, see SetGPUCacheTTL.
+func GetGPUDevices(options ...DetectOption) ([]Device, error) {
+	return GetGPUDevicesContext(context.Background(), options...)
+}
+
+// GetGPUDevicesContext is like GetGPUDevices, but gives up waiting for the
+// detection when the context is done, returning the context error.
+//
+// A wedged driver cannot be interrupted, so the detection itself keeps
+// running in background, and its result is cached once it completes.
+func GetGPUDevicesContext(ctx context.Context, options ...DetectOption) ([]Device, error) {
+	// Contexts that are never done do not need a separate goroutine.
+	if ctx.Done() == nil {
+		return getGPUDevices(options)
+	}
+
+	type result struct {
+		devices []Device
+		err     error
+	}
+
+	// Buffered, so the detection completing after the caller has given up
+	// does not block forever.
+	done := make(chan result, 1)
+	go func() {
+		devices, err := getGPUDevices(options)
+		done <- result{devices: devices, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.devices, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func getGPUDevices(options []DetectOption) ([]Device, error) {
+	devices, err := cachedEnumerator(newDetectOptions(options)).Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	// Protect the cached slice from modifications by callers.
+	result := make([]Device, len(devices))
+	copy(result, devices)
+
+	return result, nil
+}
+
+// GetGPUDevicesFiltered returns GPU devices having at least the given memory
+// size in bytes, made by any of the given vendors. Vendors are matched by a
+// case-insensitive substring of the vendor name, so "nvidia" matches
+// "NVIDIA Corporation". Zero memory size and no vendors match any device.
+func GetGPUDevicesFiltered(minMemory uint64, vendors []string) ([]Device, error) {
+	devices, err := GetGPUDevices()
 	if err != nil {
 		return nil, err
 	}
 
-	return devices, nil
+	result := make([]Device, 0, len(devices))
+	for _, device := range devices {
+		if device.MaxMemorySize() >= minMemory && matchVendor(device.VendorName(), vendors) {
+			result = append(result, device)
+		}
+	}
+
+	return result, nil
+}
+
+func matchVendor(name string, vendors []string) bool {
+	if len(vendors) == 0 {
+		return true
+	}
+
+	name = strings.ToLower(name)
+	for _, vendor := range vendors {
+		if strings.Contains(name, strings.ToLower(vendor)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newDetectOptions(options []DetectOption) detectOptions {
+	opts := detectOptions{types: DeviceTypeGPU | DeviceTypeAccelerator}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return opts
+}
+
+// detectGPUDevices queries all registered backends bypassing the cache.
+func detectGPUDevices(opts detectOptions) ([]Device, error) {
+	backendsMu.Lock()
+	registered := make([]backend, len(backends))
+	copy(registered, backends)
+	backendsMu.Unlock()
+
+	var (
+		result    []Device
+		lastErr   error
+		succeeded bool
+		// seen counts devices detected by previous backends. Identical
+		// cards have the same ID, so a rig of similar GPUs is counted
+		// rather than collapsed into a single device.
+		seen = map[string]int{}
+		// vendors are the vendors detected by previous backends.
+		vendors = map[uint]bool{}
+	)
+
+	for _, b := range registered {
+		devices, err := b.fn()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		succeeded = true
+		detected := map[uint]bool{}
+		found := map[string]int{}
+		for _, d := range devices {
+			if d.Type()&opts.types == 0 || vendors[d.VendorId()] {
+				continue
+			}
+
+			// Skip as many copies of the device as have already been
+			// detected by previous backends, but never dedupe cards
+			// within the result of a single backend.
+			found[d.ID()]++
+			if found[d.ID()] <= seen[d.ID()] {
+				continue
+			}
+
+			result = append(result, d)
+			if d.VendorId() != 0 {
+				detected[d.VendorId()] = true
+			}
+		}
+
+		for id, count := range found {
+			if count > seen[id] {
+				seen[id] = count
+			}
+		}
+		for vendor := range detected {
+			vendors[vendor] = true
+		}
+	}
+
+	if !succeeded && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return result, nil
 }
@@ -0,0 +1,393 @@
+package gpu
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGPUDevicesMergesBackends(t *testing.T) {
+	d1, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithVendorId(4098))
+	require.NoError(t, err)
+	d2, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithVendorId(4318))
+	require.NoError(t, err)
+	// The same device as d1 detected by another backend.
+	d1Dup, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithVendorId(4098))
+	require.NoError(t, err)
+
+	RegisterBackend("fake1", func() ([]Device, error) { return []Device{d1}, nil })
+	RegisterBackend("fake2", func() ([]Device, error) { return []Device{d1Dup, d2}, nil })
+	defer unregisterBackend("fake1")
+	defer unregisterBackend("fake2")
+
+	devices, err := GetGPUDevices()
+	require.NoError(t, err)
+
+	found := map[string]int{}
+	for _, d := range devices {
+		found[d.Name()]++
+	}
+
+	assert.Equal(t, 1, found["Radeon RX 580"])
+	assert.Equal(t, 1, found["GeForce GTX 1080"])
+}
+
+func TestGetGPUDevicesCountsIdenticalCards(t *testing.T) {
+	rx580 := func() Device {
+		// No vendor id, so later backends are not skipped per vendor.
+		d, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592)
+		require.NoError(t, err)
+		return d
+	}
+
+	RegisterBackend("fake1", func() ([]Device, error) { return []Device{rx580(), rx580()}, nil })
+	RegisterBackend("fake2", func() ([]Device, error) { return []Device{rx580(), rx580(), rx580()}, nil })
+	defer unregisterBackend("fake1")
+	defer unregisterBackend("fake2")
+
+	devices, err := GetGPUDevices()
+	require.NoError(t, err)
+	// Cards found by both backends are reported once, the extra one found
+	// by the second backend only is kept.
+	assert.Len(t, devices, 3)
+}
+
+func TestGetGPUDevicesFallsBackPerVendor(t *testing.T) {
+	nvml, err := NewDevice("GeForce GTX 1080", "NVIDIA Corporation", 1733, 8589934592, WithVendorId(0x10de))
+	require.NoError(t, err)
+	// The same card as seen by OpenCL, which reports less memory.
+	opencl, err := NewDevice("GeForce GTX 1080", "NVIDIA Corporation", 1733, 8506048512, WithVendorId(0x10de))
+	require.NoError(t, err)
+	amd, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithVendorId(0x1002))
+	require.NoError(t, err)
+
+	RegisterBackend("fake-nvml", func() ([]Device, error) { return []Device{nvml}, nil })
+	RegisterBackend("fake-opencl", func() ([]Device, error) { return []Device{opencl, amd}, nil })
+	defer unregisterBackend("fake-nvml")
+	defer unregisterBackend("fake-opencl")
+
+	devices, err := GetGPUDevices()
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, uint64(8589934592), devices[0].MaxMemorySize())
+	assert.Equal(t, "Radeon RX 580", devices[1].Name())
+
+	// Without NVML devices OpenCL ones are used.
+	RegisterBackend("fake-nvml", func() ([]Device, error) { return nil, fmt.Errorf("NVML is not available") })
+
+	devices, err = GetGPUDevices()
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, uint64(8506048512), devices[0].MaxMemorySize())
+}
+
+func TestNVMLBackendIsPreferred(t *testing.T) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	require.True(t, len(backends) >= 2)
+	assert.Equal(t, "nvml", backends[0].name)
+	assert.Equal(t, "opencl", backends[1].name)
+}
+
+// unregisterBackend removes a backend registered by a test, so it does not
+// affect other tests.
+func unregisterBackend(name string) {
+	defer InvalidateGPUCache()
+
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	for id := range backends {
+		if backends[id].name == name {
+			backends = append(backends[:id], backends[id+1:]...)
+			return
+		}
+	}
+}
+
+func deviceNames(devices []Device) []string {
+	var result []string
+	for _, d := range devices {
+		result = append(result, d.Name())
+	}
+	return result
+}
+
+func TestGetGPUDevicesFiltersByType(t *testing.T) {
+	cpu, err := NewDevice("Intel(R) Core(TM) i7", "Intel", 3400, 17179869184, WithDeviceType(DeviceTypeCPU))
+	require.NoError(t, err)
+	gpu, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592)
+	require.NoError(t, err)
+	accelerator, err := NewDevice("Xeon Phi", "Intel", 1100, 17179869184, WithDeviceType(DeviceTypeAccelerator))
+	require.NoError(t, err)
+
+	RegisterBackend("fake-platform", func() ([]Device, error) { return []Device{cpu, gpu, accelerator}, nil })
+	defer unregisterBackend("fake-platform")
+
+	devices, err := GetGPUDevices()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GeForce GTX 1080", "Xeon Phi"}, deviceNames(devices))
+
+	devices, err = GetGPUDevices(IncludeCPUDevices())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Intel(R) Core(TM) i7", "GeForce GTX 1080", "Xeon Phi"}, deviceNames(devices))
+}
+
+func TestDeviceTypeIsHashed(t *testing.T) {
+	gpu, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592)
+	require.NoError(t, err)
+	accelerator, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithDeviceType(DeviceTypeAccelerator))
+	require.NoError(t, err)
+
+	assert.Equal(t, DeviceTypeGPU, gpu.Type())
+	assert.NotEqual(t, gpu.ID(), accelerator.ID())
+}
+
+func TestUnmarshalDeviceType(t *testing.T) {
+	d, err := Unmarshal(&sonm.GPUDevice{Name: "Radeon RX 580", MaxClockFrequency: 1340, MaxMemorySize: 8589934592})
+	require.NoError(t, err)
+	assert.Equal(t, DeviceTypeGPU, d.Type())
+
+	d, err = Unmarshal(Marshal(mustNewDevice(t, WithDeviceType(DeviceTypeCPU))))
+	require.NoError(t, err)
+	assert.Equal(t, DeviceTypeCPU, d.Type())
+}
+
+func TestNewDeviceRejectsZeroMemoryAndClock(t *testing.T) {
+	_, err := NewDevice("Radeon RX 580", "AMD", 1340, 0)
+	assert.EqualError(t, err, "Radeon RX 580: device max memory size must be positive")
+
+	_, err = NewDevice("Radeon RX 580", "AMD", 0, 8589934592)
+	assert.EqualError(t, err, "Radeon RX 580: device max clock frequency must be positive")
+
+	_, err = Unmarshal(&sonm.GPUDevice{Name: "Radeon RX 580"})
+	assert.Error(t, err)
+}
+
+func TestNewDeviceWithMemoryAndClockOptions(t *testing.T) {
+	d, err := NewDevice("Radeon RX 580", "AMD", 0, 0, WithMaxMemorySize(8589934592), WithMaxClockFrequency(1340))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8589934592), d.MaxMemorySize())
+	assert.Equal(t, uint(1340), d.MaxClockFrequency())
+
+	// Options override positional values.
+	d = mustNewDevice(t, WithMaxMemorySize(4294967296))
+	assert.Equal(t, uint64(4294967296), d.MaxMemorySize())
+}
+
+func TestWithOpenClDeviceVersion(t *testing.T) {
+	for _, version := range []string{"OpenCL 2.1 CUDA", "OpenCL 2.1", "OpenCL 2.1 AMD-APP (2527.3)"} {
+		d, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithOpenClDeviceVersion(version))
+		require.NoError(t, err, version)
+		assert.Equal(t, 2, d.OpenCLDeviceVersionMajor(), version)
+		assert.Equal(t, 1, d.OpenCLDeviceVersionMinor(), version)
+	}
+}
+
+func TestWithOpenClDeviceVersionMalformed(t *testing.T) {
+	for _, version := range []string{"", "OpenCL", "OpenCL 2", "OpenCL 2.", "OpenCL .1", "OpenCL 2.1.0", "OpenCL two.one", "CUDA 2.1", "OpenCL -2.1"} {
+		_, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithOpenClDeviceVersion(version))
+		require.Error(t, err, version)
+		assert.Contains(t, err.Error(), fmt.Sprintf("%q", version))
+	}
+
+	_, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithOpenClDeviceVersion("OpenCL 2"))
+	assert.EqualError(t, err, `malformed OpenCL device version string "OpenCL 2": expected both major and minor versions`)
+}
+
+func mustNewDevice(t *testing.T, options ...Option) Device {
+	d, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, options...)
+	require.NoError(t, err)
+	return d
+}
+
+func TestDeviceTypeString(t *testing.T) {
+	assert.Equal(t, "GPU", DeviceTypeGPU.String())
+	assert.Equal(t, "CPU|Accelerator", (DeviceTypeCPU | DeviceTypeAccelerator).String())
+	assert.Equal(t, "Unknown", DeviceType(0).String())
+}
+
+func TestDevicePCIeLink(t *testing.T) {
+	d, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithPCIeLink(3, 16))
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, d.PCIeGeneration())
+	assert.Equal(t, 16, d.PCIeLinkWidth())
+	assert.Equal(t, "GeForce GTX 1080 (NVIDIA, 8192 MB, 1733 MHz, PCIe Gen3 x16)", d.(fmt.Stringer).String())
+
+	// The link may be narrowed at runtime to save power.
+	narrow, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithPCIeLink(3, 1))
+	require.NoError(t, err)
+	assert.Equal(t, d.Hash(), narrow.Hash())
+
+	restored, err := Unmarshal(Marshal(d))
+	require.NoError(t, err)
+	assert.Equal(t, 3, restored.PCIeGeneration())
+	assert.Equal(t, 16, restored.PCIeLinkWidth())
+}
+
+func TestDeviceNoPCIeLink(t *testing.T) {
+	d, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, d.PCIeGeneration())
+	assert.Equal(t, 0, d.PCIeLinkWidth())
+	assert.Equal(t, "GeForce GTX 1080 (NVIDIA, 8192 MB, 1733 MHz)", d.(fmt.Stringer).String())
+}
+
+func TestWithPCIeLinkNegative(t *testing.T) {
+	_, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithPCIeLink(-1, 16))
+	assert.Error(t, err)
+}
+
+func TestDeviceSensors(t *testing.T) {
+	d, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592)
+	require.NoError(t, err)
+	assert.Equal(t, UnknownSensorValue, d.UtilizationPercent())
+	assert.Equal(t, UnknownSensorValue, d.TemperatureCelsius())
+
+	busy, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithUtilization(0), WithTemperature(65))
+	require.NoError(t, err)
+	assert.Equal(t, 0, busy.UtilizationPercent())
+	assert.Equal(t, 65, busy.TemperatureCelsius())
+	// Readings change over time, while the device stays the same.
+	assert.Equal(t, d.ID(), busy.ID())
+
+	restored, err := Unmarshal(Marshal(d))
+	require.NoError(t, err)
+	assert.Equal(t, UnknownSensorValue, restored.UtilizationPercent())
+	assert.Equal(t, UnknownSensorValue, restored.TemperatureCelsius())
+
+	restored, err = Unmarshal(Marshal(busy))
+	require.NoError(t, err)
+	assert.Equal(t, 0, restored.UtilizationPercent())
+	assert.Equal(t, 65, restored.TemperatureCelsius())
+}
+
+func TestGetGPUDevicesFiltered(t *testing.T) {
+	small, err := NewDevice("Radeon RX 560", "Advanced Micro Devices, Inc.", 1275, 4<<30)
+	require.NoError(t, err)
+	large, err := NewDevice("Radeon RX Vega 64", "AMD", 1630, 8<<30)
+	require.NoError(t, err)
+	nvidia, err := NewDevice("GeForce GTX 1080 Ti", "NVIDIA Corporation", 1582, 11<<30)
+	require.NoError(t, err)
+
+	RegisterBackend("fake1", func() ([]Device, error) { return []Device{small, large, nvidia}, nil })
+	defer unregisterBackend("fake1")
+
+	devices, err := GetGPUDevicesFiltered(0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Radeon RX 560", "Radeon RX Vega 64", "GeForce GTX 1080 Ti"}, deviceNames(devices))
+
+	devices, err = GetGPUDevicesFiltered(8<<30, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Radeon RX Vega 64", "GeForce GTX 1080 Ti"}, deviceNames(devices))
+
+	devices, err = GetGPUDevicesFiltered(8<<30, []string{"nvidia"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GeForce GTX 1080 Ti"}, deviceNames(devices))
+
+	devices, err = GetGPUDevicesFiltered(0, []string{"amd", "ADVANCED MICRO DEVICES"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Radeon RX 560", "Radeon RX Vega 64"}, deviceNames(devices))
+
+	devices, err = GetGPUDevicesFiltered(0, []string{"Intel"})
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+}
+
+func TestDeviceHashCoversIdentity(t *testing.T) {
+	d, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithVendorId(4318))
+	require.NoError(t, err)
+
+	// The vendor name is reported differently by backends.
+	renamed, err := NewDevice("GeForce GTX 1080", "NVIDIA Corporation", 1733, 8589934592, WithVendorId(4318))
+	require.NoError(t, err)
+	assert.Equal(t, d.ID(), renamed.ID())
+
+	others := []Option{
+		WithVendorId(4098),
+		WithMaxMemorySize(4294967296),
+		WithMaxClockFrequency(1607),
+		WithOpenClDeviceVersionSpec(1, 2),
+		WithDeviceType(DeviceTypeAccelerator),
+	}
+	for _, option := range others {
+		other, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithVendorId(4318), option)
+		require.NoError(t, err)
+		assert.NotEqual(t, d.ID(), other.ID())
+	}
+
+	other, err := NewDevice("GeForce GTX 1070", "NVIDIA", 1733, 8589934592, WithVendorId(4318))
+	require.NoError(t, err)
+	assert.NotEqual(t, d.ID(), other.ID())
+}
+
+func TestDeviceEqual(t *testing.T) {
+	d := mustNewDevice(t)
+	restored, err := Unmarshal(Marshal(d))
+	require.NoError(t, err)
+
+	assert.True(t, d.Equal(restored))
+	assert.False(t, d.Equal(mustNewDevice(t, WithMaxMemorySize(4294967296))))
+	assert.False(t, d.Equal(nil))
+}
+
+func TestDiffDevices(t *testing.T) {
+	gtx1080 := func() Device {
+		d, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592)
+		require.NoError(t, err)
+		return d
+	}
+	rx580, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592)
+	require.NoError(t, err)
+	vega, err := NewDevice("Radeon RX Vega 64", "AMD", 1630, 8589934592)
+	require.NoError(t, err)
+
+	old := []Device{gtx1080(), gtx1080(), rx580}
+
+	added, removed := DiffDevices(old, []Device{rx580, gtx1080(), gtx1080()})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+
+	// One of two similar cards has failed, and another one is installed.
+	added, removed = DiffDevices(old, []Device{gtx1080(), rx580, vega})
+	assert.Equal(t, []string{"Radeon RX Vega 64"}, deviceNames(added))
+	assert.Equal(t, []string{"GeForce GTX 1080"}, deviceNames(removed))
+
+	added, removed = DiffDevices(nil, old)
+	assert.Len(t, added, 3)
+	assert.Empty(t, removed)
+}
+
+func TestGetGPUDevicesContextWedgedBackend(t *testing.T) {
+	d, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithVendorId(4098))
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	RegisterBackend("fake-wedged", func() ([]Device, error) {
+		<-release
+		return []Device{d}, nil
+	})
+	defer unregisterBackend("fake-wedged")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err = GetGPUDevicesContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(started) < time.Second)
+
+	// The abandoned detection completes in background.
+	close(release)
+	devices, err := GetGPUDevicesContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Radeon RX 580"}, deviceNames(devices))
+}
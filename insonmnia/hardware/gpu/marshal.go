@@ -24,6 +24,11 @@ func Marshal(d Device) *sonm.GPUDevice {
 		MaxClockFrequency:        uint64(d.MaxClockFrequency()),
 		OpenCLDeviceVersionMajor: int32(d.OpenCLDeviceVersionMajor()),
 		OpenCLDeviceVersionMinor: int32(d.OpenCLDeviceVersionMinor()),
+		DeviceType:               uint64(d.Type()),
+		PcieGeneration:           uint32(d.PCIeGeneration()),
+		PcieLinkWidth:            uint32(d.PCIeLinkWidth()),
+		UtilizationPercent:       int32(d.UtilizationPercent()),
+		TemperatureCelsius:       int32(d.TemperatureCelsius()),
 	}
 }
 
@@ -41,13 +46,24 @@ func UnmarshalDevices(d []*sonm.GPUDevice) ([]Device, error) {
 }
 
 func Unmarshal(proto *sonm.GPUDevice) (Device, error) {
+	options := []Option{
+		WithVendorId(uint(proto.GetVendorId())),
+		WithOpenClDeviceVersionSpec(proto.GetOpenCLDeviceVersionMajor(), proto.GetOpenCLDeviceVersionMinor()),
+		WithPCIeLink(int(proto.GetPcieGeneration()), int(proto.GetPcieLinkWidth())),
+		WithUtilization(int(proto.GetUtilizationPercent())),
+		WithTemperature(int(proto.GetTemperatureCelsius())),
+	}
+	// Devices reported by older workers have no type, they are GPUs.
+	if proto.GetDeviceType() != 0 {
+		options = append(options, WithDeviceType(DeviceType(proto.GetDeviceType())))
+	}
+
 	device, err := NewDevice(
 		proto.GetName(),
 		proto.GetVendorName(),
 		proto.GetMaxClockFrequency(),
 		proto.GetMaxMemorySize(),
-		WithVendorId(uint(proto.GetVendorId())),
-		WithOpenClDeviceVersionSpec(proto.GetOpenCLDeviceVersionMajor(), proto.GetOpenCLDeviceVersionMinor()),
+		options...,
 	)
 	if err != nil {
 		return nil, err
@@ -65,5 +81,8 @@ func (d *device) MarshalJSON() ([]byte, error) {
 		"maxClockFrequency":        d.MaxClockFrequency(),
 		"openCLDeviceVersionMajor": d.OpenCLDeviceVersionMajor(),
 		"openCLDeviceVersionMinor": d.OpenCLDeviceVersionMinor(),
+		"deviceType":               d.Type().String(),
+		"pcieGeneration":           d.PCIeGeneration(),
+		"pcieLinkWidth":            d.PCIeLinkWidth(),
 	})
 }
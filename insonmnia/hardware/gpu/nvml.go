@@ -0,0 +1,92 @@
+// +build nvml
+
+package gpu
+
+// #cgo LDFLAGS: -lnvidia-ml
+// #include <nvml.h>
+import "C"
+
+import (
+	"fmt"
+)
+
+const (
+	// nvidiaVendorId is the PCI vendor id of NVIDIA, the same as reported
+	// by OpenCL.
+	nvidiaVendorId   = 0x10de
+	nvidiaVendorName = "NVIDIA Corporation"
+)
+
+// GetGPUDevicesUsingNVML returns a list of NVIDIA GPU devices on the machine
+// using the NVML library shipped with the driver, which reports the true
+// memory size of the devices and does not require an OpenCL ICD.
+func GetGPUDevicesUsingNVML() ([]Device, error) {
+	if err := C.nvmlInit(); err != C.NVML_SUCCESS {
+		return nil, nvmlError("failed to initialize NVML", err)
+	}
+	defer C.nvmlShutdown()
+
+	var count C.uint
+	if err := C.nvmlDeviceGetCount(&count); err != C.NVML_SUCCESS {
+		return nil, nvmlError("failed to obtain NVML device count", err)
+	}
+
+	result := make([]Device, 0, int(count))
+	for idx := C.uint(0); idx < count; idx++ {
+		var handle C.nvmlDevice_t
+		if err := C.nvmlDeviceGetHandleByIndex(idx, &handle); err != C.NVML_SUCCESS {
+			return nil, nvmlError(fmt.Sprintf("failed to obtain NVML device #%d", idx), err)
+		}
+
+		device, err := newNVMLDevice(handle)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, device)
+	}
+
+	return result, nil
+}
+
+func newNVMLDevice(handle C.nvmlDevice_t) (Device, error) {
+	var name [C.NVML_DEVICE_NAME_BUFFER_SIZE]C.char
+	if err := C.nvmlDeviceGetName(handle, &name[0], C.NVML_DEVICE_NAME_BUFFER_SIZE); err != C.NVML_SUCCESS {
+		return nil, nvmlError("failed to obtain device name", err)
+	}
+
+	var memory C.nvmlMemory_t
+	if err := C.nvmlDeviceGetMemoryInfo(handle, &memory); err != C.NVML_SUCCESS {
+		return nil, nvmlError("failed to obtain device memory info", err)
+	}
+
+	var clock C.uint
+	if err := C.nvmlDeviceGetMaxClockInfo(handle, C.NVML_CLOCK_GRAPHICS, &clock); err != C.NVML_SUCCESS {
+		return nil, nvmlError("failed to obtain device max clock", err)
+	}
+
+	options := []Option{WithVendorId(nvidiaVendorId)}
+
+	// PCIe link properties are optional, for example they are not
+	// supported on some virtualized devices.
+	var generation, width C.uint
+	if C.nvmlDeviceGetCurrPcieLinkGeneration(handle, &generation) == C.NVML_SUCCESS &&
+		C.nvmlDeviceGetCurrPcieLinkWidth(handle, &width) == C.NVML_SUCCESS {
+		options = append(options, WithPCIeLink(int(generation), int(width)))
+	}
+
+	// So are sensors, which are missing on some older cards.
+	var utilization C.nvmlUtilization_t
+	if C.nvmlDeviceGetUtilizationRates(handle, &utilization) == C.NVML_SUCCESS {
+		options = append(options, WithUtilization(int(utilization.gpu)))
+	}
+	var temperature C.uint
+	if C.nvmlDeviceGetTemperature(handle, C.NVML_TEMPERATURE_GPU, &temperature) == C.NVML_SUCCESS {
+		options = append(options, WithTemperature(int(temperature)))
+	}
+
+	return NewDevice(C.GoString(&name[0]), nvidiaVendorName, uint64(clock), uint64(memory.total), options...)
+}
+
+func nvmlError(msg string, err C.nvmlReturn_t) error {
+	return fmt.Errorf("%s: %s", msg, C.GoString(C.nvmlErrorString(err)))
+}
@@ -0,0 +1,11 @@
+// +build !nvml
+
+package gpu
+
+import "errors"
+
+var errNVMLUnsupported = errors.New("built without NVML support")
+
+func GetGPUDevicesUsingNVML() ([]Device, error) {
+	return nil, errNVMLUnsupported
+}
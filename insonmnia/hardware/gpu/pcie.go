@@ -0,0 +1,102 @@
+package gpu
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// sysfsPCIDevicesDir is where the kernel exposes PCI devices.
+var sysfsPCIDevicesDir = "/sys/bus/pci/devices"
+
+// pcieGenerations maps link transfer rates as shown by sysfs to PCIe
+// generations.
+var pcieGenerations = map[string]int{
+	"2.5":  1,
+	"5":    2,
+	"5.0":  2,
+	"8":    3,
+	"8.0":  3,
+	"16":   4,
+	"16.0": 4,
+	"32":   5,
+	"32.0": 5,
+}
+
+// readPCIeLink reads the current PCIe generation and link width of the
+// device with the given bus address, for example "0000:03:00.0".
+func readPCIeLink(busID string) (generation, width int, err error) {
+	dir := filepath.Join(sysfsPCIDevicesDir, busID)
+
+	speed, err := ioutil.ReadFile(filepath.Join(dir, "current_link_speed"))
+	if err != nil {
+		return 0, 0, err
+	}
+	generation, err = parsePCIeLinkSpeed(string(speed))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	lanes, err := ioutil.ReadFile(filepath.Join(dir, "current_link_width"))
+	if err != nil {
+		return 0, 0, err
+	}
+	width, err = strconv.Atoi(strings.TrimSpace(string(lanes)))
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed PCIe link width: %v", err)
+	}
+
+	return generation, width, nil
+}
+
+// parsePCIeLinkSpeed converts a sysfs link speed string, like "8.0 GT/s" or
+// "8.0 GT/s PCIe" on newer kernels, to a PCIe generation.
+func parsePCIeLinkSpeed(speed string) (int, error) {
+	fields := strings.Fields(speed)
+	if len(fields) < 2 || fields[1] != "GT/s" {
+		return 0, fmt.Errorf("malformed PCIe link speed: %q", speed)
+	}
+
+	generation, ok := pcieGenerations[fields[0]]
+	if !ok {
+		return 0, fmt.Errorf("unknown PCIe link speed: %q", speed)
+	}
+
+	return generation, nil
+}
+
+// readSensors reads the GPU utilization and temperature exposed by the
+// amdgpu driver for the device with the given bus address. Readings that
+// are not exposed are UnknownSensorValue.
+func readSensors(busID string) (utilization, temperature int) {
+	dir := filepath.Join(sysfsPCIDevicesDir, busID)
+
+	utilization = UnknownSensorValue
+	if v, err := readSysfsInt(filepath.Join(dir, "gpu_busy_percent")); err == nil {
+		utilization = v
+	}
+
+	// The temperature is reported in millidegrees by the hwmon device of
+	// the card.
+	temperature = UnknownSensorValue
+	inputs, _ := filepath.Glob(filepath.Join(dir, "hwmon", "hwmon*", "temp1_input"))
+	for _, input := range inputs {
+		if v, err := readSysfsInt(input); err == nil {
+			temperature = v / 1000
+			break
+		}
+	}
+
+	return utilization, temperature
+}
+
+func readSysfsInt(path string) (int, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,107 @@
+package gpu
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setSysfsPCIDevicesDir(dir string) func() {
+	prev := sysfsPCIDevicesDir
+	sysfsPCIDevicesDir = dir
+	return func() { sysfsPCIDevicesDir = prev }
+}
+
+func writePCIDevice(t *testing.T, root, busID, speed, width string) {
+	dir := filepath.Join(root, busID)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "current_link_speed"), []byte(speed), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "current_link_width"), []byte(width), 0644))
+}
+
+func TestParsePCIeLinkSpeed(t *testing.T) {
+	cases := map[string]int{
+		"2.5 GT/s\n":       1,
+		"5.0 GT/s":         2,
+		"8.0 GT/s PCIe\n":  3,
+		"16.0 GT/s PCIe\n": 4,
+	}
+
+	for speed, expected := range cases {
+		generation, err := parsePCIeLinkSpeed(speed)
+		require.NoError(t, err, speed)
+		assert.Equal(t, expected, generation, speed)
+	}
+
+	_, err := parsePCIeLinkSpeed("Unknown speed\n")
+	assert.Error(t, err)
+	_, err = parsePCIeLinkSpeed("")
+	assert.Error(t, err)
+}
+
+func TestReadPCIeLink(t *testing.T) {
+	root, err := ioutil.TempDir("", "sysfs")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer setSysfsPCIDevicesDir(root)()
+
+	writePCIDevice(t, root, "0000:03:00.0", "8.0 GT/s PCIe\n", "16\n")
+
+	generation, width, err := readPCIeLink("0000:03:00.0")
+	require.NoError(t, err)
+	assert.Equal(t, 3, generation)
+	assert.Equal(t, 16, width)
+
+	_, _, err = readPCIeLink("0000:04:00.0")
+	assert.Error(t, err)
+}
+
+func TestParseROCmSMIPCIeLink(t *testing.T) {
+	root, err := ioutil.TempDir("", "sysfs")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer setSysfsPCIDevicesDir(root)()
+
+	writePCIDevice(t, root, "0000:03:00.0", "8.0 GT/s\n", "8\n")
+
+	output := `{
+		"card0": {"Card series": "Vega 10 XT", "VRAM Total Memory (B)": "8573157376", "sclk clock speed:": "(1630Mhz)", "PCI Bus": "0000:03:00.0"},
+		"card1": {"Card series": "Ellesmere", "VRAM Total Memory (B)": "8589934592", "sclk clock speed:": "(1340Mhz)", "PCI Bus": "0000:04:00.0"}
+	}`
+
+	devices, err := parseROCmSMI(context.Background(), []byte(output))
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+
+	assert.Equal(t, 3, devices[0].PCIeGeneration())
+	assert.Equal(t, 8, devices[0].PCIeLinkWidth())
+	// No sysfs entry, so the link is unknown.
+	assert.Equal(t, 0, devices[1].PCIeGeneration())
+	assert.Equal(t, 0, devices[1].PCIeLinkWidth())
+}
+
+func TestReadSensors(t *testing.T) {
+	root, err := ioutil.TempDir("", "sysfs")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer setSysfsPCIDevicesDir(root)()
+
+	writePCIDevice(t, root, "0000:03:00.0", "8.0 GT/s\n", "16\n")
+	dir := filepath.Join(root, "0000:03:00.0")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "gpu_busy_percent"), []byte("42\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "hwmon", "hwmon3"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "hwmon", "hwmon3", "temp1_input"), []byte("57000\n"), 0644))
+
+	utilization, temperature := readSensors("0000:03:00.0")
+	assert.Equal(t, 42, utilization)
+	assert.Equal(t, 57, temperature)
+
+	utilization, temperature = readSensors("0000:04:00.0")
+	assert.Equal(t, UnknownSensorValue, utilization)
+	assert.Equal(t, UnknownSensorValue, temperature)
+}
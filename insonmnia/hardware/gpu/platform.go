@@ -0,0 +1,61 @@
+package gpu
+
+import (
+	"context"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"go.uber.org/zap"
+)
+
+// platformDevicesFunc enumerates devices of a single OpenCL platform.
+type platformDevicesFunc func(ctx context.Context) ([]Device, error)
+
+// mergePlatformDevices collects devices of all OpenCL platforms, returning
+// devices exposed by several platforms once. Identical cards of a single
+// platform are all kept, because they cannot be told apart by their
+// properties.
+//
+// A platform failing to enumerate its devices, for example because of a
+// broken driver, is skipped, so it does not hide devices of the others. An
+// error is returned only if all platforms have failed.
+func mergePlatformDevices(ctx context.Context, platforms []platformDevicesFunc) ([]Device, error) {
+	var (
+		result    []Device
+		lastErr   error
+		succeeded bool
+		// seen counts devices exposed by previous platforms.
+		seen = map[string]int{}
+	)
+
+	for id, enumerate := range platforms {
+		devices, err := enumerate(ctx)
+		if err != nil {
+			log.G(ctx).Warn("skipping OpenCL platform", zap.Int("platform", id), zap.Error(err))
+			lastErr = err
+			continue
+		}
+
+		succeeded = true
+		found := map[string]int{}
+		for _, d := range devices {
+			found[d.ID()]++
+			if found[d.ID()] <= seen[d.ID()] {
+				continue
+			}
+
+			result = append(result, d)
+		}
+
+		for key, count := range found {
+			if count > seen[key] {
+				seen[key] = count
+			}
+		}
+	}
+
+	if !succeeded && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package gpu
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePlatformDevices(t *testing.T) {
+	amd, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithVendorId(4098))
+	require.NoError(t, err)
+	nvidia, err := NewDevice("GeForce GTX 1080", "NVIDIA", 1733, 8589934592, WithVendorId(4318))
+	require.NoError(t, err)
+	// The same card exposed by another platform.
+	amdDup, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithVendorId(4098))
+	require.NoError(t, err)
+
+	devices, err := mergePlatformDevices(context.Background(), []platformDevicesFunc{
+		func(context.Context) ([]Device, error) { return []Device{amd}, nil },
+		func(context.Context) ([]Device, error) { return nil, errors.New("broken driver") },
+		func(context.Context) ([]Device, error) { return []Device{amdDup, nvidia}, nil },
+	})
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, "Radeon RX 580", devices[0].Name())
+	assert.Equal(t, "GeForce GTX 1080", devices[1].Name())
+}
+
+func TestMergePlatformDevicesCountsIdenticalCards(t *testing.T) {
+	rx580, err := NewDevice("Radeon RX 580", "AMD", 1340, 8589934592, WithVendorId(4098))
+	require.NoError(t, err)
+
+	devices, err := mergePlatformDevices(context.Background(), []platformDevicesFunc{
+		func(context.Context) ([]Device, error) { return []Device{rx580, rx580, rx580}, nil },
+		// Another platform exposing two of the three cards.
+		func(context.Context) ([]Device, error) { return []Device{rx580, rx580}, nil },
+	})
+	require.NoError(t, err)
+	assert.Len(t, devices, 3)
+}
+
+func TestMergePlatformDevicesAllFailed(t *testing.T) {
+	_, err := mergePlatformDevices(context.Background(), []platformDevicesFunc{
+		func(context.Context) ([]Device, error) { return nil, errors.New("broken driver") },
+	})
+	assert.Error(t, err)
+
+	devices, err := mergePlatformDevices(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, devices)
+}
@@ -0,0 +1,114 @@
+package gpu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"go.uber.org/zap"
+)
+
+const (
+	rocmSMIBinary = "rocm-smi"
+	// amdVendorId is the PCI vendor id of AMD, the same as reported by
+	// OpenCL.
+	amdVendorId = 0x1002
+)
+
+var rocmClockRe = regexp.MustCompile(`(?i)\((\d+)\s*mhz\)`)
+
+// GetGPUDevicesUsingROCmSMI returns a list of AMD GPU devices reported by
+// the "rocm-smi" tool, which works even without a working OpenCL ICD.
+//
+// Machines without the tool installed have no devices to report, which is
+// not an error, so it does not hide errors of other backends.
+func GetGPUDevicesUsingROCmSMI() ([]Device, error) {
+	path, err := exec.LookPath(rocmSMIBinary)
+	if err != nil {
+		return nil, nil
+	}
+
+	output, err := exec.Command(path, "--showproductname", "--showmeminfo", "vram", "--showclocks", "--showbus", "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %v", rocmSMIBinary, err)
+	}
+
+	return parseROCmSMI(context.Background(), output)
+}
+
+// parseROCmSMI parses JSON output of rocm-smi, which is an object of cards,
+// each being a flat object of string properties, for example:
+//
+//	{"card0": {"Card series": "Vega 10 XT [Radeon RX Vega 64]",
+//	           "VRAM Total Memory (B)": "8573157376",
+//	           "sclk clock speed:": "(1630Mhz)",
+//	           "PCI Bus": "0000:03:00.0"}}
+//
+// PCIe link properties and sensor readings are read from sysfs using the
+// bus address, and are left unknown if that fails. Cards with malformed or
+// unusable properties are skipped, so they do not hide the others.
+func parseROCmSMI(ctx context.Context, data []byte) ([]Device, error) {
+	cards := map[string]map[string]string{}
+	if err := json.Unmarshal(data, &cards); err != nil {
+		return nil, fmt.Errorf("malformed %s output: %v", rocmSMIBinary, err)
+	}
+
+	names := make([]string, 0, len(cards))
+	for name := range cards {
+		if strings.HasPrefix(name, "card") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	devices := make([]Device, 0, len(names))
+	for _, name := range names {
+		card := cards[name]
+
+		model := card["Card series"]
+		if model == "" {
+			model = card["Card model"]
+		}
+
+		var memory uint64
+		if v, ok := card["VRAM Total Memory (B)"]; ok {
+			parsed, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				log.G(ctx).Warn("skipping ROCm device with malformed memory size", zap.String("card", name), zap.Error(err))
+				continue
+			}
+			memory = parsed
+		}
+
+		var clock uint64
+		if m := rocmClockRe.FindStringSubmatch(card["sclk clock speed:"]); m != nil {
+			clock, _ = strconv.ParseUint(m[1], 10, 64)
+		}
+
+		options := []Option{WithVendorId(amdVendorId)}
+		if busID, ok := card["PCI Bus"]; ok {
+			if generation, width, err := readPCIeLink(busID); err == nil {
+				options = append(options, WithPCIeLink(generation, width))
+			}
+
+			utilization, temperature := readSensors(busID)
+			options = append(options, WithUtilization(utilization), WithTemperature(temperature))
+		}
+
+		device, err := NewDevice(model, "AMD", clock, memory, options...)
+		if err != nil {
+			log.G(ctx).Warn("skipping unusable ROCm device", zap.String("card", name), zap.Error(err))
+			continue
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
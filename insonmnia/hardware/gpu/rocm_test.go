@@ -0,0 +1,77 @@
+package gpu
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseROCmSMI(t *testing.T) {
+	output := `{
+		"card1": {
+			"Card series": "Ellesmere [Radeon RX 470/480/570/570X/580/580X]",
+			"Card model": "0x67df",
+			"VRAM Total Memory (B)": "8589934592",
+			"sclk clock speed:": "(1340Mhz)"
+		},
+		"card0": {
+			"Card model": "0x687f",
+			"VRAM Total Memory (B)": "8573157376",
+			"sclk clock speed:": "(1630Mhz)"
+		},
+		"system": {"Driver version": "5.6.0"}
+	}`
+
+	devices, err := parseROCmSMI(context.Background(), []byte(output))
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+
+	assert.Equal(t, "0x687f", devices[0].Name())
+	assert.Equal(t, uint(1630), devices[0].MaxClockFrequency())
+
+	assert.Equal(t, "Ellesmere [Radeon RX 470/480/570/570X/580/580X]", devices[1].Name())
+	assert.Equal(t, "AMD", devices[1].VendorName())
+	assert.Equal(t, uint(amdVendorId), devices[1].VendorId())
+	assert.Equal(t, uint64(8589934592), devices[1].MaxMemorySize())
+	assert.Equal(t, uint(1340), devices[1].MaxClockFrequency())
+}
+
+func TestParseROCmSMIMalformed(t *testing.T) {
+	_, err := parseROCmSMI(context.Background(), []byte(`WARNING: No AMD GPUs specified`))
+	assert.Error(t, err)
+}
+
+func TestParseROCmSMISkipsUnusableCards(t *testing.T) {
+	output := `{
+		"card0": {"VRAM Total Memory (B)": "lots"},
+		"card1": {"Card series": "Vega 10 XT", "sclk clock speed:": "(1630Mhz)"},
+		"card2": {
+			"Card series": "Ellesmere",
+			"VRAM Total Memory (B)": "8589934592",
+			"sclk clock speed:": "(1340Mhz)"
+		}
+	}`
+
+	devices, err := parseROCmSMI(context.Background(), []byte(output))
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "Ellesmere", devices[0].Name())
+}
+
+func TestGetGPUDevicesUsingROCmSMIMissing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rocm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := os.Getenv("PATH")
+	defer os.Setenv("PATH", path)
+	os.Setenv("PATH", dir)
+
+	devices, err := GetGPUDevicesUsingROCmSMI()
+	assert.NoError(t, err)
+	assert.Empty(t, devices)
+}
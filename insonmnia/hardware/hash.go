@@ -0,0 +1,77 @@
+package hardware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"sort"
+
+	"github.com/cnf/structhash"
+	"github.com/sonm-io/core/insonmnia/hardware/gpu"
+	"github.com/sonm-io/core/proto"
+)
+
+// HashCapabilities returns a digest of the whole capability set of a
+// machine, which changes only if its hardware has changed.
+//
+// GPUs are detected by several backends in no particular order, so the
+// digest does not depend on the order of devices. Only total RAM is
+// accounted, because used memory is a runtime state rather than hardware.
+// For the same reason GPU sensor readings and PCIe link are left out, see
+// gpu.HashDevice.
+func HashCapabilities(caps *sonm.Capabilities) []byte {
+	h := sha256.New()
+
+	cpus := make([][]byte, 0, len(caps.GetCpu()))
+	for _, d := range caps.GetCpu() {
+		if d != nil {
+			cpus = append(cpus, structhash.Md5(*d, 1))
+		}
+	}
+	writeHashSection(h, "cpu", cpus)
+
+	gpus := make([][]byte, 0, len(caps.GetGpu()))
+	for _, d := range caps.GetGpu() {
+		if d != nil {
+			gpus = append(gpus, gpu.HashDevice(d))
+		}
+	}
+	writeHashSection(h, "gpu", gpus)
+
+	var total [8]byte
+	binary.BigEndian.PutUint64(total[:], caps.GetMem().GetTotal())
+	writeHashSection(h, "mem", [][]byte{total[:]})
+
+	return h.Sum(nil)
+}
+
+// fingerprintSize is the number of digest bytes kept in a fingerprint,
+// enough to tell machines of a single owner apart.
+const fingerprintSize = 8
+
+// Fingerprint returns a short hex digest of the machine hardware for
+// humans to compare and copy. Like HashCapabilities, it is stable across
+// reboots and device enumeration orders.
+func Fingerprint(caps *sonm.Capabilities) string {
+	return hex.EncodeToString(HashCapabilities(caps)[:fingerprintSize])
+}
+
+// writeHashSection feeds sorted items into the hash, prefixed with the
+// section name and the number of items, so that items can't move between
+// sections without changing the digest.
+func writeHashSection(h hash.Hash, name string, items [][]byte) {
+	sort.Slice(items, func(i, j int) bool {
+		return bytes.Compare(items[i], items[j]) < 0
+	})
+
+	var count [8]byte
+	binary.BigEndian.PutUint64(count[:], uint64(len(items)))
+
+	h.Write([]byte(name))
+	h.Write(count[:])
+	for _, item := range items {
+		h.Write(item)
+	}
+}
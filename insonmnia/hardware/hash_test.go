@@ -0,0 +1,104 @@
+package hardware
+
+import (
+	"testing"
+
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestCapabilities() *sonm.Capabilities {
+	return &sonm.Capabilities{
+		Cpu: []*sonm.CPUDevice{
+			{Num: 0, VendorId: "GenuineIntel", ModelName: "Intel(R) Core(TM) i7", Cores: 4, Flags: []string{"sse4_2"}},
+		},
+		Mem: &sonm.RAMDevice{Total: 17179869184, Used: 4294967296},
+		Gpu: []*sonm.GPUDevice{
+			{Name: "GeForce GTX 1080", VendorName: "NVIDIA", MaxMemorySize: 8589934592},
+			{Name: "Radeon RX 580", VendorName: "AMD", MaxMemorySize: 8589934592},
+		},
+	}
+}
+
+func TestHashCapabilitiesGPUOrderInsensitive(t *testing.T) {
+	caps := makeTestCapabilities()
+	reordered := makeTestCapabilities()
+	reordered.Gpu[0], reordered.Gpu[1] = reordered.Gpu[1], reordered.Gpu[0]
+
+	assert.Equal(t, HashCapabilities(caps), HashCapabilities(reordered))
+}
+
+func TestHashCapabilitiesDetectsChanges(t *testing.T) {
+	changes := map[string]func(caps *sonm.Capabilities){
+		"cpu cores":  func(caps *sonm.Capabilities) { caps.Cpu[0].Cores = 8 },
+		"cpu flags":  func(caps *sonm.Capabilities) { caps.Cpu[0].Flags = nil },
+		"cpu added":  func(caps *sonm.Capabilities) { caps.Cpu = append(caps.Cpu, &sonm.CPUDevice{Num: 1}) },
+		"gpu memory": func(caps *sonm.Capabilities) { caps.Gpu[1].MaxMemorySize = 4294967296 },
+		"gpu type":   func(caps *sonm.Capabilities) { caps.Gpu[0].DeviceType = 8 },
+		"gpu gone":   func(caps *sonm.Capabilities) { caps.Gpu = caps.Gpu[:1] },
+		"ram total":  func(caps *sonm.Capabilities) { caps.Mem.Total = 8589934592 },
+		"no ram":     func(caps *sonm.Capabilities) { caps.Mem = nil },
+	}
+
+	expected := HashCapabilities(makeTestCapabilities())
+	for name, change := range changes {
+		caps := makeTestCapabilities()
+		change(caps)
+		assert.NotEqual(t, expected, HashCapabilities(caps), name)
+	}
+}
+
+func TestHashCapabilitiesIgnoresUsedMemory(t *testing.T) {
+	caps := makeTestCapabilities()
+	caps.Mem.Used = 0
+
+	assert.Equal(t, HashCapabilities(makeTestCapabilities()), HashCapabilities(caps))
+}
+
+func TestHashCapabilitiesIgnoresGPURuntimeState(t *testing.T) {
+	caps := makeTestCapabilities()
+	caps.Gpu[0].UtilizationPercent = 97
+	caps.Gpu[0].TemperatureCelsius = 83
+	// The link is downgraded when the card is idle.
+	caps.Gpu[1].PcieGeneration = 1
+	caps.Gpu[1].PcieLinkWidth = 8
+
+	assert.Equal(t, HashCapabilities(makeTestCapabilities()), HashCapabilities(caps))
+}
+
+func TestHashCapabilitiesNil(t *testing.T) {
+	assert.NotPanics(t, func() {
+		assert.Equal(t, HashCapabilities(nil), HashCapabilities(&sonm.Capabilities{}))
+	})
+}
+
+func TestFingerprintStable(t *testing.T) {
+	caps := makeTestCapabilities()
+	fingerprint := Fingerprint(caps)
+	assert.Len(t, fingerprint, 16)
+
+	// Detected again after a reboot, with GPUs in another order and other
+	// memory usage.
+	rebooted := makeTestCapabilities()
+	rebooted.Gpu[0], rebooted.Gpu[1] = rebooted.Gpu[1], rebooted.Gpu[0]
+	rebooted.Mem.Used = 1073741824
+
+	assert.Equal(t, fingerprint, Fingerprint(rebooted))
+}
+
+func TestFingerprintSensitive(t *testing.T) {
+	changes := map[string]func(caps *sonm.Capabilities){
+		"cpu model":     func(caps *sonm.Capabilities) { caps.Cpu[0].ModelName = "Intel(R) Core(TM) i9" },
+		"cpu frequency": func(caps *sonm.Capabilities) { caps.Cpu[0].ClockFrequency = 3400 },
+		"gpu name":      func(caps *sonm.Capabilities) { caps.Gpu[0].Name = "GeForce GTX 1080 Ti" },
+		"gpu clock":     func(caps *sonm.Capabilities) { caps.Gpu[1].MaxClockFrequency = 1340 },
+		"ram total":     func(caps *sonm.Capabilities) { caps.Mem.Total = 34359738368 },
+	}
+
+	expected := Fingerprint(makeTestCapabilities())
+	for name, change := range changes {
+		caps := makeTestCapabilities()
+		change(caps)
+		assert.NotEqual(t, expected, Fingerprint(caps), name)
+	}
+}
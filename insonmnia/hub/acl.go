@@ -3,6 +3,7 @@ package hub
 import (
 	"encoding/json"
 	"sync"
+	"time"
 
 	"gopkg.in/fatih/set.v0"
 )
@@ -24,16 +25,23 @@ type ACLStorage interface {
 	// Traversal will continue until all items in the Set have been visited,
 	// or if the closure returns false.
 	Each(fn func(string) bool)
+	// RegisteredAt returns the time the given worker credentials were
+	// inserted at. It is unknown for credentials received from the cluster.
+	RegisteredAt(credentials string) (time.Time, bool)
 }
 
 type workerACLStorage struct {
 	storage *set.SetNonTS
-	mu      sync.RWMutex
+	// registeredAt is kept locally, it is not a part of the synchronized
+	// state.
+	registeredAt map[string]time.Time
+	mu           sync.RWMutex
 }
 
 func NewACLStorage() ACLStorage {
 	return &workerACLStorage{
-		storage: set.NewNonTS(),
+		storage:      set.NewNonTS(),
+		registeredAt: map[string]time.Time{},
 	}
 }
 
@@ -64,12 +72,23 @@ func (s *workerACLStorage) UnmarshalJSON(data []byte) error {
 	for _, val := range unmarshalled {
 		s.storage.Add(val)
 	}
+	for credentials := range s.registeredAt {
+		if !s.storage.Has(credentials) {
+			delete(s.registeredAt, credentials)
+		}
+	}
 	return nil
 }
 
 func (s *workerACLStorage) Insert(credentials string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if s.registeredAt == nil {
+		s.registeredAt = map[string]time.Time{}
+	}
+	if !s.storage.Has(credentials) {
+		s.registeredAt[credentials] = time.Now()
+	}
 	s.storage.Add(credentials)
 }
 
@@ -79,6 +98,7 @@ func (s *workerACLStorage) Remove(credentials string) bool {
 	exists := s.storage.Has(credentials)
 	if exists {
 		s.storage.Remove(credentials)
+		delete(s.registeredAt, credentials)
 	}
 	return exists
 }
@@ -96,3 +116,10 @@ func (s *workerACLStorage) Each(fn func(string) bool) {
 		return fn(credentials.(string))
 	})
 }
+
+func (s *workerACLStorage) RegisteredAt(credentials string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ts, ok := s.registeredAt[credentials]
+	return ts, ok
+}
@@ -0,0 +1,41 @@
+package hub
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLStorageRegisteredAt(t *testing.T) {
+	acl := NewACLStorage()
+	acl.Insert("0x1")
+
+	ts, ok := acl.RegisteredAt("0x1")
+	require.True(t, ok)
+
+	// Registering again keeps the original time.
+	acl.Insert("0x1")
+	ts2, _ := acl.RegisteredAt("0x1")
+	assert.Equal(t, ts, ts2)
+
+	acl.Remove("0x1")
+	_, ok = acl.RegisteredAt("0x1")
+	assert.False(t, ok)
+}
+
+func TestACLStorageRegisteredAtNotSynchronized(t *testing.T) {
+	acl := NewACLStorage()
+	acl.Insert("0x1")
+
+	data, err := json.Marshal(acl)
+	require.NoError(t, err)
+	assert.Equal(t, `["0x1"]`, string(data))
+
+	other := NewACLStorage()
+	require.NoError(t, json.Unmarshal(data, other))
+	assert.True(t, other.Has("0x1"))
+	_, ok := other.RegisteredAt("0x1")
+	assert.False(t, ok)
+}
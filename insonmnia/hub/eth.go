@@ -3,9 +3,12 @@ package hub
 import (
 	"context"
 	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
 	"time"
 
 	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/pkg/errors"
 	"github.com/sonm-io/core/blockchain"
 	"github.com/sonm-io/core/insonmnia/structs"
 	pb "github.com/sonm-io/core/proto"
@@ -13,9 +16,35 @@ import (
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrDealNotOwned is returned for deals supplied by someone else.
+	ErrDealNotOwned error = &dealUnusableError{reason: "deal is supplied by another hub"}
+	// ErrDealNotAccepted is returned for deals that are not accepted, either
+	// yet or anymore.
+	ErrDealNotAccepted error = &dealUnusableError{reason: "deal is not accepted"}
+)
+
+// dealUnusableError describes why the hub cannot use a deal. Clients get
+// all such errors as ErrDealNotFound, see isDealUnusable.
+type dealUnusableError struct {
+	reason string
+}
+
+func (e *dealUnusableError) Error() string {
+	return e.reason
+}
+
+// isDealUnusable reports whether the deal exists but cannot be used by the
+// hub, as opposed to failing to get the deal info at all.
+func isDealUnusable(err error) bool {
+	_, ok := errors.Cause(err).(*dealUnusableError)
+	return ok
+}
+
 type ETH interface {
-	// WaitForDealCreated waits for deal created on Buyer-side
-	WaitForDealCreated(request *structs.DealRequest) (*pb.Deal, error)
+	// WaitForDealCreated waits for deal created on Buyer-side until the
+	// context is canceled or the deal wait timeout is reached.
+	WaitForDealCreated(ctx context.Context, request *structs.DealRequest) (*pb.Deal, error)
 	// WaitForDealClosed blocks the current execution context until the
 	// specified deal is closed.
 	WaitForDealClosed(ctx context.Context, dealID DealID, buyerID string) error
@@ -23,88 +52,195 @@ type ETH interface {
 	// AcceptDeal approves deal on Hub-side
 	AcceptDeal(id string) error
 
-	// GetDeal checks whether a given deal exists.
+	// GetDeal checks whether a given deal exists, is supplied by us and
+	// accepted, returning ErrDealNotOwned or ErrDealNotAccepted otherwise.
 	GetDeal(id string) (*pb.Deal, error)
+
+	// GetMyDeals returns deals with the given status where the client's own
+	// address acts either as a buyer or as a supplier.
+	GetMyDeals(status pb.DealStatus) ([]*pb.Deal, error)
+
+	// FindDealBySpecHash returns a pending or accepted deal with the given
+	// counterparty and specification hash, looking through the blockchain
+	// once without waiting. Empty addr means any counterparty.
+	FindDealBySpecHash(addr, hash string) (*pb.Deal, error)
 }
 
-const defaultDealWaitTimeout = 900 * time.Second
+const (
+	defaultDealWaitTimeout = 900 * time.Second
+	// defaultFindDealPollInterval and defaultDealClosedPollInterval are
+	// used unless the poll interval is configured.
+	defaultFindDealPollInterval   = 3 * time.Second
+	defaultDealClosedPollInterval = 5 * time.Second
+	// maxFindDealBackoff limits the delay between searches for a deal while
+	// the blockchain is failing.
+	maxFindDealBackoff = 30 * time.Second
+)
+
+// findDealJitter returns a random duration in [0, n), replaced in tests.
+var findDealJitter = rand.Int63n
 
 type eth struct {
-	key     *ecdsa.PrivateKey
-	bc      blockchain.Blockchainer
-	ctx     context.Context
-	timeout time.Duration
+	// key is the primary key, used for all new transactions.
+	key *ecdsa.PrivateKey
+	// previousKeys are rotated-out keys that still own some deals.
+	previousKeys []*ecdsa.PrivateKey
+	bc           blockchain.Blockchainer
+	ctx          context.Context
+	timeout      time.Duration
+	// pollInterval is how often the blockchain is polled for deals, zero
+	// means the defaults.
+	pollInterval time.Duration
 }
 
-func (e *eth) WaitForDealCreated(request *structs.DealRequest) (*pb.Deal, error) {
+func (e *eth) WaitForDealCreated(ctx context.Context, request *structs.DealRequest) (*pb.Deal, error) {
 	// e.findDeals blocks until order will be found or timeout will reached
-	return e.findDeals(e.ctx, request.Order.ByuerID, request.SpecHash)
+	return e.findDeals(ctx, request.Order.ByuerID, request.SpecHash)
 }
 
 func (e *eth) WaitForDealClosed(ctx context.Context, dealID DealID, buyerID string) error {
 	log.G(ctx).Debug("waiting for deal closed", zap.String("dealID", string(dealID)))
 
-	timer := time.NewTicker(5 * time.Second)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Deals may be closed by any of our addresses, so subscribe to all hubs
+	// and match deal ids.
+	closed, err := e.bc.SubscribeDealClosed(ctx, "", buyerID)
+	if err != nil {
+		log.G(ctx).Debug("cannot subscribe to closed deals, falling back to polling", zap.Error(err))
+		return e.pollDealClosed(ctx, dealID, buyerID)
+	}
+
+	// The deal may have been closed before subscribing.
+	if done, err := e.isDealClosed(ctx, dealID, buyerID); err != nil || done {
+		return err
+	}
+
+	for {
+		select {
+		case id, ok := <-closed:
+			if !ok {
+				log.G(ctx).Debug("closed deals subscription is broken, falling back to polling")
+				return e.pollDealClosed(ctx, dealID, buyerID)
+			}
+
+			if id.String() == string(dealID) {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (e *eth) pollDealClosed(ctx context.Context, dealID DealID, buyerID string) error {
+	timer := time.NewTicker(e.pollIntervalOr(defaultDealClosedPollInterval))
 	defer timer.Stop()
 
 	for {
 		select {
 		case <-timer.C:
-			log.G(ctx).Debug("checking whether deal is closed")
-
-			ids, err := e.bc.GetClosedDeal(util.PubKeyToAddr(e.key.PublicKey).Hex(), buyerID)
-			if err != nil {
+			if done, err := e.isDealClosed(ctx, dealID, buyerID); err != nil || done {
 				return err
 			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
 
-			log.G(ctx).Info("found some closed deals", zap.Int("count", len(ids)))
+func (e *eth) isDealClosed(ctx context.Context, dealID DealID, buyerID string) (bool, error) {
+	log.G(ctx).Debug("checking whether deal is closed")
 
-			for _, id := range ids {
-				dealInfo, err := e.bc.GetDealInfo(id)
-				if err != nil {
-					continue
-				}
+	var ids []*big.Int
+	for _, addr := range e.addrs() {
+		found, err := e.bc.GetClosedDeal(addr, buyerID)
+		if err != nil {
+			return false, err
+		}
+		ids = append(ids, found...)
+	}
 
-				if dealInfo.GetId() == string(dealID) && dealInfo.GetStatus() == pb.DealStatus_CLOSED {
-					return nil
-				}
-			}
+	log.G(ctx).Info("found some closed deals", zap.Int("count", len(ids)))
 
-		case <-ctx.Done():
-			return ctx.Err()
+	for _, id := range ids {
+		dealInfo, err := e.bc.GetDealInfo(id)
+		if err != nil {
+			continue
+		}
+
+		if dealInfo.GetId() == string(dealID) && dealInfo.GetStatus() == pb.DealStatus_CLOSED {
+			return true, nil
 		}
 	}
+
+	return false, nil
 }
 
 func (e *eth) findDeals(ctx context.Context, addr, hash string) (*pb.Deal, error) {
-	// TODO(sshaman1101): make if configurable?
-	ctx, cancel := context.WithTimeout(e.ctx, e.timeout)
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
 	defer cancel()
 
-	tk := time.NewTicker(3 * time.Second)
-	defer tk.Stop()
-
-	if deal := e.findDealOnce(addr, hash); deal != nil {
-		return deal, nil
-	}
+	interval := e.pollIntervalOr(defaultFindDealPollInterval)
+	failures := 0
 
 	for {
+		deal, err := e.findDealOnce(addr, hash)
+		if deal != nil {
+			return deal, nil
+		}
+
+		if err != nil {
+			failures++
+			log.G(ctx).Warn("cannot look for opened deals", zap.Int("failures", failures), zap.Error(err))
+		} else {
+			failures = 0
+		}
+
+		timer := time.NewTimer(findDealRetryDelay(interval, failures))
 		select {
-		case <-tk.C:
-			if deal := e.findDealOnce(addr, hash); deal != nil {
-				return deal, nil
-			}
+		case <-timer.C:
 		case <-ctx.Done():
+			timer.Stop()
 			return nil, ctx.Err()
 		}
 	}
 }
 
-func (e *eth) findDealOnce(addr, hash string) *pb.Deal {
+// findDealRetryDelay returns the delay before the next search for a deal
+// after the given number of consecutive failed ones. The poll interval is
+// doubled on each failure up to maxFindDealBackoff, and up to a half of it
+// is randomized, so that hubs do not retry all at once.
+func findDealRetryDelay(interval time.Duration, failures int) time.Duration {
+	if failures == 0 {
+		return interval
+	}
+
+	limit := maxFindDealBackoff
+	if interval > limit {
+		limit = interval
+	}
+
+	delay := interval
+	for i := 0; i < failures && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+
+	half := delay / 2
+	return delay - half + time.Duration(findDealJitter(int64(half)+1))
+}
+
+// findDealOnce returns nil without an error when the deal is not opened
+// yet, and an error if the blockchain cannot be queried.
+func (e *eth) findDealOnce(addr, hash string) (*pb.Deal, error) {
 	// get deals opened by our client
 	IDs, err := e.bc.GetOpenedDeal(util.PubKeyToAddr(e.key.PublicKey).Hex(), addr)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
 	log.G(e.ctx).Info("found some opened deals",
@@ -112,21 +248,24 @@ func (e *eth) findDealOnce(addr, hash string) *pb.Deal {
 		zap.String("hash", hash),
 		zap.Int("count", len(IDs)))
 
+	var lastErr error
 	for _, id := range IDs {
 		// then get extended info
 		deal, err := e.bc.GetDealInfo(id)
 		if err != nil {
+			lastErr = err
 			continue
 		}
 
 		// then check for status
 		// and check if task hash is equal with request's one
 		if deal.GetStatus() == pb.DealStatus_PENDING && deal.GetSpecificationHash() == hash {
-			return deal
+			return deal, nil
 		}
 	}
 
-	return nil
+	// The deal may be among the ones we have failed to get.
+	return nil, lastErr
 }
 
 func (e *eth) AcceptDeal(id string) error {
@@ -145,25 +284,79 @@ func (e *eth) GetDeal(id string) (*pb.Deal, error) {
 		return nil, err
 	}
 
+	// Blockchain failures are not ErrDealNotFound, so they are not mistaken
+	// for a missing deal.
 	deal, err := e.bc.GetDealInfo(bigID)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrapf(err, "cannot get info of deal %s", id)
 	}
 
 	// NOTE: May GetSupplierID return common.Address?
-	idOK := deal.GetSupplierID() == util.PubKeyToAddr(e.key.PublicKey).Hex()
-	statusOK := deal.GetStatus() == pb.DealStatus_ACCEPTED
-	dealOK := idOK && statusOK
+	if !e.isOwnAddr(deal.GetSupplierID()) {
+		return nil, ErrDealNotOwned
+	}
 
-	if dealOK {
-		return deal, nil
-	} else {
-		return nil, errDealNotFound
+	if deal.GetStatus() != pb.DealStatus_ACCEPTED {
+		return nil, ErrDealNotAccepted
 	}
+
+	return deal, nil
+}
+
+func (e *eth) GetMyDeals(status pb.DealStatus) ([]*pb.Deal, error) {
+	return blockchain.GetMyDeals(e.bc, status, util.PubKeyToAddr(e.key.PublicKey).Hex())
+}
+
+func (e *eth) FindDealBySpecHash(addr, hash string) (*pb.Deal, error) {
+	deal, err := blockchain.FindDealBySpecHash(e.bc, e.addrs(), addr, hash)
+	if err != nil {
+		return nil, err
+	}
+	if deal == nil {
+		return nil, ErrDealNotFound
+	}
+
+	return deal, nil
+}
+
+func (e *eth) pollIntervalOr(defaultInterval time.Duration) time.Duration {
+	if e.pollInterval > 0 {
+		return e.pollInterval
+	}
+
+	return defaultInterval
+}
+
+// addrs returns addresses of all known keys, the primary one goes first.
+func (e *eth) addrs() []string {
+	addrs := []string{util.PubKeyToAddr(e.key.PublicKey).Hex()}
+	for _, key := range e.previousKeys {
+		addrs = append(addrs, util.PubKeyToAddr(key.PublicKey).Hex())
+	}
+
+	return addrs
+}
+
+func (e *eth) isOwnAddr(addr string) bool {
+	for _, own := range e.addrs() {
+		if own == addr {
+			return true
+		}
+	}
+
+	return false
 }
 
 // NewETH constructs a new Ethereum client.
-func NewETH(ctx context.Context, key *ecdsa.PrivateKey, bcr blockchain.Blockchainer, timeout time.Duration) (ETH, error) {
+//
+// The blockchain is polled for deals every pollInterval, zero keeps the
+// defaults of 3s when waiting for a deal to be created and 5s when waiting
+// for it to be closed.
+//
+// Deals owned by any of previousKeys are still recognized as our own, which
+// allows to rotate keys without downtime. New transactions are always
+// signed with the primary key.
+func NewETH(ctx context.Context, key *ecdsa.PrivateKey, bcr blockchain.Blockchainer, timeout, pollInterval time.Duration, previousKeys ...*ecdsa.PrivateKey) (ETH, error) {
 	var err error
 	if bcr == nil {
 		bcr, err = blockchain.NewAPI(nil, nil)
@@ -173,9 +366,11 @@ func NewETH(ctx context.Context, key *ecdsa.PrivateKey, bcr blockchain.Blockchai
 	}
 
 	return &eth{
-		ctx:     ctx,
-		key:     key,
-		bc:      bcr,
-		timeout: timeout,
+		ctx:          ctx,
+		key:          key,
+		previousKeys: previousKeys,
+		bc:           bcr,
+		timeout:      timeout,
+		pollInterval: pollInterval,
 	}, nil
 }
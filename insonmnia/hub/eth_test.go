@@ -2,18 +2,23 @@ package hub
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/sonm-io/core/blockchain"
 	"github.com/sonm-io/core/insonmnia/structs"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func makeTestKey() (string, *ecdsa.PrivateKey) {
@@ -94,7 +99,7 @@ func TestEth_WaitForDealCreated(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	found, err := eeth.WaitForDealCreated(req)
+	found, err := eeth.WaitForDealCreated(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "bbb", found.SpecificationHash)
@@ -135,8 +140,350 @@ func TestEth_CheckDealExists2(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	found, err := eeth.WaitForDealCreated(req)
+	found, err := eeth.WaitForDealCreated(context.Background(), req)
 	assert.Nil(t, found)
 	assert.Error(t, err)
 	assert.EqualError(t, err, "context deadline exceeded")
 }
+
+func TestEth_WaitForDealCreatedCanceled(t *testing.T) {
+	addr, key := makeTestKey()
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	bC.EXPECT().GetOpenedDeal(addr, "client-addr").AnyTimes().Return(nil, nil)
+
+	eeth := &eth{
+		ctx:     context.Background(),
+		key:     key,
+		bc:      bC,
+		timeout: defaultDealWaitTimeout,
+	}
+
+	req, err := structs.NewDealRequest(&pb.DealRequest{
+		AskId:    addr,
+		BidId:    "client-addr",
+		SpecHash: "aaa",
+		Order:    &pb.Order{Slot: &pb.Slot{}, ByuerID: "client-addr"},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	found, err := eeth.WaitForDealCreated(ctx, req)
+	assert.Nil(t, found)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestEth_WaitForDealCreatedRetriesErrors(t *testing.T) {
+	addr, key := makeTestKey()
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	gomock.InOrder(
+		bC.EXPECT().GetOpenedDeal(addr, "client-addr").Times(3).Return(nil, errors.New("connection refused")),
+		bC.EXPECT().GetOpenedDeal(addr, "client-addr").Return([]*big.Int{big.NewInt(100)}, nil),
+	)
+	bC.EXPECT().GetDealInfo(big.NewInt(100)).Return(&pb.Deal{
+		SupplierID:        addr,
+		BuyerID:           "client-addr",
+		Status:            pb.DealStatus_PENDING,
+		SpecificationHash: "aaa",
+	}, nil)
+
+	eeth := &eth{
+		ctx:          context.Background(),
+		key:          key,
+		bc:           bC,
+		timeout:      time.Second,
+		pollInterval: time.Millisecond,
+	}
+
+	req, err := structs.NewDealRequest(&pb.DealRequest{
+		AskId:    addr,
+		BidId:    "client-addr",
+		SpecHash: "aaa",
+		Order:    &pb.Order{Slot: &pb.Slot{}, ByuerID: "client-addr"},
+	})
+	require.NoError(t, err)
+
+	found, err := eeth.WaitForDealCreated(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "aaa", found.SpecificationHash)
+}
+
+func TestFindDealRetryDelay(t *testing.T) {
+	defer func(jitter func(int64) int64) { findDealJitter = jitter }(findDealJitter)
+	// The largest possible jitter.
+	findDealJitter = func(n int64) int64 { return n - 1 }
+
+	assert.Equal(t, 3*time.Second, findDealRetryDelay(3*time.Second, 0))
+	assert.Equal(t, 6*time.Second, findDealRetryDelay(3*time.Second, 1))
+	assert.Equal(t, 12*time.Second, findDealRetryDelay(3*time.Second, 2))
+	assert.Equal(t, 24*time.Second, findDealRetryDelay(3*time.Second, 3))
+	assert.Equal(t, maxFindDealBackoff, findDealRetryDelay(3*time.Second, 4))
+	assert.Equal(t, maxFindDealBackoff, findDealRetryDelay(3*time.Second, 100))
+	// Longer intervals are not shortened by the limit.
+	assert.Equal(t, time.Minute, findDealRetryDelay(time.Minute, 5))
+
+	// The smallest possible jitter halves the delay.
+	findDealJitter = func(int64) int64 { return 0 }
+	assert.Equal(t, 3*time.Second, findDealRetryDelay(3*time.Second, 1))
+	assert.Equal(t, maxFindDealBackoff/2, findDealRetryDelay(3*time.Second, 100))
+}
+
+func TestEth_GetMyDeals(t *testing.T) {
+	addr, key := makeTestKey()
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	// as a supplier
+	bC.EXPECT().GetAcceptedDeal(addr, "").Times(1).Return([]*big.Int{big.NewInt(1), big.NewInt(2)}, nil)
+	// as a buyer
+	bC.EXPECT().GetAcceptedDeal("", addr).Times(1).Return([]*big.Int{big.NewInt(2), big.NewInt(3)}, nil)
+
+	bC.EXPECT().GetDealInfo(big.NewInt(1)).Times(1).Return(&pb.Deal{Id: "1", SupplierID: addr}, nil)
+	bC.EXPECT().GetDealInfo(big.NewInt(2)).Times(1).Return(&pb.Deal{Id: "2", SupplierID: addr, BuyerID: addr}, nil)
+	bC.EXPECT().GetDealInfo(big.NewInt(3)).Times(1).Return(&pb.Deal{Id: "3", BuyerID: addr}, nil)
+
+	eeth := &eth{
+		ctx: context.Background(),
+		key: key,
+		bc:  bC,
+	}
+
+	deals, err := eeth.GetMyDeals(pb.DealStatus_ACCEPTED)
+	assert.NoError(t, err)
+	assert.Len(t, deals, 3)
+	assert.Equal(t, "1", deals[0].GetId())
+	assert.Equal(t, "2", deals[1].GetId())
+	assert.Equal(t, "3", deals[2].GetId())
+}
+
+func TestEth_GetDealOwnedByRotatedKey(t *testing.T) {
+	_, key := makeTestKey()
+	oldAddr, oldKey := makeTestKey()
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	bC.EXPECT().GetDealInfo(big.NewInt(1)).AnyTimes().Return(&pb.Deal{SupplierID: oldAddr, Status: pb.DealStatus_ACCEPTED}, nil)
+
+	eeth, err := NewETH(context.Background(), key, bC, time.Second, 0, oldKey)
+	assert.NoError(t, err)
+
+	deal, err := eeth.GetDeal("1")
+	assert.NoError(t, err)
+	assert.NotNil(t, deal)
+
+	// Without the rotated key the deal is not ours anymore.
+	eeth, err = NewETH(context.Background(), key, bC, time.Second, 0)
+	assert.NoError(t, err)
+
+	deal, err = eeth.GetDeal("1")
+	assert.Error(t, err)
+	assert.Nil(t, deal)
+}
+
+func TestEth_WaitForDealClosedRotatedKey(t *testing.T) {
+	addr, key := makeTestKey()
+	oldAddr, oldKey := makeTestKey()
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	bC.EXPECT().GetClosedDeal(addr, "client-addr").AnyTimes().Return(nil, nil)
+	bC.EXPECT().GetClosedDeal(oldAddr, "client-addr").AnyTimes().Return([]*big.Int{big.NewInt(1)}, nil)
+	bC.EXPECT().GetDealInfo(big.NewInt(1)).AnyTimes().Return(&pb.Deal{Id: "1", SupplierID: oldAddr, Status: pb.DealStatus_CLOSED}, nil)
+	bC.EXPECT().SubscribeDealClosed(gomock.Any(), "", "client-addr").Return(nil, blockchain.ErrSubscriptionsUnsupported)
+
+	eeth := &eth{
+		ctx:          context.Background(),
+		key:          key,
+		previousKeys: []*ecdsa.PrivateKey{oldKey},
+		bc:           bC,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	assert.NoError(t, eeth.WaitForDealClosed(ctx, DealID("1"), "client-addr"))
+}
+
+func TestEth_WaitForDealClosedPollInterval(t *testing.T) {
+	addr, key := makeTestKey()
+
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	bC.EXPECT().SubscribeDealClosed(gomock.Any(), "", "client-addr").Return(nil, blockchain.ErrSubscriptionsUnsupported)
+	gomock.InOrder(
+		bC.EXPECT().GetClosedDeal(addr, "client-addr").Times(2).Return(nil, nil),
+		bC.EXPECT().GetClosedDeal(addr, "client-addr").Return([]*big.Int{big.NewInt(1)}, nil),
+	)
+	bC.EXPECT().GetDealInfo(big.NewInt(1)).Return(&pb.Deal{Id: "1", SupplierID: addr, Status: pb.DealStatus_CLOSED}, nil)
+
+	eeth, err := NewETH(context.Background(), key, bC, time.Second, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	// Way less than the default interval of three polls.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, eeth.WaitForDealClosed(ctx, DealID("1"), "client-addr"))
+}
+
+func TestEth_WaitForDealClosedSubscription(t *testing.T) {
+	addr, key := makeTestKey()
+	closed := make(chan *big.Int)
+
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	bC.EXPECT().SubscribeDealClosed(gomock.Any(), "", "client-addr").Return((<-chan *big.Int)(closed), nil)
+	// Checked once right after subscribing, and never polled again.
+	bC.EXPECT().GetClosedDeal(addr, "client-addr").Times(1).Return(nil, nil)
+
+	eeth := &eth{
+		ctx: context.Background(),
+		key: key,
+		bc:  bC,
+	}
+
+	go func() {
+		closed <- big.NewInt(2)
+		closed <- big.NewInt(1)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, eeth.WaitForDealClosed(ctx, DealID("1"), "client-addr"))
+}
+
+func TestEth_WaitForDealClosedBeforeSubscription(t *testing.T) {
+	addr, key := makeTestKey()
+
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	bC.EXPECT().SubscribeDealClosed(gomock.Any(), "", "client-addr").Return(make(<-chan *big.Int), nil)
+	bC.EXPECT().GetClosedDeal(addr, "client-addr").Return([]*big.Int{big.NewInt(1)}, nil)
+	bC.EXPECT().GetDealInfo(big.NewInt(1)).Return(&pb.Deal{Id: "1", SupplierID: addr, Status: pb.DealStatus_CLOSED}, nil)
+
+	eeth := &eth{
+		ctx: context.Background(),
+		key: key,
+		bc:  bC,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, eeth.WaitForDealClosed(ctx, DealID("1"), "client-addr"))
+}
+
+func TestEth_FindDealBySpecHash(t *testing.T) {
+	addr, key := makeTestKey()
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	bC.EXPECT().GetOpenedDeal(addr, "client-addr").AnyTimes().Return([]*big.Int{big.NewInt(100)}, nil)
+	bC.EXPECT().GetOpenedDeal("client-addr", addr).AnyTimes().Return(nil, nil)
+	bC.EXPECT().GetAcceptedDeal(addr, "client-addr").AnyTimes().Return(nil, nil)
+	bC.EXPECT().GetAcceptedDeal("client-addr", addr).AnyTimes().Return([]*big.Int{big.NewInt(200)}, nil)
+
+	bC.EXPECT().GetDealInfo(big.NewInt(100)).AnyTimes().Return(&pb.Deal{
+		Id:                "100",
+		SupplierID:        addr,
+		BuyerID:           "client-addr",
+		Status:            pb.DealStatus_PENDING,
+		SpecificationHash: "aaa",
+	}, nil)
+	bC.EXPECT().GetDealInfo(big.NewInt(200)).AnyTimes().Return(&pb.Deal{
+		Id:                "200",
+		SupplierID:        "client-addr",
+		BuyerID:           addr,
+		Status:            pb.DealStatus_ACCEPTED,
+		SpecificationHash: "bbb",
+	}, nil)
+
+	eeth := &eth{
+		ctx: context.Background(),
+		key: key,
+		bc:  bC,
+	}
+
+	opened, err := eeth.FindDealBySpecHash("client-addr", "aaa")
+	require.NoError(t, err)
+	assert.Equal(t, "100", opened.GetId())
+
+	accepted, err := eeth.FindDealBySpecHash("client-addr", "bbb")
+	require.NoError(t, err)
+	assert.Equal(t, "200", accepted.GetId())
+
+	_, err = eeth.FindDealBySpecHash("client-addr", "ccc")
+	assert.Equal(t, ErrDealNotFound, err)
+}
+
+func TestEth_FindDealBySpecHashSkipsStaleStatus(t *testing.T) {
+	addr, key := makeTestKey()
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	bC.EXPECT().GetOpenedDeal(gomock.Any(), gomock.Any()).AnyTimes().Return([]*big.Int{big.NewInt(100)}, nil)
+	bC.EXPECT().GetAcceptedDeal(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+	// The deal has been closed since the id list was fetched.
+	bC.EXPECT().GetDealInfo(big.NewInt(100)).AnyTimes().Return(&pb.Deal{
+		SupplierID:        addr,
+		Status:            pb.DealStatus_CLOSED,
+		SpecificationHash: "aaa",
+	}, nil)
+
+	eeth := &eth{
+		ctx: context.Background(),
+		key: key,
+		bc:  bC,
+	}
+
+	_, err := eeth.FindDealBySpecHash("", "aaa")
+	assert.Equal(t, ErrDealNotFound, err)
+}
+
+func TestEth_GetDealUnusable(t *testing.T) {
+	addr, key := makeTestKey()
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	// Ours, but not accepted yet.
+	bC.EXPECT().GetDealInfo(big.NewInt(1)).AnyTimes().Return(&pb.Deal{SupplierID: addr, Status: pb.DealStatus_PENDING}, nil)
+	// Accepted, but supplied by someone else.
+	bC.EXPECT().GetDealInfo(big.NewInt(2)).AnyTimes().Return(&pb.Deal{SupplierID: "anotherEthAddress", Status: pb.DealStatus_ACCEPTED}, nil)
+	// Neither ours nor accepted, ownership is checked first.
+	bC.EXPECT().GetDealInfo(big.NewInt(3)).AnyTimes().Return(&pb.Deal{SupplierID: "anotherEthAddress", Status: pb.DealStatus_CLOSED}, nil)
+
+	eeth := &eth{
+		ctx: context.Background(),
+		key: key,
+		bc:  bC,
+	}
+
+	_, err := eeth.GetDeal("1")
+	assert.Equal(t, ErrDealNotAccepted, err)
+	assert.True(t, isDealUnusable(err))
+
+	_, err = eeth.GetDeal("2")
+	assert.Equal(t, ErrDealNotOwned, err)
+	assert.True(t, isDealUnusable(err))
+
+	_, err = eeth.GetDeal("3")
+	assert.Equal(t, ErrDealNotOwned, err)
+}
+
+func TestEth_GetDealBlockchainFailure(t *testing.T) {
+	_, key := makeTestKey()
+	rpcErr := errors.New("connection refused")
+	bC := blockchain.NewMockBlockchainer(gomock.NewController(t))
+	bC.EXPECT().GetDealInfo(big.NewInt(1)).Return(nil, rpcErr)
+
+	eeth := &eth{
+		ctx: context.Background(),
+		key: key,
+		bc:  bC,
+	}
+
+	_, err := eeth.GetDeal("1")
+	require.Error(t, err)
+	assert.Equal(t, rpcErr, errors.Cause(err))
+	assert.False(t, isDealUnusable(err))
+	assert.Equal(t, "cannot get info of deal 1: connection refused", err.Error())
+	// Not reported to clients as a missing deal.
+	assert.Equal(t, err, dealStatusError(err))
+}
+
+func TestDealStatusError(t *testing.T) {
+	st, ok := status.FromError(dealStatusError(ErrDealNotAccepted))
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "deal is not accepted", st.Message())
+
+	other := fmt.Errorf("connection refused")
+	assert.Equal(t, other, dealStatusError(other))
+}
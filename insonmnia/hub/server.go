@@ -42,12 +42,23 @@ var (
 	ErrAskNotFound      = status.Errorf(codes.NotFound, "ask not found")
 	ErrDeviceNotFound   = status.Errorf(codes.NotFound, "device not found")
 	ErrMinerNotFound    = status.Errorf(codes.NotFound, "miner not found")
-	errDealNotFound     = status.Errorf(codes.NotFound, "deal not found")
+	ErrDealNotFound     = status.Errorf(codes.NotFound, "deal not found")
 	errTaskNotFound     = status.Errorf(codes.NotFound, "task not found")
 )
 
 type DealID string
 
+// dealStatusError reports unusable deals to clients as NotFound, as before
+// the reasons were distinguished, but with the reason as the message.
+// gRPC cannot derive a status from errors of other types by itself.
+func dealStatusError(err error) error {
+	if isDealUnusable(err) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return err
+}
+
 // Hub collects miners, send them orders to spawn containers, etc.
 type Hub struct {
 	// TODO (3Hren): Probably port pool should be associated with the gateway implicitly.
@@ -130,11 +141,9 @@ func (h *Hub) Status(ctx context.Context, _ *pb.Empty) (*pb.HubStatusReply, erro
 	minersCount := len(h.miners)
 	h.minersMu.Unlock()
 
-	uptime := time.Now().Unix() - h.startTime.Unix()
-
 	reply := &pb.HubStatusReply{
 		MinerCount: uint64(minersCount),
-		Uptime:     uint64(uptime),
+		Uptime:     uint64(time.Since(h.startTime)),
 		Platform:   util.GetPlatformName(),
 		Version:    h.version,
 		EthAddr:    util.PubKeyToAddr(h.ethKey.PublicKey).Hex(),
@@ -405,7 +414,7 @@ func (h *Hub) getTaskHistory(dealID, taskID string) (*TaskInfo, error) {
 
 	tasks, ok := h.deals[DealID(dealID)]
 	if !ok {
-		return nil, errDealNotFound
+		return nil, ErrDealNotFound
 	}
 
 	for _, task := range tasks.Tasks {
@@ -435,7 +444,7 @@ func (h *Hub) generateTaskID() string {
 func (h *Hub) startTask(ctx context.Context, request *structs.StartTaskRequest) (*pb.HubStartTaskReply, error) {
 	deal, err := h.eth.GetDeal(request.GetDeal().Id)
 	if err != nil {
-		return nil, err
+		return nil, dealStatusError(err)
 	}
 
 	dealID := DealID(deal.GetId())
@@ -446,7 +455,7 @@ func (h *Hub) startTask(ctx context.Context, request *structs.StartTaskRequest)
 
 	if !ok {
 		// Hub knows nothing about this deal
-		return nil, errDealNotFound
+		return nil, ErrDealNotFound
 	}
 
 	// Extract proper miner associated with the deal specified.
@@ -601,7 +610,7 @@ func (h *Hub) GetDealInfo(ctx context.Context, dealID *pb.ID) (*pb.DealInfoReply
 func (h *Hub) getDealInfo(dealID DealID) (*dealInfo, error) {
 	meta, ok := h.deals[dealID]
 	if !ok {
-		return nil, errDealNotFound
+		return nil, ErrDealNotFound
 	}
 
 	h.tasksMu.Lock()
@@ -767,14 +776,15 @@ func (h *Hub) ProposeDeal(ctx context.Context, r *pb.DealRequest) (*pb.Empty, er
 		return nil, err
 	}
 
-	h.waiter.Go(h.getDealWaiter(ctx, request, order))
+	// The waiter outlives the request, so it is bound to the hub instead.
+	h.waiter.Go(h.getDealWaiter(h.ctx, request, order))
 
 	return &pb.Empty{}, nil
 }
 
 func (h *Hub) getDealWaiter(ctx context.Context, req *structs.DealRequest, order *structs.Order) func() error {
 	return func() error {
-		createdDeal, err := h.eth.WaitForDealCreated(req)
+		createdDeal, err := h.eth.WaitForDealCreated(ctx, req)
 		if err != nil || createdDeal == nil {
 			log.G(h.ctx).Warn(
 				"cannot find created deal for current proposal",
@@ -1038,14 +1048,26 @@ func (h *Hub) RemoveSlot(ctx context.Context, request *pb.ID) (*pb.Empty, error)
 func (h *Hub) GetRegisteredWorkers(ctx context.Context, empty *pb.Empty) (*pb.GetRegisteredWorkersReply, error) {
 	log.G(h.ctx).Info("handling GetRegisteredWorkers request")
 
-	var ids []*pb.ID
-
+	var creds []string
 	h.acl.Each(func(cred string) bool {
-		ids = append(ids, &pb.ID{Id: cred})
+		creds = append(creds, cred)
 		return true
 	})
 
-	return &pb.GetRegisteredWorkersReply{Ids: ids}, nil
+	ids := make([]*pb.ID, 0, len(creds))
+	workers := make([]*pb.RegisteredWorker, 0, len(creds))
+	for _, cred := range creds {
+		id := &pb.ID{Id: cred}
+		worker := &pb.RegisteredWorker{Id: id}
+		if ts, ok := h.acl.RegisteredAt(cred); ok {
+			worker.RegisteredAt = &pb.Timestamp{Seconds: ts.Unix(), Nanos: int32(ts.Nanosecond())}
+		}
+
+		ids = append(ids, id)
+		workers = append(workers, worker)
+	}
+
+	return &pb.GetRegisteredWorkersReply{Ids: ids, Workers: workers}, nil
 }
 
 // RegisterWorker allows Worker with given ID to connect to the Hub
@@ -1131,7 +1153,7 @@ func New(ctx context.Context, cfg *Config, version string, opts ...Option) (*Hub
 		}
 	}
 
-	ethWrapper, err := NewETH(ctx, defaults.ethKey, defaults.bcr, defaultDealWaitTimeout)
+	ethWrapper, err := NewETH(ctx, defaults.ethKey, defaults.bcr, defaultDealWaitTimeout, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -1403,7 +1425,7 @@ func (h *Hub) saveTask(dealID DealID, info *TaskInfo) error {
 
 	taskIDs, ok := h.deals[dealID]
 	if !ok {
-		return errDealNotFound
+		return ErrDealNotFound
 	}
 
 	taskIDs.Tasks = append(taskIDs.Tasks, info)
@@ -1453,7 +1475,7 @@ func (h *Hub) popDealHistory(dealID DealID) ([]*TaskInfo, error) {
 	tasks, ok := h.deals[dealID]
 	if !ok {
 		h.tasksMu.Unlock()
-		return nil, errDealNotFound
+		return nil, ErrDealNotFound
 	}
 	delete(h.deals, dealID)
 
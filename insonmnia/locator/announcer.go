@@ -0,0 +1,199 @@
+package locator
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+)
+
+var errAnnouncerStarted = errors.New("announcer is already started")
+
+// announceTarget is a single locator the announcer keeps announcing to.
+type announceTarget struct {
+	endpoint string
+	client   pb.LocatorClient
+	conn     io.Closer
+}
+
+// dialFunc connects to the locator at the given endpoint.
+type dialFunc func(ctx context.Context, endpoint string) (pb.LocatorClient, io.Closer, error)
+
+// MultiAnnouncer periodically announces the same addresses to several
+// locators, giving redundancy without federating the locators themselves.
+//
+// Unreachable locators do not prevent announcing to the others, they are
+// retried on the next tick.
+type MultiAnnouncer struct {
+	key     *ecdsa.PrivateKey
+	period  time.Duration
+	req     *pb.AnnounceRequest
+	targets []*announceTarget
+
+	mu          sync.Mutex
+	dial        dialFunc
+	certRotator util.HitlessCertRotator
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewMultiAnnouncer constructs an announcer of the given request to the
+// given locator endpoints, authenticating with the given key. Endpoints
+// may contain the locator's ETH address as "<addr>@<host>:<port>".
+func NewMultiAnnouncer(key *ecdsa.PrivateKey, endpoints []string, period time.Duration, req *pb.AnnounceRequest) *MultiAnnouncer {
+	targets := make([]*announceTarget, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		targets = append(targets, &announceTarget{endpoint: endpoint})
+	}
+
+	return &MultiAnnouncer{
+		key:     key,
+		period:  period,
+		req:     req,
+		targets: targets,
+	}
+}
+
+// Start announces to all locators immediately and then every period in
+// background until Stop is called or the context is canceled.
+func (m *MultiAnnouncer) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		return errAnnouncerStarted
+	}
+
+	if m.dial == nil {
+		certRotator, TLSConfig, err := util.NewHitlessCertRotator(ctx, m.key)
+		if err != nil {
+			return err
+		}
+
+		m.certRotator = certRotator
+		m.dial = newWalletAuthenticatedDial(util.NewTLS(TLSConfig))
+	}
+
+	ctx, m.cancel = context.WithCancel(ctx)
+	m.done = make(chan struct{})
+
+	go m.run(ctx, m.done)
+
+	return nil
+}
+
+// Stop stops announcing and closes all connections.
+func (m *MultiAnnouncer) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel == nil {
+		return
+	}
+
+	m.cancel()
+	<-m.done
+	m.cancel = nil
+
+	for _, target := range m.targets {
+		if target.conn != nil {
+			target.conn.Close()
+		}
+		target.client = nil
+		target.conn = nil
+	}
+
+	// The dial function is bound to the rotator, so both are made anew on
+	// the next start.
+	if m.certRotator != nil {
+		m.certRotator.Close()
+		m.certRotator = nil
+		m.dial = nil
+	}
+}
+
+func (m *MultiAnnouncer) run(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+
+	tk := time.NewTicker(m.period)
+	defer tk.Stop()
+
+	m.announceOnce(ctx)
+
+	for {
+		select {
+		case <-tk.C:
+			m.announceOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// announceOnce announces to all locators concurrently, each bounded by the
+// period, and returns the number of successful announces.
+func (m *MultiAnnouncer) announceOnce(ctx context.Context) int {
+	ctx, cancel := context.WithTimeout(ctx, m.period)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	results := make(chan bool, len(m.targets))
+	for _, target := range m.targets {
+		wg.Add(1)
+		go func(target *announceTarget) {
+			defer wg.Done()
+
+			err := m.announce(ctx, target)
+			if err != nil {
+				log.G(ctx).Warn("cannot announce addresses to Locator",
+					zap.String("endpoint", target.endpoint), zap.Error(err))
+			}
+			results <- err == nil
+		}(target)
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for ok := range results {
+		if ok {
+			succeeded++
+		}
+	}
+
+	return succeeded
+}
+
+func (m *MultiAnnouncer) announce(ctx context.Context, target *announceTarget) error {
+	if target.client == nil {
+		client, conn, err := m.dial(ctx, target.endpoint)
+		if err != nil {
+			return err
+		}
+
+		target.client = client
+		target.conn = conn
+	}
+
+	_, err := target.client.Announce(ctx, m.req)
+	return err
+}
+
+func newWalletAuthenticatedDial(creds credentials.TransportCredentials) dialFunc {
+	return func(ctx context.Context, endpoint string) (pb.LocatorClient, io.Closer, error) {
+		conn, err := util.MakeWalletAuthenticatedClient(ctx, creds, endpoint)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return pb.NewLocatorClient(conn), conn, nil
+	}
+}
@@ -0,0 +1,141 @@
+package locator
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type fakeAnnounceLocator struct {
+	pb.LocatorClient
+
+	mu        sync.Mutex
+	announces []*pb.AnnounceRequest
+	// fail tells whether the announce with the given number fails.
+	fail     func(n int) bool
+	calls    int
+	notify   chan struct{}
+	closed   bool
+	dialFail int
+}
+
+func (f *fakeAnnounceLocator) Announce(ctx context.Context, in *pb.AnnounceRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.notify != nil {
+		select {
+		case f.notify <- struct{}{}:
+		default:
+		}
+	}
+
+	if f.fail != nil && f.fail(f.calls) {
+		return nil, errors.New("locator is unavailable")
+	}
+
+	f.announces = append(f.announces, in)
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeAnnounceLocator) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	return nil
+}
+
+func (f *fakeAnnounceLocator) succeeded() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.announces)
+}
+
+// fakeDial connects to fake locators by endpoint, failing the first
+// dialFail connections of each.
+func fakeDial(locators map[string]*fakeAnnounceLocator) dialFunc {
+	return func(ctx context.Context, endpoint string) (pb.LocatorClient, io.Closer, error) {
+		locator := locators[endpoint]
+
+		locator.mu.Lock()
+		defer locator.mu.Unlock()
+
+		if locator.dialFail > 0 {
+			locator.dialFail--
+			return nil, nil, errors.New("connection refused")
+		}
+
+		return locator, locator, nil
+	}
+}
+
+func TestMultiAnnouncer_PartialFailures(t *testing.T) {
+	stable := &fakeAnnounceLocator{}
+	// Fails every other announce and cannot be dialed at first.
+	flaky := &fakeAnnounceLocator{fail: func(n int) bool { return n%2 == 1 }, dialFail: 1}
+
+	req := &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}}
+	announcer := NewMultiAnnouncer(key, []string{"stable:9090", "flaky:9090"}, time.Second, req)
+	announcer.dial = fakeDial(map[string]*fakeAnnounceLocator{"stable:9090": stable, "flaky:9090": flaky})
+
+	ctx := context.Background()
+
+	// The flaky locator is unreachable.
+	assert.Equal(t, 1, announcer.announceOnce(ctx))
+	assert.Equal(t, 1, stable.succeeded())
+	assert.Equal(t, 0, flaky.succeeded())
+
+	// Reconnected, but the announce itself fails.
+	assert.Equal(t, 1, announcer.announceOnce(ctx))
+	assert.Equal(t, 0, flaky.succeeded())
+
+	// Retried on the next tick.
+	assert.Equal(t, 2, announcer.announceOnce(ctx))
+	assert.Equal(t, 3, stable.succeeded())
+	require.Equal(t, 1, flaky.succeeded())
+	assert.Equal(t, req, flaky.announces[0])
+}
+
+func TestMultiAnnouncer_StartStop(t *testing.T) {
+	first := &fakeAnnounceLocator{notify: make(chan struct{}, 1)}
+	second := &fakeAnnounceLocator{notify: make(chan struct{}, 1), fail: func(int) bool { return true }}
+
+	announcer := NewMultiAnnouncer(key, []string{"first:9090", "second:9090"}, 10*time.Millisecond, &pb.AnnounceRequest{})
+	announcer.dial = fakeDial(map[string]*fakeAnnounceLocator{"first:9090": first, "second:9090": second})
+
+	require.NoError(t, announcer.Start(context.Background()))
+	assert.Error(t, announcer.Start(context.Background()))
+
+	// Wait for a couple of ticks on both locators.
+	for i := 0; i < 2; i++ {
+		for _, locator := range []*fakeAnnounceLocator{first, second} {
+			select {
+			case <-locator.notify:
+			case <-time.After(5 * time.Second):
+				t.Fatal("no announce in time")
+			}
+		}
+	}
+
+	announcer.Stop()
+	assert.True(t, first.closed)
+	assert.True(t, second.closed)
+	assert.True(t, first.succeeded() >= 2)
+	assert.Equal(t, 0, second.succeeded())
+
+	// No announces after stopping.
+	calls := first.succeeded()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, calls, first.succeeded())
+}
@@ -0,0 +1,77 @@
+package locator
+
+import (
+	"net"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+)
+
+// AuditConfig configures the audit trail of announces and resolves, which
+// is written apart from the main log, so it can be routed to a dedicated
+// sink.
+type AuditConfig struct {
+	// Output is a path to write audit entries to, "stdout" and "stderr"
+	// are recognized too. Empty disables the audit log.
+	Output string `yaml:"output"`
+	// Level is the minimum level of audit entries written. Successful
+	// requests are logged with the info level, failed ones with the warn
+	// level.
+	Level int `yaml:"level"`
+}
+
+func newAuditLogger(conf AuditConfig) (*zap.Logger, error) {
+	if conf.Output == "" {
+		return zap.NewNop(), nil
+	}
+
+	loggerConfig := zap.Config{
+		Level:            zap.NewAtomicLevelAt(zapcore.Level(conf.Level)),
+		OutputPaths:      []string{conf.Output},
+		ErrorOutputPaths: []string{"stderr"},
+		Encoding:         "json",
+		EncoderConfig:    zap.NewProductionEncoderConfig(),
+	}
+
+	return loggerConfig.Build()
+}
+
+// audit writes an audit entry for the request handled with the given
+// context, which records who has made it, from where and how it ended.
+func (l *Locator) audit(ctx context.Context, method string, err error, fields ...zapcore.Field) {
+	var caller string
+	if ethAddr, err := l.extractEthAddr(ctx); err == nil {
+		caller = ethAddr.Hex()
+	}
+
+	fields = append(fields,
+		zap.String("method", method),
+		zap.String("caller", caller),
+		zap.String("client_ip", clientIP(ctx)),
+	)
+
+	if err != nil {
+		l.auditLog.Warn("request failed", append(fields, zap.String("outcome", "error"), zap.Error(err))...)
+		return
+	}
+
+	l.auditLog.Info("request succeeded", append(fields, zap.String("outcome", "ok"))...)
+}
+
+// clientIP returns the IP of the peer the request has come from, or an
+// empty string if it is unknown.
+func clientIP(ctx context.Context) string {
+	pr, ok := peer.FromContext(ctx)
+	if !ok || pr.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(pr.Addr.String())
+	if err != nil {
+		return pr.Addr.String()
+	}
+
+	return host
+}
@@ -0,0 +1,154 @@
+package locator
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+)
+
+func readAuditLog(t *testing.T, path string) []map[string]interface{} {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+
+	return entries
+}
+
+func TestLocator_Audit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locator")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := DefaultConfig(":9090")
+	conf.Audit.Output = filepath.Join(dir, "audit.log")
+
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	worker := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	client := common.HexToAddress("0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5")
+	ctxFrom := func(addr common.Address, ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr:     &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000},
+			AuthInfo: util.EthAuthInfo{Wallet: addr},
+		})
+	}
+
+	_, err = lc.Announce(ctxFrom(worker, "10.0.0.1"), &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1:10002"}})
+	require.NoError(t, err)
+	_, err = lc.Resolve(ctxFrom(client, "10.0.0.2"), &pb.ResolveRequest{EthAddr: worker.Hex()})
+	require.NoError(t, err)
+	_, err = lc.Resolve(ctxFrom(client, "10.0.0.2"), &pb.ResolveRequest{EthAddr: client.Hex()})
+	require.Error(t, err)
+	require.NoError(t, lc.auditLog.Sync())
+
+	entries := readAuditLog(t, conf.Audit.Output)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "Announce", entries[0]["method"])
+	assert.Equal(t, worker.Hex(), entries[0]["caller"])
+	assert.Equal(t, "10.0.0.1", entries[0]["client_ip"])
+	assert.Equal(t, []interface{}{"10.0.0.1:10002"}, entries[0]["ips"])
+	assert.Equal(t, "ok", entries[0]["outcome"])
+
+	assert.Equal(t, "Resolve", entries[1]["method"])
+	assert.Equal(t, client.Hex(), entries[1]["caller"])
+	assert.Equal(t, "10.0.0.2", entries[1]["client_ip"])
+	assert.Equal(t, worker.Hex(), entries[1]["target"])
+	assert.Equal(t, "ok", entries[1]["outcome"])
+
+	assert.Equal(t, "error", entries[2]["outcome"])
+	assert.Equal(t, "warn", entries[2]["level"])
+	assert.Equal(t, errNodeNotFound.Error(), entries[2]["error"])
+}
+
+func TestLocator_AuditLevel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locator")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := DefaultConfig(":9090")
+	conf.Audit.Output = filepath.Join(dir, "audit.log")
+	// Warn, so only failed requests are audited.
+	conf.Audit.Level = 1
+
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	worker := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	lc.putAnnounce(&node{ethAddr: worker})
+
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: worker.Hex()})
+	require.NoError(t, err)
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: "garbage"})
+	require.Error(t, err)
+	require.NoError(t, lc.auditLog.Sync())
+
+	entries := readAuditLog(t, conf.Audit.Output)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "garbage", entries[0]["target"])
+	// Requests without an authenticated peer are audited anonymously.
+	assert.Equal(t, "", entries[0]["caller"])
+	assert.Equal(t, "", entries[0]["client_ip"])
+}
+
+func TestLocator_AuditAnnounceBatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locator")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := DefaultConfig(":9090")
+	conf.Audit.Output = filepath.Join(dir, "audit.log")
+
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	worker, _ := crypto.GenerateKey()
+	announce, err := SignAnnounce(worker, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1"}})
+	require.NoError(t, err)
+
+	_, err = lc.AnnounceBatch(agentContext(), &pb.AnnounceBatchRequest{
+		Announces: []*pb.SignedAnnounce{announce, announce},
+	})
+	require.NoError(t, err)
+	require.NoError(t, lc.auditLog.Sync())
+
+	// Every entry is audited on its own, the caller being the agent.
+	entries := readAuditLog(t, conf.Audit.Output)
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, "AnnounceBatch", entry["method"])
+		assert.Equal(t, util.PubKeyToAddr(key.PublicKey).Hex(), entry["caller"])
+		assert.Equal(t, announce.EthAddr, entry["target"])
+	}
+	assert.Equal(t, "ok", entries[0]["outcome"])
+	assert.Equal(t, "error", entries[1]["outcome"])
+}
+
+func TestLocator_AuditDisabled(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+	assert.False(t, lc.auditLog.Core().Enabled(0))
+}
@@ -0,0 +1,193 @@
+package locator
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/pkg/errors"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// announceMaxClockSkew is how far in the future a signed announce timestamp
+// may be to tolerate clock differences between the agent and the locator.
+const announceMaxClockSkew = time.Minute
+
+var errAnnounceReplayed = errors.New("announce is not newer than the stored one")
+
+// SignAnnounce makes an announce of the given request on behalf of the
+// owner of the given key, suitable for sending within AnnounceBatch.
+func SignAnnounce(key *ecdsa.PrivateKey, req *pb.AnnounceRequest) (*pb.SignedAnnounce, error) {
+	announce := &pb.SignedAnnounce{
+		EthAddr:         util.PubKeyToAddr(key.PublicKey).Hex(),
+		IpAddr:          req.GetIpAddr(),
+		Timestamp:       time.Now().Unix(),
+		Tags:            req.GetTags(),
+		ProtocolVersion: req.GetProtocolVersion(),
+		TtlSeconds:      req.GetTtlSeconds(),
+	}
+
+	signature, err := crypto.Sign(announceHash(util.PubKeyToAddr(key.PublicKey), announce), key)
+	if err != nil {
+		return nil, err
+	}
+
+	announce.Signature = signature
+	return announce, nil
+}
+
+// announceHash returns the hash of the announce signed by the node, which
+// covers everything but the signature itself. Lists are separated by a
+// zero byte, so IPs cannot be passed off as tags and vice versa.
+func announceHash(ethAddr common.Address, announce *pb.SignedAnnounce) []byte {
+	var tsBytes, versionBytes, ttlBytes [8]byte
+	binary.BigEndian.PutUint64(tsBytes[:], uint64(announce.GetTimestamp()))
+	binary.BigEndian.PutUint64(versionBytes[:], uint64(announce.GetProtocolVersion()))
+	binary.BigEndian.PutUint64(ttlBytes[:], announce.GetTtlSeconds())
+
+	return crypto.Keccak256(
+		ethAddr.Bytes(),
+		tsBytes[:],
+		[]byte(strings.Join(announce.GetIpAddr(), ",")),
+		[]byte{0},
+		[]byte(strings.Join(announce.GetTags(), ",")),
+		versionBytes[:],
+		ttlBytes[:],
+	)
+}
+
+// verifyAnnounce checks that the announce is signed by the key of the
+// address it announces and that it is neither stale nor from the future.
+func (l *Locator) verifyAnnounce(req *pb.SignedAnnounce) (common.Address, error) {
+	ethAddr, err := parseEthAddr(req.GetEthAddr())
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	signedAt := time.Unix(req.GetTimestamp(), 0)
+	if time.Since(signedAt) > l.conf.NodeTTL {
+		return common.Address{}, errors.New("announce is expired")
+	}
+	if time.Until(signedAt) > announceMaxClockSkew {
+		return common.Address{}, errors.New("announce is signed in the future")
+	}
+
+	pub, err := crypto.SigToPub(announceHash(ethAddr, req), req.GetSignature())
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "malformed signature")
+	}
+
+	if signer := util.PubKeyToAddr(*pub); signer != ethAddr {
+		return common.Address{}, errors.Errorf("announce is signed by %s", signer.Hex())
+	}
+
+	return ethAddr, nil
+}
+
+// AnnounceBatch stores every correctly signed announce of the batch. A bad
+// entry does not fail the whole batch, instead its error is reported in the
+// corresponding result.
+func (l *Locator) AnnounceBatch(ctx context.Context, req *pb.AnnounceBatchRequest) (*pb.AnnounceBatchReply, error) {
+	if l.conf.ReadOnly {
+		return nil, errReadOnly
+	}
+
+	agentAddr, err := l.extractEthAddr(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.G(l.ctx).Info("handling AnnounceBatch request",
+		zap.Stringer("eth", agentAddr), zap.Int("size", len(req.GetAnnounces())))
+
+	results := make([]*pb.AnnounceResult, 0, len(req.GetAnnounces()))
+	for _, announce := range req.GetAnnounces() {
+		result := &pb.AnnounceResult{EthAddr: announce.GetEthAddr()}
+		if err := l.announceSigned(ctx, announce); err != nil {
+			result.Error = err.Error()
+		}
+
+		results = append(results, result)
+	}
+
+	return &pb.AnnounceBatchReply{Results: results}, nil
+}
+
+// announceSigned stores a single announce of a batch, applying the same
+// checks as Announce does to announces made by nodes themselves.
+func (l *Locator) announceSigned(ctx context.Context, announce *pb.SignedAnnounce) (err error) {
+	defer func() {
+		l.audit(ctx, "AnnounceBatch", err,
+			zap.String("target", announce.GetEthAddr()), zap.Strings("ips", announce.GetIpAddr()))
+	}()
+
+	ethAddr, err := l.verifyAnnounce(announce)
+	if err != nil {
+		log.G(l.ctx).Warn("rejecting announce", zap.String("eth", announce.GetEthAddr()), zap.Error(err))
+		return err
+	}
+
+	if !l.allowedToAnnounce(ethAddr) {
+		return errAnnounceDenied
+	}
+
+	if l.limiter != nil && !l.limiter.allow(ethAddr) {
+		return errAnnounceRateLimited
+	}
+
+	ipAddr, err := l.limitIPs(ethAddr, announce.GetIpAddr())
+	if err != nil {
+		return err
+	}
+
+	ttl, err := l.announceTTL(announce.GetTtlSeconds())
+	if err != nil {
+		return err
+	}
+
+	return l.putSignedAnnounce(&node{
+		ethAddr:         ethAddr,
+		ipAddr:          ipAddr,
+		tags:            newTagSet(announce.GetTags()),
+		protocolVersion: announce.GetProtocolVersion(),
+		ts:              time.Unix(announce.GetTimestamp(), 0),
+		ttl:             ttl,
+	})
+}
+
+// ResolveBatch resolves every requested node under a single db lock. Like
+// in AnnounceBatch, a malformed or unknown address does not fail the whole
+// batch, its error is reported in the corresponding result instead.
+func (l *Locator) ResolveBatch(ctx context.Context, req *pb.ResolveBatchRequest) (*pb.ResolveBatchReply, error) {
+	log.G(l.ctx).Info("handling ResolveBatch request", zap.Int("size", len(req.GetEthAddrs())))
+
+	results := make(map[string]*pb.ResolveBatchResult, len(req.GetEthAddrs()))
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	for _, addr := range req.GetEthAddrs() {
+		ethAddr, err := parseEthAddr(addr)
+		if err != nil {
+			results[addr] = &pb.ResolveBatchResult{Error: err.Error()}
+			continue
+		}
+
+		n, ok := l.db[ethAddr]
+		if !ok {
+			results[addr] = &pb.ResolveBatchResult{Error: errNodeNotFound.Error()}
+			continue
+		}
+
+		results[addr] = &pb.ResolveBatchResult{Reply: l.resolveReply(n)}
+	}
+
+	return &pb.ResolveBatchReply{Results: results}, nil
+}
@@ -0,0 +1,224 @@
+package locator
+
+import (
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/locator/locatortest"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func agentContext() context.Context {
+	return locatortest.ContextWithWallet(util.PubKeyToAddr(key.PublicKey))
+}
+
+func TestLocator_AnnounceBatch(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	worker1, _ := crypto.GenerateKey()
+	worker2, _ := crypto.GenerateKey()
+
+	valid, err := SignAnnounce(worker1, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1"}})
+	require.NoError(t, err)
+
+	// Signed by someone else on behalf of the second worker.
+	forged, err := SignAnnounce(worker1, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.2"}})
+	require.NoError(t, err)
+	forged.EthAddr = util.PubKeyToAddr(worker2.PublicKey).Hex()
+
+	reply, err := lc.AnnounceBatch(agentContext(), &pb.AnnounceBatchRequest{
+		Announces: []*pb.SignedAnnounce{valid, forged},
+	})
+	require.NoError(t, err)
+	require.Len(t, reply.GetResults(), 2)
+
+	assert.Equal(t, valid.EthAddr, reply.Results[0].EthAddr)
+	assert.Empty(t, reply.Results[0].Error)
+	assert.Equal(t, forged.EthAddr, reply.Results[1].EthAddr)
+	assert.Contains(t, reply.Results[1].Error, "announce is signed by")
+
+	n, err := lc.getResolve(util.PubKeyToAddr(worker1.PublicKey))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, n.ipAddr)
+
+	_, err = lc.getResolve(util.PubKeyToAddr(worker2.PublicKey))
+	assert.Equal(t, errNodeNotFound, err)
+}
+
+func TestLocator_AnnounceBatchRejectsTampered(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	worker, _ := crypto.GenerateKey()
+
+	tampered, err := SignAnnounce(worker, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1"}})
+	require.NoError(t, err)
+	tampered.IpAddr = []string{"6.6.6.6"}
+
+	stale, err := SignAnnounce(worker, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1"}})
+	require.NoError(t, err)
+	stale.Timestamp = time.Now().Add(-2 * time.Hour).Unix()
+
+	reply, err := lc.AnnounceBatch(agentContext(), &pb.AnnounceBatchRequest{
+		Announces: []*pb.SignedAnnounce{tampered, stale},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, reply.Results[0].Error, "announce is signed by")
+	assert.Equal(t, "announce is expired", reply.Results[1].Error)
+	assert.Empty(t, lc.db)
+}
+
+func TestLocator_AnnounceBatchAllowedAnnouncers(t *testing.T) {
+	allowed, _ := crypto.GenerateKey()
+	denied, _ := crypto.GenerateKey()
+
+	conf := DefaultConfig(":9090")
+	conf.AllowedAnnouncers = []common.Address{util.PubKeyToAddr(allowed.PublicKey)}
+
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	first, err := SignAnnounce(allowed, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1"}})
+	require.NoError(t, err)
+	second, err := SignAnnounce(denied, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.2"}})
+	require.NoError(t, err)
+
+	// The agent itself does not need to be allowed, only announced nodes do.
+	reply, err := lc.AnnounceBatch(agentContext(), &pb.AnnounceBatchRequest{
+		Announces: []*pb.SignedAnnounce{first, second},
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, reply.Results[0].Error)
+	assert.Equal(t, errAnnounceDenied.Error(), reply.Results[1].Error)
+	assert.Len(t, lc.db, 1)
+}
+
+func TestLocator_AnnounceBatchKeepsAnnounceProperties(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	worker, _ := crypto.GenerateKey()
+	announce, err := SignAnnounce(worker, &pb.AnnounceRequest{
+		IpAddr:          []string{"10.0.0.1"},
+		Tags:            []string{"gpu"},
+		ProtocolVersion: 2,
+		TtlSeconds:      60,
+	})
+	require.NoError(t, err)
+
+	// Properties are signed too.
+	tampered := *announce
+	tampered.Tags = []string{"gpu", "eu-region"}
+
+	reply, err := lc.AnnounceBatch(agentContext(), &pb.AnnounceBatchRequest{
+		Announces: []*pb.SignedAnnounce{&tampered, announce},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, reply.Results[0].Error, "announce is signed by")
+	assert.Empty(t, reply.Results[1].Error)
+
+	n, err := lc.getResolve(util.PubKeyToAddr(worker.PublicKey), "gpu")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), n.protocolVersion)
+	assert.Equal(t, time.Minute, n.ttl)
+}
+
+func TestLocator_AnnounceBatchRejectsReplayed(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	worker, _ := crypto.GenerateKey()
+	old, err := SignAnnounce(worker, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1"}})
+	require.NoError(t, err)
+	old.Timestamp = time.Now().Add(-time.Minute).Unix()
+	old, err = resign(worker, old)
+	require.NoError(t, err)
+
+	current, err := SignAnnounce(worker, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.2"}})
+	require.NoError(t, err)
+
+	reply, err := lc.AnnounceBatch(agentContext(), &pb.AnnounceBatchRequest{
+		Announces: []*pb.SignedAnnounce{current, old, current},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, reply.Results[0].Error)
+	assert.Equal(t, errAnnounceReplayed.Error(), reply.Results[1].Error)
+	assert.Equal(t, errAnnounceReplayed.Error(), reply.Results[2].Error)
+
+	n, err := lc.getResolve(util.PubKeyToAddr(worker.PublicKey))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2"}, n.ipAddr)
+}
+
+func TestLocator_AnnounceBatchRateLimited(t *testing.T) {
+	conf := DefaultConfig(":9090")
+	conf.AnnounceRateLimit = 1
+
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	worker, _ := crypto.GenerateKey()
+	first, err := SignAnnounce(worker, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1"}})
+	require.NoError(t, err)
+	second, err := SignAnnounce(worker, &pb.AnnounceRequest{IpAddr: []string{"10.0.0.2"}})
+	require.NoError(t, err)
+
+	reply, err := lc.AnnounceBatch(agentContext(), &pb.AnnounceBatchRequest{
+		Announces: []*pb.SignedAnnounce{first, second},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, reply.Results[0].Error)
+	assert.Equal(t, errAnnounceRateLimited.Error(), reply.Results[1].Error)
+}
+
+// resign signs the announce again after it has been modified.
+func resign(key *ecdsa.PrivateKey, announce *pb.SignedAnnounce) (*pb.SignedAnnounce, error) {
+	signature, err := crypto.Sign(announceHash(util.PubKeyToAddr(key.PublicKey), announce), key)
+	if err != nil {
+		return nil, err
+	}
+
+	announce.Signature = signature
+	return announce, nil
+}
+
+func TestLocator_ResolveBatch(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	worker, _ := crypto.GenerateKey()
+	workerAddr := util.PubKeyToAddr(worker.PublicKey)
+	lc.putAnnounce(&node{ethAddr: workerAddr, ipAddr: []string{"10.0.0.1"}})
+
+	unknown, _ := crypto.GenerateKey()
+	unknownAddr := util.PubKeyToAddr(unknown.PublicKey).Hex()
+
+	reply, err := lc.ResolveBatch(context.Background(), &pb.ResolveBatchRequest{
+		EthAddrs: []string{workerAddr.Hex(), unknownAddr, "garbage"},
+	})
+	require.NoError(t, err)
+	require.Len(t, reply.GetResults(), 3)
+
+	resolved := reply.GetResults()[workerAddr.Hex()]
+	require.NotNil(t, resolved.GetReply())
+	assert.Empty(t, resolved.GetError())
+	assert.Equal(t, []string{"10.0.0.1"}, resolved.GetReply().GetIpAddr())
+	assert.True(t, resolved.GetReply().GetCacheTTLSeconds() > 0)
+	assert.InDelta(t, 0, resolved.GetReply().GetAgeSeconds(), 2)
+
+	assert.Nil(t, reply.GetResults()[unknownAddr].GetReply())
+	assert.Equal(t, errNodeNotFound.Error(), reply.GetResults()[unknownAddr].GetError())
+
+	assert.Nil(t, reply.GetResults()["garbage"].GetReply())
+	assert.NotEmpty(t, reply.GetResults()["garbage"].GetError())
+}
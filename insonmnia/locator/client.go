@@ -0,0 +1,117 @@
+package locator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/sonm-io/core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// minCachePruneSize is the cache size below which expired entries are not
+// pruned on insert, because there are too few of them to bother.
+const minCachePruneSize = 64
+
+type cachedResolve struct {
+	reply    *pb.ResolveReply
+	deadline time.Time
+}
+
+// Client wraps a Locator client, caching Resolve results for as long as
+// the server allows by its TTL hint.
+type Client struct {
+	pb.LocatorClient
+
+	mu    sync.Mutex
+	cache map[string]*cachedResolve
+	// pruneSize is the cache size reaching which makes the next insert
+	// prune expired entries. It is doubled relative to the number of live
+	// entries after each prune, so inserts stay cheap on average.
+	pruneSize int
+	now       func() time.Time
+}
+
+// NewClient constructs a caching Locator client on top of the given one.
+func NewClient(client pb.LocatorClient) *Client {
+	return &Client{
+		LocatorClient: client,
+		cache:         make(map[string]*cachedResolve),
+		pruneSize:     minCachePruneSize,
+		now:           time.Now,
+	}
+}
+
+// cacheKey distinguishes requests for the same address with different tag
+// or version filters, because they may have different results.
+func cacheKey(in *pb.ResolveRequest) string {
+	tags := append([]string(nil), in.GetTags()...)
+	sort.Strings(tags)
+
+	return fmt.Sprintf("%s|%s|%d", in.GetEthAddr(), strings.Join(tags, ","), in.GetMinProtocolVersion())
+}
+
+func (c *Client) Resolve(ctx context.Context, in *pb.ResolveRequest, opts ...grpc.CallOption) (*pb.ResolveReply, error) {
+	key := cacheKey(in)
+	if reply, ok := c.getCached(key); ok {
+		return reply, nil
+	}
+
+	reply, err := c.LocatorClient.Resolve(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl := reply.GetCacheTTLSeconds(); ttl > 0 {
+		c.putCached(key, reply, time.Duration(ttl)*time.Second)
+	}
+
+	return reply, nil
+}
+
+// putCached caches the reply for the given time. Expired entries are
+// otherwise dropped only when looked up again, so they are pruned here too,
+// keeping the cache from growing with addresses nobody resolves anymore.
+func (c *Client) putCached(key string, reply *pb.ResolveReply, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.cache) >= c.pruneSize {
+		for k, entry := range c.cache {
+			if !now.Before(entry.deadline) {
+				delete(c.cache, k)
+			}
+		}
+
+		c.pruneSize = 2 * len(c.cache)
+		if c.pruneSize < minCachePruneSize {
+			c.pruneSize = minCachePruneSize
+		}
+	}
+
+	c.cache[key] = &cachedResolve{
+		reply:    reply,
+		deadline: now.Add(ttl),
+	}
+}
+
+func (c *Client) getCached(key string) (*pb.ResolveReply, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !c.now().Before(entry.deadline) {
+		delete(c.cache, key)
+		return nil, false
+	}
+
+	return entry.reply, true
+}
@@ -0,0 +1,106 @@
+package locator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type countingLocatorClient struct {
+	pb.LocatorClient
+	reply    *pb.ResolveReply
+	resolves int
+}
+
+func (c *countingLocatorClient) Resolve(ctx context.Context, in *pb.ResolveRequest, opts ...grpc.CallOption) (*pb.ResolveReply, error) {
+	c.resolves++
+	return c.reply, nil
+}
+
+func TestClient_ResolveCached(t *testing.T) {
+	inner := &countingLocatorClient{
+		reply: &pb.ResolveReply{IpAddr: []string{"1.2.3.4:10002"}, CacheTTLSeconds: 60},
+	}
+
+	now := time.Now()
+	cl := NewClient(inner)
+	cl.now = func() time.Time { return now }
+
+	req := &pb.ResolveRequest{EthAddr: "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD"}
+	for i := 0; i < 5; i++ {
+		reply, err := cl.Resolve(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1.2.3.4:10002"}, reply.GetIpAddr())
+	}
+	assert.Equal(t, 1, inner.resolves)
+
+	// The hint has expired, so the result must be refreshed.
+	now = now.Add(61 * time.Second)
+	_, err := cl.Resolve(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, inner.resolves)
+}
+
+func TestClient_PrunesExpired(t *testing.T) {
+	inner := &countingLocatorClient{
+		reply: &pb.ResolveReply{IpAddr: []string{"1.2.3.4:10002"}, CacheTTLSeconds: 60},
+	}
+
+	now := time.Now()
+	cl := NewClient(inner)
+	cl.now = func() time.Time { return now }
+
+	resolve := func(i int) {
+		_, err := cl.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: fmt.Sprintf("0x%040x", i)})
+		assert.NoError(t, err)
+	}
+
+	for i := 0; i < minCachePruneSize; i++ {
+		resolve(i)
+	}
+	assert.Len(t, cl.cache, minCachePruneSize)
+
+	// Expired entries of addresses not resolved again are pruned.
+	now = now.Add(61 * time.Second)
+	resolve(minCachePruneSize)
+	assert.Len(t, cl.cache, 1)
+}
+
+func TestClient_ResolveNoHint(t *testing.T) {
+	inner := &countingLocatorClient{
+		reply: &pb.ResolveReply{IpAddr: []string{"1.2.3.4:10002"}},
+	}
+
+	cl := NewClient(inner)
+	req := &pb.ResolveRequest{EthAddr: "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD"}
+	for i := 0; i < 3; i++ {
+		_, err := cl.Resolve(context.Background(), req)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, inner.resolves)
+}
+
+func TestClient_ResolveCachedPerTags(t *testing.T) {
+	inner := &countingLocatorClient{
+		reply: &pb.ResolveReply{IpAddr: []string{"1.2.3.4:10002"}, CacheTTLSeconds: 60},
+	}
+
+	cl := NewClient(inner)
+
+	addr := "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD"
+	_, err := cl.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr})
+	assert.NoError(t, err)
+	_, err = cl.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr, Tags: []string{"gpu", "high-mem"}})
+	assert.NoError(t, err)
+	_, err = cl.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr, Tags: []string{"high-mem", "gpu"}})
+	assert.NoError(t, err)
+	_, err = cl.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr, MinProtocolVersion: 2})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, inner.resolves)
+}
@@ -3,6 +3,7 @@ package locator
 import (
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/jinzhu/configor"
 	"github.com/sonm-io/core/accounts"
 )
@@ -12,6 +13,45 @@ type LocatorConfig struct {
 	NodeTTL       time.Duration      `yaml:"node_ttl"`
 	CleanupPeriod time.Duration      `yaml:"cleanup_period"`
 	Eth           accounts.EthConfig `required:"true" yaml:"ethereum"`
+	// CompactionPeriod describes how often the node db is checked for
+	// excessive backing capacity. Zero disables compaction.
+	CompactionPeriod time.Duration `yaml:"compaction_period" default:"10m"`
+	// CompactionRatio is the live/peak size ratio below which the node db
+	// is rebuilt to free memory.
+	CompactionRatio float64 `yaml:"compaction_ratio" default:"0.5"`
+	// DisableReplyCompression makes the locator send replies as is, saving
+	// CPU on small messages. Replies are gzipped by default, the same as by
+	// other services. Compressed requests are always accepted, because
+	// clients made with util.MakeGrpcClient gzip everything they send.
+	DisableReplyCompression bool `yaml:"disable_reply_compression"`
+	// MaxIPsPerNode limits the number of addresses a node may announce.
+	// Zero means no limit.
+	MaxIPsPerNode int `yaml:"max_ips_per_node" default:"64"`
+	// StrictIPLimit makes the locator reject announces exceeding
+	// MaxIPsPerNode instead of truncating them.
+	StrictIPLimit bool `yaml:"strict_ip_limit"`
+	// AllowedAnnouncers restricts announces to the listed nodes, which is
+	// useful for private deployments. Empty allows anyone to announce.
+	AllowedAnnouncers []common.Address `yaml:"allowed_announcers"`
+	// ReadOnly turns the locator into a replica which serves resolves
+	// from the snapshot saved by the primary and rejects announces.
+	ReadOnly bool `yaml:"read_only"`
+	// SnapshotPath is where the primary saves the node db, restoring it on
+	// start, and replicas load it from. Empty disables snapshots.
+	SnapshotPath string `yaml:"snapshot_path"`
+	// SnapshotPeriod describes how often the snapshot is synced.
+	SnapshotPeriod time.Duration `yaml:"snapshot_period" default:"1m"`
+	// MaxAnnounceTTL is the longest TTL a node may announce with. Zero
+	// means four times NodeTTL.
+	MaxAnnounceTTL time.Duration `yaml:"max_announce_ttl"`
+	// AnnounceRateLimit is the number of announces per minute allowed for
+	// each node. Zero means no limit.
+	AnnounceRateLimit int `yaml:"announce_rate_limit"`
+	// MetricsAddr is the address to serve metrics in the Prometheus format
+	// at, on a separate HTTP listener. Empty disables the endpoint.
+	MetricsAddr string `yaml:"metrics_addr"`
+	// Audit configures the audit log of announces and resolves.
+	Audit AuditConfig `yaml:"audit"`
 }
 
 // NewConfig loads a hub config from the specified YAML file.
@@ -30,5 +70,12 @@ func DefaultConfig(addr string) *LocatorConfig {
 		ListenAddr:    addr,
 		NodeTTL:       time.Hour,
 		CleanupPeriod: time.Minute,
+
+		CompactionPeriod: 10 * time.Minute,
+		CompactionRatio:  0.5,
+
+		MaxIPsPerNode: 64,
+
+		SnapshotPeriod: time.Minute,
 	}
 }
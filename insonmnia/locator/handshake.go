@@ -0,0 +1,58 @@
+package locator
+
+import (
+	"fmt"
+	"net"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/rcrowley/go-metrics"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+)
+
+// HandshakeError describes a failed TLS handshake with a remote peer.
+type HandshakeError struct {
+	RemoteAddr net.Addr
+	Err        error
+}
+
+func (e *HandshakeError) Error() string {
+	return fmt.Sprintf("TLS handshake with %s failed: %v", e.RemoteAddr, e.Err)
+}
+
+// handshakeObserver wraps server transport credentials to make handshake
+// failures visible. They happen before any handler is called, so without
+// it a client with a missing or invalid certificate leaves no trace on the
+// locator side.
+type handshakeObserver struct {
+	credentials.TransportCredentials
+
+	ctx      context.Context
+	failures metrics.Counter
+}
+
+func newHandshakeObserver(ctx context.Context, creds credentials.TransportCredentials, failures metrics.Counter) credentials.TransportCredentials {
+	return &handshakeObserver{
+		TransportCredentials: creds,
+		ctx:                  ctx,
+		failures:             failures,
+	}
+}
+
+func (h *handshakeObserver) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	secureConn, authInfo, err := h.TransportCredentials.ServerHandshake(conn)
+	if err != nil {
+		h.failures.Inc(1)
+		log.G(h.ctx).Warn("TLS handshake with a client failed, check its certificate",
+			zap.Stringer("remote", conn.RemoteAddr()), zap.Error(err))
+
+		return nil, nil, &HandshakeError{RemoteAddr: conn.RemoteAddr(), Err: err}
+	}
+
+	return secureConn, authInfo, nil
+}
+
+func (h *handshakeObserver) Clone() credentials.TransportCredentials {
+	return newHandshakeObserver(h.ctx, h.TransportCredentials.Clone(), h.failures)
+}
@@ -0,0 +1,91 @@
+package locator
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/noxiouz/zapctx/ctxlog"
+	"github.com/rcrowley/go-metrics"
+	"github.com/sonm-io/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/net/context"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestHandshakeObserver(t *testing.T) {
+	logs := &syncBuffer{}
+	logger := zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(logs), zap.DebugLevel))
+	ctx := ctxlog.WithLogger(context.Background(), logger)
+
+	_, TLSConfig, err := util.NewHitlessCertRotator(ctx, key)
+	require.NoError(t, err)
+
+	failures := metrics.NewCounter()
+	creds := newHandshakeObserver(ctx, util.NewTLS(TLSConfig), failures)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	// A client without any certificate.
+	go func() {
+		conn, err := tls.Dial("tcp", lis.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// The server may reject the client only after the client considers
+		// the handshake finished, so wait for it to hang up.
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		conn.Read(make([]byte, 1))
+	}()
+
+	conn, err := lis.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, _, err = creds.ServerHandshake(conn)
+	require.Error(t, err)
+
+	handshakeErr, ok := err.(*HandshakeError)
+	require.True(t, ok)
+	assert.Equal(t, conn.RemoteAddr(), handshakeErr.RemoteAddr)
+
+	assert.Equal(t, int64(1), failures.Count())
+	assert.Contains(t, logs.String(), "TLS handshake with a client failed")
+	assert.Contains(t, logs.String(), `"remote":"`+conn.RemoteAddr().String()+`"`)
+}
+
+func TestHandshakeError(t *testing.T) {
+	err := &HandshakeError{
+		RemoteAddr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4242},
+		Err:        errors.New("tls: client didn't provide a certificate"),
+	}
+
+	assert.Equal(t, "TLS handshake with 10.0.0.1:4242 failed: tls: client didn't provide a certificate", err.Error())
+}
@@ -0,0 +1,140 @@
+package locator
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The locator implements the standard gRPC health checking protocol, so
+// load balancers and orchestrators can probe it with stock tools, like
+// grpc_health_probe. The vendored gRPC does not ship the health package,
+// hence the messages and the service are declared here, being wire
+// compatible with "grpc.health.v1.Health".
+
+const healthServiceName = "grpc.health.v1.Health"
+
+// servingStatus mirrors HealthCheckResponse.ServingStatus.
+type servingStatus int32
+
+const (
+	statusUnknown        servingStatus = 0
+	statusServing        servingStatus = 1
+	statusNotServing     servingStatus = 2
+	statusServiceUnknown servingStatus = 3
+)
+
+type healthCheckRequest struct {
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+}
+
+func (m *healthCheckRequest) Reset()         { *m = healthCheckRequest{} }
+func (m *healthCheckRequest) String() string { return proto.CompactTextString(m) }
+func (*healthCheckRequest) ProtoMessage()    {}
+
+type healthCheckResponse struct {
+	Status servingStatus `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (m *healthCheckResponse) Reset()         { *m = healthCheckResponse{} }
+func (m *healthCheckResponse) String() string { return proto.CompactTextString(m) }
+func (*healthCheckResponse) ProtoMessage()    {}
+
+type healthServer interface {
+	Check(context.Context, *healthCheckRequest) (*healthCheckResponse, error)
+}
+
+// health tracks the locator readiness. The locator is serving once the
+// expired nodes cleanup is running and the node db snapshot, if enabled,
+// has been loaded, until it starts shutting down.
+type health struct {
+	mu             sync.Mutex
+	cleanupRunning bool
+	dbLoaded       bool
+	shuttingDown   bool
+}
+
+func (h *health) setCleanupRunning() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cleanupRunning = true
+}
+
+func (h *health) setDBLoaded() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.dbLoaded = true
+}
+
+func (h *health) setShuttingDown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.shuttingDown = true
+}
+
+func (h *health) isShuttingDown() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.shuttingDown
+}
+
+func (h *health) status() servingStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cleanupRunning && h.dbLoaded && !h.shuttingDown {
+		return statusServing
+	}
+
+	return statusNotServing
+}
+
+// Check reports the status of the whole server for the empty service name
+// and of the locator service, which are the same thing.
+func (h *health) Check(ctx context.Context, req *healthCheckRequest) (*healthCheckResponse, error) {
+	switch req.Service {
+	case "", "sonm.Locator":
+		return &healthCheckResponse{Status: h.status()}, nil
+	default:
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+}
+
+func registerHealthServer(srv *grpc.Server, h healthServer) {
+	srv.RegisterService(&healthServiceDesc, h)
+}
+
+func healthCheckHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(healthCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(healthServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + healthServiceName + "/Check",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(healthServer).Check(ctx, req.(*healthCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var healthServiceDesc = grpc.ServiceDesc{
+	ServiceName: healthServiceName,
+	HandlerType: (*healthServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Check",
+			Handler:    healthCheckHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "health.proto",
+}
@@ -0,0 +1,186 @@
+package locator
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// waitServing waits for the cleanup goroutine to start, which the locator
+// needs to become serving.
+func waitServing(t *testing.T, h *health) {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		h.mu.Lock()
+		running := h.cleanupRunning
+		h.mu.Unlock()
+		if running {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("cleanup has not started")
+}
+
+func checkHealth(t *testing.T, h *health, service string) servingStatus {
+	reply, err := h.Check(context.Background(), &healthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return reply.Status
+}
+
+func TestHealth_Status(t *testing.T) {
+	h := &health{}
+	assert.Equal(t, statusNotServing, h.status())
+
+	h.setCleanupRunning()
+	assert.Equal(t, statusNotServing, h.status())
+
+	h.setDBLoaded()
+	assert.Equal(t, statusServing, checkHealth(t, h, ""))
+	assert.Equal(t, statusServing, checkHealth(t, h, "sonm.Locator"))
+
+	h.setShuttingDown()
+	assert.Equal(t, statusNotServing, h.status())
+}
+
+func TestHealth_UnknownService(t *testing.T) {
+	h := &health{}
+	_, err := h.Check(context.Background(), &healthCheckRequest{Service: "sonm.Hub"})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestHealth_WireFormat(t *testing.T) {
+	// Status SERVING as encoded by grpc.health.v1.HealthCheckResponse.
+	data, err := proto.Marshal(&healthCheckResponse{Status: statusServing})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x08, 0x01}, data)
+
+	req := &healthCheckRequest{}
+	require.NoError(t, proto.Unmarshal([]byte{0x0a, 0x04, 's', 'o', 'n', 'm'}, req))
+	assert.Equal(t, "sonm", req.Service)
+}
+
+func TestLocator_HealthWithoutSnapshot(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	waitServing(t, lc.health)
+	assert.Equal(t, statusServing, lc.health.status())
+}
+
+func TestLocator_HealthReplicaWaitsForSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locator")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(dir, "locator.snapshot")
+
+	conf := DefaultConfig(":9090")
+	conf.SnapshotPath = path
+	conf.ReadOnly = true
+	replica, err := NewLocator(ctx, conf, key)
+	require.NoError(t, err)
+
+	waitServing(t, replica.health)
+	assert.Equal(t, statusNotServing, replica.health.status())
+
+	primaryConf := DefaultConfig(":9090")
+	primaryConf.SnapshotPath = path
+	primary, err := NewLocator(ctx, primaryConf, key)
+	require.NoError(t, err)
+	// Nothing to restore on the first start of the primary.
+	waitServing(t, primary.health)
+	assert.Equal(t, statusServing, primary.health.status())
+
+	require.NoError(t, primary.syncSnapshot())
+	require.NoError(t, replica.syncSnapshot())
+	assert.Equal(t, statusServing, replica.health.status())
+}
+
+func TestLocator_HealthCorruptSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locator")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(dir, "locator.snapshot")
+	require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+
+	conf := DefaultConfig(":9090")
+	conf.SnapshotPath = path
+	conf.ReadOnly = true
+	replica, err := NewLocator(ctx, conf, key)
+	require.NoError(t, err)
+
+	waitServing(t, replica.health)
+	assert.Equal(t, statusNotServing, replica.health.status())
+	assert.Equal(t, int64(1), replica.metrics.snapshotLoadFailures.Count())
+	// Replicas never touch the snapshot.
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+
+	primaryConf := DefaultConfig(":9090")
+	primaryConf.SnapshotPath = path
+	primary, err := NewLocator(ctx, primaryConf, key)
+	require.NoError(t, err)
+
+	waitServing(t, primary.health)
+	assert.Equal(t, statusServing, primary.health.status())
+	assert.Empty(t, primary.db)
+	assert.Equal(t, int64(1), primary.metrics.snapshotLoadFailures.Count())
+
+	// Saving the empty db must not destroy the corrupt snapshot.
+	require.NoError(t, primary.syncSnapshot())
+	corrupt, err := ioutil.ReadFile(path + corruptSnapshotSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, "{", string(corrupt))
+}
+
+func TestLocator_HealthCheckRPC(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig("localhost:9092"), key)
+	require.NoError(t, err)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- lc.Serve()
+	}()
+
+	cert, pkey, err := util.GenerateCert(key)
+	require.NoError(t, err)
+	crt, err := tls.X509KeyPair(cert, pkey)
+	require.NoError(t, err)
+	creds := util.NewTLS(&tls.Config{Certificates: []tls.Certificate{crt}, InsecureSkipVerify: true})
+
+	conn, err := util.MakeGrpcClient(context.Background(), "localhost:9092", creds)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	waitServing(t, lc.health)
+
+	reply := &healthCheckResponse{}
+	err = grpc.Invoke(context.Background(), "/grpc.health.v1.Health/Check", &healthCheckRequest{}, reply, conn)
+	require.NoError(t, err)
+	assert.Equal(t, statusServing, reply.Status)
+
+	lc.Close()
+	assert.Equal(t, statusNotServing, lc.health.status())
+	assert.NoError(t, <-served)
+}
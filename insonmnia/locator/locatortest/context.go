@@ -0,0 +1,24 @@
+// Package locatortest provides helpers for testing handlers that rely on
+// the authenticated peer, as the Locator does.
+package locatortest
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/util"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ContextWithWallet returns a context of a request made by a peer
+// authenticated with the given Ethereum address.
+func ContextWithWallet(addr common.Address) context.Context {
+	return ContextWithAuthInfo(util.EthAuthInfo{Wallet: addr})
+}
+
+// ContextWithAuthInfo returns a context of a request made by a peer with
+// arbitrary auth info, which is useful to check how unexpected
+// authentication types are handled.
+func ContextWithAuthInfo(authInfo credentials.AuthInfo) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: authInfo})
+}
@@ -0,0 +1,72 @@
+package locator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rcrowley/go-metrics"
+)
+
+// locatorMetrics describes locator's internal state exposed for monitoring.
+type locatorMetrics struct {
+	registry metrics.Registry
+	// gatherer collects both the registry above and native Prometheus
+	// metrics for the metrics endpoint.
+	gatherer *prometheus.Registry
+
+	// dbSizePreCompaction shows the peak node db size observed right before
+	// the last compaction.
+	dbSizePreCompaction metrics.Gauge
+	// dbSizePostCompaction shows the node db size right after the last
+	// compaction.
+	dbSizePostCompaction metrics.Gauge
+	// compactions counts how many times the node db has been rebuilt.
+	compactions metrics.Counter
+	// handshakeFailures counts TLS handshakes failed on the server side,
+	// mostly because of missing or invalid client certificates.
+	handshakeFailures metrics.Counter
+	// dbSize shows the current number of nodes in the db.
+	dbSize metrics.Gauge
+	// announces counts accepted announces, including batched ones.
+	announces metrics.Counter
+	// resolves counts Resolve requests with a well-formed address.
+	resolves metrics.Counter
+	// resolveMisses counts resolves which found no suitable node.
+	resolveMisses metrics.Counter
+	// resolveLatency measures how long Resolve requests take.
+	resolveLatency prometheus.Histogram
+	// snapshotLoadFailures counts node db snapshots failed to load, except
+	// for missing ones.
+	snapshotLoadFailures metrics.Counter
+}
+
+func newLocatorMetrics() *locatorMetrics {
+	r := metrics.NewRegistry()
+
+	m := &locatorMetrics{
+		registry:             r,
+		dbSizePreCompaction:  metrics.NewRegisteredGauge("db_size_pre_compaction", r),
+		dbSizePostCompaction: metrics.NewRegisteredGauge("db_size_post_compaction", r),
+		compactions:          metrics.NewRegisteredCounter("compactions", r),
+		handshakeFailures:    metrics.NewRegisteredCounter("tls_handshake_failures", r),
+		dbSize:               metrics.NewRegisteredGauge("db_size", r),
+		announces:            metrics.NewRegisteredCounter("announces", r),
+		resolves:             metrics.NewRegisteredCounter("resolves", r),
+		resolveMisses:        metrics.NewRegisteredCounter("resolve_misses", r),
+		snapshotLoadFailures: metrics.NewRegisteredCounter("snapshot_load_failures", r),
+		resolveLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "resolve_latency_seconds",
+			Help:      "How long Resolve requests take.",
+			Buckets:   resolveLatencyBuckets,
+		}),
+	}
+
+	m.gatherer = prometheus.NewRegistry()
+	m.gatherer.MustRegister(&registryCollector{registry: r}, m.resolveLatency)
+
+	return m
+}
+
+// Metrics returns a registry with locator metrics.
+func (l *Locator) Metrics() metrics.Registry {
+	return l.metrics.registry
+}
@@ -0,0 +1,44 @@
+package locator
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rcrowley/go-metrics"
+)
+
+// metricsNamespace prefixes the names of all exported locator metrics.
+const metricsNamespace = "locator"
+
+// resolveLatencyBuckets span from half a millisecond to about a second,
+// since resolving is mostly an in-memory lookup.
+var resolveLatencyBuckets = prometheus.ExponentialBuckets(0.0005, 2, 12)
+
+// newMetricsHandler serves the gathered metrics in the Prometheus exposition
+// format, so they can be scraped without running an exporter alongside.
+func newMetricsHandler(gatherer prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+}
+
+// registryCollector exports counters and gauges of a go-metrics registry as
+// Prometheus ones. It describes nothing, which makes it unchecked, because
+// metrics may be added to the registry at any time.
+type registryCollector struct {
+	registry metrics.Registry
+}
+
+func (c *registryCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+func (c *registryCollector) Collect(ch chan<- prometheus.Metric) {
+	c.registry.Each(func(name string, metric interface{}) {
+		desc := prometheus.NewDesc(metricsNamespace+"_"+name, "", nil, nil)
+
+		switch m := metric.(type) {
+		case metrics.Counter:
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(m.Count()))
+		case metrics.Gauge:
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(m.Value()))
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package locator
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrapeMetrics(t *testing.T, m *locatorMetrics) string {
+	rec := httptest.NewRecorder()
+	newMetricsHandler(m.gatherer).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	require.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	return rec.Body.String()
+}
+
+func TestMetricsHandler(t *testing.T) {
+	m := newLocatorMetrics()
+	m.announces.Inc(1)
+	m.dbSize.Update(42)
+
+	body := scrapeMetrics(t, m)
+	assert.Contains(t, body, "# TYPE locator_announces counter\nlocator_announces 1\n")
+	assert.Contains(t, body, "# TYPE locator_db_size gauge\nlocator_db_size 42\n")
+}
+
+func TestMetricsHandlerResolveLatency(t *testing.T) {
+	m := newLocatorMetrics()
+	m.resolveLatency.Observe(time.Millisecond.Seconds())
+	m.resolveLatency.Observe((3 * time.Second).Seconds())
+
+	body := scrapeMetrics(t, m)
+	assert.Contains(t, body, "# TYPE locator_resolve_latency_seconds histogram\n")
+	assert.Contains(t, body, "locator_resolve_latency_seconds_bucket{le=\"0.0005\"} 0\n")
+	assert.Contains(t, body, "locator_resolve_latency_seconds_bucket{le=\"0.001\"} 1\n")
+	assert.Contains(t, body, "locator_resolve_latency_seconds_bucket{le=\"1.024\"} 1\n")
+	assert.Contains(t, body, "locator_resolve_latency_seconds_bucket{le=\"+Inf\"} 2\n")
+	assert.Contains(t, body, "locator_resolve_latency_seconds_sum 3.001\n")
+	assert.Contains(t, body, "locator_resolve_latency_seconds_count 2\n")
+}
@@ -0,0 +1,83 @@
+package locator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errAnnounceRateLimited = status.Error(codes.ResourceExhausted, "too many announces, try again later")
+
+// tokenBucket is the announce budget of a single node.
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// announceLimiter limits announces of each node with a token bucket, which
+// is refilled continuously and holds up to a minute worth of announces.
+type announceLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[common.Address]*tokenBucket
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+func newAnnounceLimiter(perMinute int) *announceLimiter {
+	return &announceLimiter{
+		rate:    float64(perMinute) / time.Minute.Seconds(),
+		burst:   float64(perMinute),
+		buckets: map[common.Address]*tokenBucket{},
+		now:     time.Now,
+	}
+}
+
+// allow takes a token from the bucket of the given node, reporting whether
+// there has been one.
+func (al *announceLimiter) allow(ethAddr common.Address) bool {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	now := al.now()
+	bucket, ok := al.buckets[ethAddr]
+	if !ok {
+		bucket = &tokenBucket{tokens: al.burst, updated: now}
+		al.buckets[ethAddr] = bucket
+	}
+
+	al.refill(bucket, now)
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+	return true
+}
+
+func (al *announceLimiter) refill(bucket *tokenBucket, now time.Time) {
+	bucket.tokens += now.Sub(bucket.updated).Seconds() * al.rate
+	if bucket.tokens > al.burst {
+		bucket.tokens = al.burst
+	}
+	bucket.updated = now
+}
+
+// prune forgets buckets that have been refilled completely, because such
+// buckets do not differ from the ones made anew.
+func (al *announceLimiter) prune() {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	now := al.now()
+	for ethAddr, bucket := range al.buckets {
+		al.refill(bucket, now)
+		if bucket.tokens >= al.burst {
+			delete(al.buckets, ethAddr)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package locator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnounceLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := newAnnounceLimiter(2)
+	limiter.now = func() time.Time { return now }
+
+	spammer := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	other := common.HexToAddress("0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5")
+
+	assert.True(t, limiter.allow(spammer))
+	assert.True(t, limiter.allow(spammer))
+	assert.False(t, limiter.allow(spammer))
+	// Other nodes have their own budget.
+	assert.True(t, limiter.allow(other))
+
+	// A token is refilled every 30 seconds.
+	now = now.Add(30 * time.Second)
+	assert.True(t, limiter.allow(spammer))
+	assert.False(t, limiter.allow(spammer))
+}
+
+func TestAnnounceLimiterPrune(t *testing.T) {
+	now := time.Now()
+	limiter := newAnnounceLimiter(2)
+	limiter.now = func() time.Time { return now }
+
+	spammer := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	limiter.allow(spammer)
+	limiter.allow(spammer)
+
+	// Not refilled yet.
+	now = now.Add(30 * time.Second)
+	limiter.prune()
+	assert.Len(t, limiter.buckets, 1)
+
+	now = now.Add(30 * time.Second)
+	limiter.prune()
+	assert.Empty(t, limiter.buckets)
+}
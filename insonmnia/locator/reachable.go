@@ -0,0 +1,96 @@
+package locator
+
+import (
+	"sort"
+	"time"
+
+	log "github.com/noxiouz/zapctx/ctxlog"
+	pb "github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReportReachable records that the node has been successfully connected to
+// at the given address, which makes resolves list it before addresses that
+// have been reported earlier or never. Only announced addresses are
+// accepted, so reports can reorder them but never add new ones.
+func (l *Locator) ReportReachable(ctx context.Context, req *pb.ReportReachableRequest) (*pb.Empty, error) {
+	if l.conf.ReadOnly {
+		return nil, errReadOnly
+	}
+
+	log.G(l.ctx).Debug("handling ReportReachable request", zap.String("eth", req.EthAddr), zap.String("ip", req.IpAddr))
+
+	ethAddr, err := parseEthAddr(req.EthAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	n, ok := l.db[ethAddr]
+	if !ok {
+		return nil, errNodeNotFound
+	}
+
+	if !n.hasIP(req.IpAddr) {
+		return nil, status.Errorf(codes.InvalidArgument, "address %q has not been announced by the node", req.IpAddr)
+	}
+
+	// Resolves read nodes after releasing the lock, so the node is replaced
+	// rather than modified in place.
+	updated := *n
+	updated.reachable = make(map[string]time.Time, len(n.reachable)+1)
+	for addr, ts := range n.reachable {
+		updated.reachable[addr] = ts
+	}
+	updated.reachable[req.IpAddr] = time.Now()
+	l.db[ethAddr] = &updated
+
+	return &pb.Empty{}, nil
+}
+
+func (n *node) hasIP(addr string) bool {
+	for _, ip := range n.ipAddr {
+		if ip == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reachableFor returns reachability of the node's addresses which are
+// still among the given ones, to be kept across announces.
+func (n *node) reachableFor(ipAddr []string) map[string]time.Time {
+	var reachable map[string]time.Time
+	for _, addr := range ipAddr {
+		if ts, ok := n.reachable[addr]; ok {
+			if reachable == nil {
+				reachable = map[string]time.Time{}
+			}
+			reachable[addr] = ts
+		}
+	}
+
+	return reachable
+}
+
+// orderedIPs returns announced addresses, the most recently reachable
+// first. Addresses never reported reachable keep their announced order
+// after the others.
+func (n *node) orderedIPs() []string {
+	if len(n.reachable) == 0 {
+		return n.ipAddr
+	}
+
+	ips := append([]string(nil), n.ipAddr...)
+	sort.SliceStable(ips, func(i, j int) bool {
+		return n.reachable[ips[i]].After(n.reachable[ips[j]])
+	})
+
+	return ips
+}
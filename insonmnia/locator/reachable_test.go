@@ -0,0 +1,86 @@
+package locator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/locator/locatortest"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNode_OrderedIPs(t *testing.T) {
+	now := time.Now()
+	n := &node{
+		ipAddr: []string{"10.0.0.1:10002", "10.0.0.2:10002", "10.0.0.3:10002", "10.0.0.4:10002"},
+		reachable: map[string]time.Time{
+			"10.0.0.2:10002": now.Add(-time.Minute),
+			"10.0.0.4:10002": now,
+		},
+	}
+
+	assert.Equal(t, []string{"10.0.0.4:10002", "10.0.0.2:10002", "10.0.0.1:10002", "10.0.0.3:10002"}, n.orderedIPs())
+	// The announced order itself is kept intact.
+	assert.Equal(t, "10.0.0.1:10002", n.ipAddr[0])
+
+	n.reachable = nil
+	assert.Equal(t, n.ipAddr, n.orderedIPs())
+}
+
+func TestLocator_ReportReachable(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	addr := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	ips := []string{"10.0.0.1:10002", "10.0.0.2:10002", "10.0.0.3:10002"}
+
+	_, err = lc.Announce(locatortest.ContextWithWallet(addr), &pb.AnnounceRequest{IpAddr: ips})
+	require.NoError(t, err)
+
+	resolve := func() []string {
+		reply, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex()})
+		require.NoError(t, err)
+		return reply.GetIpAddr()
+	}
+
+	assert.Equal(t, ips, resolve())
+
+	_, err = lc.ReportReachable(context.Background(), &pb.ReportReachableRequest{EthAddr: addr.Hex(), IpAddr: "10.0.0.3:10002"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.3:10002", "10.0.0.1:10002", "10.0.0.2:10002"}, resolve())
+
+	// Reachability of addresses that are announced again survives the
+	// announce.
+	_, err = lc.Announce(locatortest.ContextWithWallet(addr), &pb.AnnounceRequest{IpAddr: ips[1:]})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.3:10002", "10.0.0.2:10002"}, resolve())
+
+	_, err = lc.Announce(locatortest.ContextWithWallet(addr), &pb.AnnounceRequest{IpAddr: ips[:2]})
+	require.NoError(t, err)
+	assert.Equal(t, ips[:2], resolve())
+}
+
+func TestLocator_ReportReachableInvalid(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	addr := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	_, err = lc.ReportReachable(context.Background(), &pb.ReportReachableRequest{EthAddr: addr.Hex(), IpAddr: "10.0.0.1:10002"})
+	assert.Equal(t, errNodeNotFound, err)
+
+	_, err = lc.Announce(locatortest.ContextWithWallet(addr), &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1:10002"}})
+	require.NoError(t, err)
+
+	_, err = lc.ReportReachable(context.Background(), &pb.ReportReachableRequest{EthAddr: addr.Hex(), IpAddr: "10.0.0.9:10002"})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	_, err = lc.ReportReachable(context.Background(), &pb.ReportReachableRequest{EthAddr: "garbage", IpAddr: "10.0.0.1:10002"})
+	st, _ = status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
@@ -0,0 +1,97 @@
+package locator
+
+import (
+	"net"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	pb "github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errIPNotFound = status.Error(codes.NotFound, "no node has announced the given IP")
+
+// ipIndex maps announced IPs to the nodes which have announced them. The
+// same IP may be announced by several nodes, for example when they are
+// behind a NAT.
+type ipIndex map[string]map[common.Address]struct{}
+
+func newIPIndex(db map[common.Address]*node) ipIndex {
+	idx := ipIndex{}
+	for _, n := range db {
+		idx.add(n)
+	}
+
+	return idx
+}
+
+func (idx ipIndex) add(n *node) {
+	for _, addr := range n.ipAddr {
+		ip := normalizeIP(addr)
+		if idx[ip] == nil {
+			idx[ip] = map[common.Address]struct{}{}
+		}
+		idx[ip][n.ethAddr] = struct{}{}
+	}
+}
+
+func (idx ipIndex) remove(n *node) {
+	for _, addr := range n.ipAddr {
+		ip := normalizeIP(addr)
+		delete(idx[ip], n.ethAddr)
+		if len(idx[ip]) == 0 {
+			delete(idx, ip)
+		}
+	}
+}
+
+// normalizeIP strips the port from announced "host:port" addresses and
+// brings IPs to their canonical form, so lookups do not depend on how the
+// address has been written.
+func normalizeIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+
+	return host
+}
+
+// ReverseResolve returns addresses of all nodes which have announced the
+// given IP.
+func (l *Locator) ReverseResolve(ctx context.Context, req *pb.ReverseResolveRequest) (*pb.ReverseResolveReply, error) {
+	log.G(l.ctx).Info("handling ReverseResolve request", zap.String("ip", req.GetIp()))
+
+	if req.GetIp() == "" {
+		return nil, status.Error(codes.InvalidArgument, "IP is required")
+	}
+
+	ethAddrs := l.getReverseResolve(normalizeIP(req.GetIp()))
+	if len(ethAddrs) == 0 {
+		return nil, errIPNotFound
+	}
+
+	sort.Strings(ethAddrs)
+
+	return &pb.ReverseResolveReply{EthAddrs: ethAddrs}, nil
+}
+
+func (l *Locator) getReverseResolve(ip string) []string {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	ethAddrs := make([]string, 0, len(l.byIP[ip]))
+	for ethAddr := range l.byIP[ip] {
+		ethAddrs = append(ethAddrs, ethAddr.Hex())
+	}
+
+	return ethAddrs
+}
@@ -0,0 +1,65 @@
+package locator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func reverseResolveCode(t *testing.T, lc *Locator, ip string) codes.Code {
+	_, err := lc.ReverseResolve(context.Background(), &pb.ReverseResolveRequest{Ip: ip})
+	require.Error(t, err)
+
+	st, _ := status.FromError(err)
+	return st.Code()
+}
+
+func TestLocator_ReverseResolve(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	first := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	second := common.HexToAddress("0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5")
+	lc.putAnnounce(&node{ethAddr: second, ipAddr: []string{"10.0.0.1:10002", "[2001:db8::1]:10002"}})
+	lc.putAnnounce(&node{ethAddr: first, ipAddr: []string{"10.0.0.1:10003"}})
+
+	// Both nodes are behind the same IP.
+	reply, err := lc.ReverseResolve(context.Background(), &pb.ReverseResolveRequest{Ip: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.Hex(), second.Hex()}, reply.GetEthAddrs())
+
+	reply, err = lc.ReverseResolve(context.Background(), &pb.ReverseResolveRequest{Ip: "2001:db8:0::1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{second.Hex()}, reply.GetEthAddrs())
+
+	assert.Equal(t, codes.NotFound, reverseResolveCode(t, lc, "10.0.0.2"))
+	assert.Equal(t, codes.InvalidArgument, reverseResolveCode(t, lc, ""))
+
+	// Announcing again replaces old IPs.
+	lc.putAnnounce(&node{ethAddr: second, ipAddr: []string{"10.0.0.2:10002"}})
+	reply, err = lc.ReverseResolve(context.Background(), &pb.ReverseResolveRequest{Ip: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.Hex()}, reply.GetEthAddrs())
+	assert.Equal(t, codes.NotFound, reverseResolveCode(t, lc, "2001:db8::1"))
+}
+
+func TestLocator_ReverseResolveExpired(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	worker := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	lc.putAnnounce(&node{ethAddr: worker, ipAddr: []string{"10.0.0.1:10002"}})
+	lc.db[worker].ts = time.Now().Add(-2 * lc.conf.NodeTTL)
+
+	lc.traverseAndClean()
+
+	assert.Equal(t, codes.NotFound, reverseResolveCode(t, lc, "10.0.0.1"))
+	assert.Empty(t, lc.byIP)
+}
@@ -3,14 +3,18 @@ package locator
 import (
 	"crypto/ecdsa"
 	"crypto/tls"
-	"fmt"
+	"encoding/hex"
 	"net"
+	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	log "github.com/noxiouz/zapctx/ctxlog"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
@@ -22,65 +26,405 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-var errNodeNotFound = errors.New("node with given Eth address cannot be found")
+var (
+	errNodeNotFound = status.Error(codes.NotFound, "node with given Eth address cannot be found")
+	// errNoCompatibleNode differs from errNodeNotFound by the code, so
+	// clients may tell outdated nodes from unknown ones.
+	errNoCompatibleNode = status.Error(codes.FailedPrecondition, "node with given Eth address speaks an incompatible protocol version")
+	errAnnounceDenied   = status.Error(codes.PermissionDenied, "node is not allowed to announce")
+)
+
+const (
+	// minResolvePrefixLen limits the cost of prefix resolving, which scans
+	// the whole node db, by rejecting too broad prefixes.
+	minResolvePrefixLen = 6
+	// maxResolvePrefixNodes is the maximum number of nodes returned by
+	// prefix resolving.
+	maxResolvePrefixNodes = 16
+	// defaultMaxAnnounceTTLFactor is the default maximum announced TTL
+	// relative to the node TTL.
+	defaultMaxAnnounceTTLFactor = 4
+)
 
 type node struct {
 	ethAddr common.Address
 	ipAddr  []string
-	ts      time.Time
+	tags    map[string]struct{}
+	// protocolVersion is zero for nodes that did not announce it.
+	protocolVersion uint32
+	ts              time.Time
+	// ttl overrides the locator's node TTL when not zero.
+	ttl time.Duration
+	// reachable maps announced addresses to the last time they have been
+	// reported reachable.
+	reachable map[string]time.Time
+}
+
+func newTagSet(tags []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		set[tag] = struct{}{}
+	}
+
+	return set
+}
+
+// hasTags reports whether the node has all of the given tags.
+func (n *node) hasTags(tags []string) bool {
+	for _, tag := range tags {
+		if _, ok := n.tags[tag]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// supportsVersion reports whether the node speaks at least the given
+// protocol version. Zero minimum means any version.
+func (n *node) supportsVersion(min uint32) bool {
+	return n.protocolVersion >= min
 }
 
 type Locator struct {
 	mx sync.Mutex
 
-	conf        *LocatorConfig
-	db          map[common.Address]*node
+	conf *LocatorConfig
+	db   map[common.Address]*node
+	// dbPeak is the maximum db size observed since the last compaction.
+	dbPeak      int
+	metrics     *locatorMetrics
 	ctx         context.Context
 	ethKey      *ecdsa.PrivateKey
 	grpc        *grpc.Server
 	certRotator util.HitlessCertRotator
 	creds       credentials.TransportCredentials
+	// byIP indexes the db by announced IPs for reverse lookups.
+	byIP ipIndex
+	// limiter is nil when announces are not rate limited.
+	limiter *announceLimiter
+	// announcers is nil when anyone is allowed to announce.
+	announcers map[common.Address]struct{}
+	health     *health
+	// auditLog records announces and resolves apart from the main log.
+	auditLog *zap.Logger
 }
 
-func (l *Locator) Announce(ctx context.Context, req *pb.AnnounceRequest) (*pb.Empty, error) {
+func (l *Locator) Announce(ctx context.Context, req *pb.AnnounceRequest) (_ *pb.Empty, err error) {
+	defer func() { l.audit(ctx, "Announce", err, zap.Strings("ips", req.IpAddr)) }()
+
+	if l.conf.ReadOnly {
+		return nil, errReadOnly
+	}
+
 	ethAddr, err := l.extractEthAddr(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if !l.allowedToAnnounce(ethAddr) {
+		return nil, errAnnounceDenied
+	}
+
+	// Checked before logging, so flooding nodes do not flood the log.
+	if l.limiter != nil && !l.limiter.allow(ethAddr) {
+		return nil, errAnnounceRateLimited
+	}
+
 	log.G(l.ctx).Info("handling Announce request",
-		zap.Stringer("eth", ethAddr), zap.Strings("ips", req.IpAddr))
+		zap.Stringer("eth", ethAddr), zap.Strings("ips", req.IpAddr), zap.Strings("tags", req.Tags),
+		zap.Uint32("version", req.ProtocolVersion))
+
+	ipAddr, err := l.limitIPs(ethAddr, req.IpAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := l.announceTTL(req.TtlSeconds)
+	if err != nil {
+		return nil, err
+	}
 
 	l.putAnnounce(&node{
-		ethAddr: ethAddr,
-		ipAddr:  req.IpAddr,
+		ethAddr:         ethAddr,
+		ipAddr:          ipAddr,
+		tags:            newTagSet(req.Tags),
+		protocolVersion: req.ProtocolVersion,
+		ttl:             ttl,
 	})
 
 	return &pb.Empty{}, nil
 }
 
-func (l *Locator) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.ResolveReply, error) {
-	log.G(l.ctx).Info("handling Resolve request", zap.String("eth", req.EthAddr))
+func (l *Locator) Resolve(ctx context.Context, req *pb.ResolveRequest) (_ *pb.ResolveReply, err error) {
+	defer func() { l.audit(ctx, "Resolve", err, zap.String("target", req.EthAddr)) }()
+
+	log.G(l.ctx).Info("handling Resolve request", zap.String("eth", req.EthAddr), zap.Strings("tags", req.Tags),
+		zap.Uint32("min_version", req.MinProtocolVersion))
 
-	if !common.IsHexAddress(req.EthAddr) {
-		return nil, fmt.Errorf("invalid ethaddress %s", req.EthAddr)
+	defer prometheus.NewTimer(l.metrics.resolveLatency).ObserveDuration()
+
+	ethAddr, err := parseEthAddr(req.EthAddr)
+	if err != nil {
+		return nil, err
 	}
 
-	n, err := l.getResolve(common.HexToAddress(req.EthAddr))
+	l.metrics.resolves.Inc(1)
+
+	n, err := l.getResolve(ethAddr, req.Tags...)
 	if err != nil {
+		l.metrics.resolveMisses.Inc(1)
 		return nil, err
 	}
 
-	return &pb.ResolveReply{IpAddr: n.ipAddr}, nil
+	if !n.supportsVersion(req.MinProtocolVersion) {
+		l.metrics.resolveMisses.Inc(1)
+		return nil, errNoCompatibleNode
+	}
+
+	return l.resolveReply(n), nil
+}
+
+func (l *Locator) ResolvePrefix(ctx context.Context, req *pb.ResolvePrefixRequest) (*pb.ResolvePrefixReply, error) {
+	log.G(l.ctx).Info("handling ResolvePrefix request", zap.String("prefix", req.Prefix))
+
+	prefix := strings.ToLower(req.Prefix)
+	if strings.HasPrefix(prefix, "0x") {
+		prefix = prefix[2:]
+	}
+
+	if len(prefix) < minResolvePrefixLen {
+		return nil, status.Errorf(codes.InvalidArgument, "prefix must contain at least %d hex digits", minResolvePrefixLen)
+	}
+
+	if len(prefix) > 2*common.AddressLength || strings.TrimLeft(prefix, "0123456789abcdef") != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address prefix %q", req.Prefix)
+	}
+
+	nodes := l.getResolvePrefix(prefix)
+	if len(nodes) == 0 {
+		return nil, errNodeNotFound
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].EthAddr < nodes[j].EthAddr
+	})
+
+	reply := &pb.ResolvePrefixReply{
+		Nodes:     nodes,
+		Ambiguous: len(nodes) > 1,
+	}
+
+	if len(nodes) > maxResolvePrefixNodes {
+		reply.Nodes = nodes[:maxResolvePrefixNodes]
+		reply.Truncated = true
+	}
+
+	return reply, nil
+}
+
+// validIPs returns announced addresses which are IPs, optionally with a
+// port, so resolves never return garbage to clients.
+func (l *Locator) validIPs(ethAddr common.Address, ipAddr []string) []string {
+	valid := make([]string, 0, len(ipAddr))
+	for _, addr := range ipAddr {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+
+		if net.ParseIP(host) == nil {
+			log.G(l.ctx).Warn("stripping invalid announced IP", zap.Stringer("eth", ethAddr), zap.String("addr", addr))
+			continue
+		}
+
+		valid = append(valid, addr)
+	}
+
+	return valid
+}
+
+// Stats reports the number of registered nodes and how fresh they are,
+// which is cheaper for operators than scraping metrics.
+func (l *Locator) Stats(ctx context.Context, req *pb.Empty) (*pb.LocatorStatsReply, error) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	reply := &pb.LocatorStatsReply{
+		Nodes:          uint64(len(l.db)),
+		NodeTTLSeconds: uint64(l.conf.NodeTTL / time.Second),
+	}
+
+	var oldest, newest time.Time
+	for _, n := range l.db {
+		if oldest.IsZero() || n.ts.Before(oldest) {
+			oldest = n.ts
+		}
+		if newest.IsZero() || n.ts.After(newest) {
+			newest = n.ts
+		}
+	}
+
+	if len(l.db) > 0 {
+		reply.OldestAnnounce = &pb.Timestamp{Seconds: oldest.Unix(), Nanos: int32(oldest.Nanosecond())}
+		reply.NewestAnnounce = &pb.Timestamp{Seconds: newest.Unix(), Nanos: int32(newest.Nanosecond())}
+	}
+
+	return reply, nil
+}
+
+// allowedToAnnounce reports whether the node may announce itself.
+func (l *Locator) allowedToAnnounce(ethAddr common.Address) bool {
+	if l.announcers == nil {
+		return true
+	}
+
+	_, ok := l.announcers[ethAddr]
+	return ok
+}
+
+// limitIPs strips invalid announced addresses and enforces the
+// MaxIPsPerNode limit on the rest by either truncating them or rejecting
+// the announce in strict mode.
+func (l *Locator) limitIPs(ethAddr common.Address, ipAddr []string) ([]string, error) {
+	ipAddr = l.validIPs(ethAddr, ipAddr)
+
+	limit := l.conf.MaxIPsPerNode
+	if limit <= 0 || len(ipAddr) <= limit {
+		return ipAddr, nil
+	}
+
+	if l.conf.StrictIPLimit {
+		log.G(l.ctx).Warn("rejecting announce with too many IPs",
+			zap.Stringer("eth", ethAddr), zap.Int("count", len(ipAddr)), zap.Int("limit", limit))
+		return nil, status.Errorf(codes.InvalidArgument, "too many IPs announced: %d, at most %d allowed", len(ipAddr), limit)
+	}
+
+	log.G(l.ctx).Warn("truncating announce with too many IPs",
+		zap.Stringer("eth", ethAddr), zap.Int("count", len(ipAddr)), zap.Int("limit", limit))
+	return ipAddr[:limit], nil
+}
+
+func (l *Locator) resolveReply(n *node) *pb.ResolveReply {
+	// Snapshots may come from a locator with a clock running ahead.
+	age := time.Since(n.ts)
+	if age < 0 {
+		age = 0
+	}
+
+	return &pb.ResolveReply{
+		IpAddr:          n.orderedIPs(),
+		CacheTTLSeconds: l.cacheTTL(n),
+		AgeSeconds:      uint64(age / time.Second),
+	}
+}
+
+// announceTTL validates the TTL a node has announced with, rejecting TTLs
+// longer than the configured maximum, so nodes cannot keep themselves in
+// the db forever.
+func (l *Locator) announceTTL(ttlSeconds uint64) (time.Duration, error) {
+	max := l.maxAnnounceTTL()
+	if ttlSeconds > uint64(max/time.Second) {
+		return 0, status.Errorf(codes.InvalidArgument, "TTL is too long: %d seconds, at most %d allowed",
+			ttlSeconds, uint64(max/time.Second))
+	}
+
+	return time.Duration(ttlSeconds) * time.Second, nil
+}
+
+// maxAnnounceTTL returns the longest TTL a node may announce with.
+func (l *Locator) maxAnnounceTTL() time.Duration {
+	if l.conf.MaxAnnounceTTL > 0 {
+		return l.conf.MaxAnnounceTTL
+	}
+
+	return defaultMaxAnnounceTTLFactor * l.conf.NodeTTL
+}
+
+// nodeTTL returns the TTL the node has announced with, or the default one.
+func (l *Locator) nodeTTL(n *node) time.Duration {
+	if n.ttl > 0 {
+		return n.ttl
+	}
+
+	return l.conf.NodeTTL
+}
+
+// expired reports whether the node has outlived its TTL.
+func (l *Locator) expired(n *node) bool {
+	return time.Since(n.ts) > l.nodeTTL(n)
+}
+
+// cacheTTL returns how many whole seconds are left until the node expires.
+func (l *Locator) cacheTTL(n *node) uint64 {
+	left := l.nodeTTL(n) - time.Since(n.ts)
+	if left <= 0 {
+		return 0
+	}
+
+	return uint64(left / time.Second)
+}
+
+// parseEthAddr strictly parses a "0x"-prefixed hex address. Letter case is
+// not significant, because addresses are keyed by their binary form.
+func parseEthAddr(addr string) (common.Address, error) {
+	if !strings.HasPrefix(addr, "0x") && !strings.HasPrefix(addr, "0X") {
+		return common.Address{}, status.Errorf(codes.InvalidArgument, "invalid eth address %q: missing 0x prefix", addr)
+	}
+
+	if len(addr) != 2+2*common.AddressLength || !common.IsHexAddress(addr) {
+		return common.Address{}, status.Errorf(codes.InvalidArgument, "invalid eth address %q", addr)
+	}
+
+	return common.HexToAddress(addr), nil
 }
 
 func (l *Locator) Serve() error {
+	if l.conf.MetricsAddr != "" {
+		if err := l.serveMetrics(); err != nil {
+			return err
+		}
+	}
+
 	lis, err := net.Listen("tcp", l.conf.ListenAddr)
 	if err != nil {
 		return err
 	}
 
-	return l.grpc.Serve(lis)
+	err = l.grpc.Serve(lis)
+	// Stopped servers fail to accept on the closed listener.
+	if l.health.isShuttingDown() {
+		return nil
+	}
+
+	return err
+}
+
+// Close reports the locator as not serving to health checks and stops it
+// gracefully, letting pending requests complete.
+func (l *Locator) Close() {
+	l.health.setShuttingDown()
+	l.grpc.GracefulStop()
+}
+
+// serveMetrics starts serving metrics in background, failing only if the
+// listener cannot be bound.
+func (l *Locator) serveMetrics() error {
+	lis, err := net.Listen("tcp", l.conf.MetricsAddr)
+	if err != nil {
+		return errors.Wrap(err, "cannot listen for metrics")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", newMetricsHandler(l.metrics.gatherer))
+
+	go func() {
+		if err := http.Serve(lis, mux); err != nil {
+			log.G(l.ctx).Warn("metrics endpoint has stopped", zap.Error(err))
+		}
+	}()
+
+	return nil
 }
 
 func (l *Locator) extractEthAddr(ctx context.Context) (common.Address, error) {
@@ -102,36 +446,128 @@ func (l *Locator) putAnnounce(n *node) {
 	defer l.mx.Unlock()
 
 	n.ts = time.Now()
+	l.storeAnnounce(n)
+}
+
+// putSignedAnnounce stores the node announced at its signing time, unless
+// the db already has a more recent announce of it, which makes replaying a
+// captured announce useless for rolling the node back.
+func (l *Locator) putSignedAnnounce(n *node) error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if prev, ok := l.db[n.ethAddr]; ok && !n.ts.After(prev.ts) {
+		return errAnnounceReplayed
+	}
+
+	l.storeAnnounce(n)
+	return nil
+}
+
+// storeAnnounce replaces the node in the db. The db must be locked.
+func (l *Locator) storeAnnounce(n *node) {
+	if prev, ok := l.db[n.ethAddr]; ok {
+		l.byIP.remove(prev)
+		n.reachable = prev.reachableFor(n.ipAddr)
+	}
+
 	l.db[n.ethAddr] = n
+	l.byIP.add(n)
+
+	if len(l.db) > l.dbPeak {
+		l.dbPeak = len(l.db)
+	}
+
+	l.metrics.announces.Inc(1)
+	l.metrics.dbSize.Update(int64(len(l.db)))
 }
 
-func (l *Locator) getResolve(ethAddr common.Address) (*node, error) {
+// getResolve looks up the node by its address. If tags are given, the node
+// is found only if it has all of them.
+func (l *Locator) getResolve(ethAddr common.Address, tags ...string) (*node, error) {
 	l.mx.Lock()
 	defer l.mx.Unlock()
 
 	n, ok := l.db[ethAddr]
-	if !ok {
+	if !ok || !n.hasTags(tags) {
 		return nil, errNodeNotFound
 	}
 
 	return n, nil
 }
 
+// getResolvePrefix scans the db for nodes whose lowercase hex address
+// without "0x" starts with the given prefix.
+func (l *Locator) getResolvePrefix(prefix string) []*pb.ResolvedNode {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	var nodes []*pb.ResolvedNode
+	for addr, n := range l.db {
+		if strings.HasPrefix(hex.EncodeToString(addr.Bytes()), prefix) {
+			nodes = append(nodes, &pb.ResolvedNode{EthAddr: addr.Hex(), IpAddr: n.ipAddr})
+		}
+	}
+
+	return nodes
+}
+
 func (l *Locator) cleanExpiredNodes() {
 	t := time.NewTicker(l.conf.CleanupPeriod)
 	defer t.Stop()
 
+	// Compaction is optional, nil channel blocks forever.
+	var compactC <-chan time.Time
+	if l.conf.CompactionPeriod > 0 {
+		ct := time.NewTicker(l.conf.CompactionPeriod)
+		defer ct.Stop()
+		compactC = ct.C
+	}
+
+	l.health.setCleanupRunning()
+
 	for {
 		select {
 		case <-t.C:
 			l.traverseAndClean()
+		case <-compactC:
+			l.compact()
 		}
 	}
 }
 
-func (l *Locator) traverseAndClean() {
-	deadline := time.Now().Add(-1 * l.conf.NodeTTL)
+// compact rebuilds the node db when the number of live entries drops below
+// the configured ratio of the peak size, because Go maps never shrink their
+// backing storage after deletions.
+//
+// Returns true if the db has been rebuilt.
+func (l *Locator) compact() bool {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	live, peak := len(l.db), l.dbPeak
+	if peak == 0 || float64(live) >= float64(peak)*l.conf.CompactionRatio {
+		return false
+	}
+
+	db := make(map[common.Address]*node, live)
+	for addr, n := range l.db {
+		db[addr] = n
+	}
+	l.db = db
+	l.byIP = newIPIndex(db)
+	l.dbPeak = live
 
+	l.metrics.dbSizePreCompaction.Update(int64(peak))
+	l.metrics.dbSizePostCompaction.Update(int64(live))
+	l.metrics.compactions.Inc(1)
+
+	log.G(l.ctx).Debug("node db compacted", zap.Int("peak", peak), zap.Int("live", live))
+
+	return true
+}
+
+func (l *Locator) traverseAndClean() {
 	l.mx.Lock()
 	defer l.mx.Unlock()
 
@@ -141,14 +577,21 @@ func (l *Locator) traverseAndClean() {
 		keep  uint64
 	)
 	for addr, node := range l.db {
-		if node.ts.Before(deadline) {
+		if l.expired(node) {
 			delete(l.db, addr)
+			l.byIP.remove(node)
 			del++
 		} else {
 			keep++
 		}
 	}
 
+	l.metrics.dbSize.Update(int64(len(l.db)))
+
+	if l.limiter != nil {
+		l.limiter.prune()
+	}
+
 	log.G(l.ctx).Debug("expired nodes cleaned",
 		zap.Int("total", total), zap.Uint64("keep", keep), zap.Uint64("del", del))
 }
@@ -158,11 +601,34 @@ func NewLocator(ctx context.Context, conf *LocatorConfig, key *ecdsa.PrivateKey)
 		return nil, errors.Wrap(err, "private key should be provided")
 	}
 
+	if conf.ReadOnly && conf.SnapshotPath == "" {
+		return nil, errReadOnlyWithoutSnapshot
+	}
+
 	l = &Locator{
-		db:     make(map[common.Address]*node),
-		conf:   conf,
-		ctx:    ctx,
-		ethKey: key,
+		db:      make(map[common.Address]*node),
+		byIP:    ipIndex{},
+		conf:    conf,
+		ctx:     ctx,
+		ethKey:  key,
+		metrics: newLocatorMetrics(),
+		health:  &health{dbLoaded: conf.SnapshotPath == ""},
+	}
+
+	if conf.AnnounceRateLimit > 0 {
+		l.limiter = newAnnounceLimiter(conf.AnnounceRateLimit)
+	}
+
+	if len(conf.AllowedAnnouncers) > 0 {
+		l.announcers = make(map[common.Address]struct{}, len(conf.AllowedAnnouncers))
+		for _, addr := range conf.AllowedAnnouncers {
+			l.announcers[addr] = struct{}{}
+		}
+	}
+
+	l.auditLog, err = newAuditLogger(conf.Audit)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot build audit logger")
 	}
 
 	var TLSConfig *tls.Config
@@ -171,13 +637,32 @@ func NewLocator(ctx context.Context, conf *LocatorConfig, key *ecdsa.PrivateKey)
 		return nil, err
 	}
 
-	l.creds = util.NewTLS(TLSConfig)
-	srv := util.MakeGrpcServer(l.creds)
+	l.creds = newHandshakeObserver(ctx, util.NewTLS(TLSConfig), l.metrics.handshakeFailures)
+
+	var opts []grpc.ServerOption
+	if conf.DisableReplyCompression {
+		// Keep the decompressor installed, otherwise compressed announces
+		// would be rejected, but send replies as is.
+		opts = append(opts, grpc.RPCCompressor(nil))
+	}
+
+	srv := util.MakeGrpcServer(l.creds, opts...)
 	l.grpc = srv
 
 	go l.cleanExpiredNodes()
 
+	if conf.SnapshotPath != "" {
+		if err := l.restoreSnapshot(); err != nil {
+			return nil, err
+		}
+
+		if conf.SnapshotPeriod > 0 {
+			go l.syncSnapshots()
+		}
+	}
+
 	pb.RegisterLocatorServer(srv, l)
+	registerHealthServer(srv, l.health)
 
 	return l, nil
 }
@@ -3,15 +3,30 @@ package locator
 import (
 	"crypto/ecdsa"
 	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sonm-io/core/insonmnia/locator/locatortest"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -58,6 +73,67 @@ func TestLocator_Resolve(t *testing.T) {
 	assert.Len(t, n2.ipAddr, 2)
 }
 
+func TestLocator_ResolveCacheTTL(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	addr := common.StringToAddress("123")
+	lc.putAnnounce(&node{ethAddr: addr, ipAddr: []string{"111"}})
+	lc.db[addr].ts = time.Now().Add(-20 * time.Minute)
+
+	reply, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex()})
+	assert.NoError(t, err)
+	// Approximately 40 minutes of the hour TTL are left.
+	assert.InDelta(t, 40*60, reply.GetCacheTTLSeconds(), 2)
+	assert.InDelta(t, 20*60, reply.GetAgeSeconds(), 2)
+}
+
+func TestLocator_ResolveCaseInsensitive(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	addr := common.HexToAddress("0x8125721c2413d99a33e351e1f6bb4e56b6b633fd")
+	lc.putAnnounce(&node{ethAddr: addr, ipAddr: []string{"111"}})
+
+	queries := []string{
+		"0x8125721c2413d99a33e351e1f6bb4e56b6b633fd",
+		"0x8125721C2413D99A33E351E1F6BB4E56B6B633FD",
+		"0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD",
+	}
+	for _, query := range queries {
+		reply, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: query})
+		assert.NoError(t, err, query)
+		assert.Equal(t, []string{"111"}, reply.GetIpAddr(), query)
+	}
+}
+
+func TestLocator_ResolveInvalidAddr(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	queries := []string{
+		"",
+		"8125721c2413d99a33e351e1f6bb4e56b6b633fd",
+		"0x8125721c2413d99a33e351e1f6bb4e56b6b633",
+		"0x8125721c2413d99a33e351e1f6bb4e56b6b633fd00",
+		"0xZZ25721c2413d99a33e351e1f6bb4e56b6b633fd",
+	}
+	for _, query := range queries {
+		_, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: query})
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code(), query)
+	}
+}
+
 func TestLocator_Resolve2(t *testing.T) {
 	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
 	if err != nil {
@@ -140,3 +216,623 @@ func TestLocator_AnnounceExternal(t *testing.T) {
 		t.Error("Failed to securely announce")
 	}
 }
+
+func TestLocator_AnnounceCompressed(t *testing.T) {
+	for _, disableReplyCompression := range []bool{false, true} {
+		conf := DefaultConfig("localhost:0")
+		conf.DisableReplyCompression = disableReplyCompression
+		testAnnounceCompressed(t, conf)
+	}
+}
+
+func testAnnounceCompressed(t *testing.T, conf *LocatorConfig) {
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", conf.ListenAddr)
+	require.NoError(t, err)
+	go lc.grpc.Serve(lis)
+	defer lc.grpc.Stop()
+
+	cert, pkey, err := util.GenerateCert(key)
+	require.NoError(t, err)
+	crt, err := tls.X509KeyPair(cert, pkey)
+	require.NoError(t, err)
+	creds := util.NewTLS(&tls.Config{Certificates: []tls.Certificate{crt}, InsecureSkipVerify: true})
+
+	conn, err := util.MakeGrpcClient(context.Background(), lis.Addr().String(), creds,
+		grpc.WithCompressor(grpc.NewGZIPCompressor()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ips := make([]string, 0, 64)
+	for i := 0; i < 64; i++ {
+		ips = append(ips, fmt.Sprintf("10.0.0.%d", i))
+	}
+
+	locatorClient := pb.NewLocatorClient(conn)
+	_, err = locatorClient.Announce(context.Background(), &pb.AnnounceRequest{IpAddr: ips})
+	require.NoError(t, err)
+
+	reply, err := locatorClient.Resolve(context.Background(),
+		&pb.ResolveRequest{EthAddr: util.PubKeyToAddr(key.PublicKey).Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, ips, reply.GetIpAddr())
+}
+
+func TestLocator_Compact(t *testing.T) {
+	conf := DefaultConfig(":9090")
+	conf.CompactionRatio = 0.5
+
+	lc, err := NewLocator(context.Background(), conf, key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for i := 0; i < 100; i++ {
+		lc.putAnnounce(&node{ethAddr: common.BigToAddress(big.NewInt(int64(i)))})
+	}
+
+	// Not enough entries are gone yet.
+	assert.False(t, lc.compact())
+
+	for i := 0; i < 90; i++ {
+		delete(lc.db, common.BigToAddress(big.NewInt(int64(i))))
+	}
+
+	assert.True(t, lc.compact())
+	assert.Len(t, lc.db, 10)
+	assert.Equal(t, 10, lc.dbPeak)
+	assert.Equal(t, int64(100), lc.metrics.dbSizePreCompaction.Value())
+	assert.Equal(t, int64(10), lc.metrics.dbSizePostCompaction.Value())
+	assert.Equal(t, int64(1), lc.metrics.compactions.Count())
+
+	// Nothing changed since the last compaction.
+	assert.False(t, lc.compact())
+}
+
+func TestLocator_ResolvePrefix(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	lc.putAnnounce(&node{ethAddr: common.HexToAddress("0xAbCdEf0000000000000000000000000000000001"), ipAddr: []string{"1"}})
+	lc.putAnnounce(&node{ethAddr: common.HexToAddress("0xabcdef1000000000000000000000000000000002"), ipAddr: []string{"2"}})
+	lc.putAnnounce(&node{ethAddr: common.HexToAddress("0x1234560000000000000000000000000000000003"), ipAddr: []string{"3"}})
+
+	reply, err := lc.ResolvePrefix(context.Background(), &pb.ResolvePrefixRequest{Prefix: "0xABCDEF00"})
+	assert.NoError(t, err)
+	assert.False(t, reply.GetAmbiguous())
+	assert.Len(t, reply.GetNodes(), 1)
+	assert.Equal(t, []string{"1"}, reply.GetNodes()[0].GetIpAddr())
+
+	reply, err = lc.ResolvePrefix(context.Background(), &pb.ResolvePrefixRequest{Prefix: "abcdef"})
+	assert.NoError(t, err)
+	assert.True(t, reply.GetAmbiguous())
+	assert.False(t, reply.GetTruncated())
+	assert.Len(t, reply.GetNodes(), 2)
+
+	_, err = lc.ResolvePrefix(context.Background(), &pb.ResolvePrefixRequest{Prefix: "fedcba"})
+	assert.Equal(t, errNodeNotFound, err)
+}
+
+func TestLocator_ResolvePrefixInvalid(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, prefix := range []string{"", "0xabc", "abcdeg", "0x" + strings.Repeat("a", 41)} {
+		_, err := lc.ResolvePrefix(context.Background(), &pb.ResolvePrefixRequest{Prefix: prefix})
+		st, ok := status.FromError(err)
+		assert.True(t, ok, prefix)
+		assert.Equal(t, codes.InvalidArgument, st.Code(), prefix)
+	}
+}
+
+func TestLocator_ResolvePrefixTruncated(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for i := 0; i < maxResolvePrefixNodes+1; i++ {
+		lc.putAnnounce(&node{ethAddr: common.HexToAddress(fmt.Sprintf("0xabcdef%034x", i))})
+	}
+
+	reply, err := lc.ResolvePrefix(context.Background(), &pb.ResolvePrefixRequest{Prefix: "abcdef"})
+	assert.NoError(t, err)
+	assert.True(t, reply.GetTruncated())
+	assert.Len(t, reply.GetNodes(), maxResolvePrefixNodes)
+}
+
+func TestLocator_ResolveTags(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	addr := common.StringToAddress("123")
+	lc.putAnnounce(&node{ethAddr: addr, ipAddr: []string{"111"}, tags: newTagSet([]string{"gpu", "eu-region"})})
+
+	resolve := func(tags ...string) error {
+		_, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex(), Tags: tags})
+		return err
+	}
+
+	assert.NoError(t, resolve())
+	assert.NoError(t, resolve("gpu"))
+	assert.NoError(t, resolve("gpu", "eu-region"))
+	assert.Equal(t, errNodeNotFound, resolve("high-mem"))
+	assert.Equal(t, errNodeNotFound, resolve("gpu", "high-mem"))
+}
+
+func TestLocator_ResolveMinProtocolVersion(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	addr := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	_, err = lc.Announce(locatortest.ContextWithWallet(addr), &pb.AnnounceRequest{
+		IpAddr:          []string{"1.2.3.4:10002"},
+		ProtocolVersion: 3,
+	})
+	require.NoError(t, err)
+
+	resolve := func(min uint32) error {
+		_, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex(), MinProtocolVersion: min})
+		return err
+	}
+
+	assert.NoError(t, resolve(0))
+	assert.NoError(t, resolve(2))
+	// Exact match is compatible.
+	assert.NoError(t, resolve(3))
+	// The node is too old.
+	assert.Equal(t, errNoCompatibleNode, resolve(4))
+}
+
+func TestLocator_ResolveMinProtocolVersionUnannounced(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	addr := common.StringToAddress("123")
+	lc.putAnnounce(&node{ethAddr: addr, ipAddr: []string{"111"}})
+
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex(), MinProtocolVersion: 1})
+	assert.Equal(t, errNoCompatibleNode, err)
+
+	// Unknown nodes are still reported as not found.
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{
+		EthAddr:            common.StringToAddress("456").Hex(),
+		MinProtocolVersion: 1,
+	})
+	assert.Equal(t, errNodeNotFound, err)
+}
+
+func TestLocator_AnnounceHandler(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	addr := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	_, err = lc.Announce(locatortest.ContextWithWallet(addr), &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}})
+	assert.NoError(t, err)
+
+	reply, err := lc.Resolve(locatortest.ContextWithWallet(addr), &pb.ResolveRequest{EthAddr: addr.Hex()})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.2.3.4:10002"}, reply.GetIpAddr())
+}
+
+func TestLocator_AnnounceHandlerNoPeer(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = lc.Announce(context.Background(), &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}})
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.DataLoss, st.Code())
+	assert.Empty(t, lc.db)
+}
+
+func TestLocator_AnnounceHandlerWrongAuthInfo(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx := locatortest.ContextWithAuthInfo(credentials.TLSInfo{})
+	_, err = lc.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}})
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unauthenticated, st.Code())
+	assert.Empty(t, lc.db)
+}
+
+func TestLocator_ResolveHandlerNotFound(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	addr := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	_, err = lc.Resolve(locatortest.ContextWithWallet(addr), &pb.ResolveRequest{EthAddr: addr.Hex()})
+	assert.Equal(t, errNodeNotFound, err)
+}
+
+func TestLocator_ResolveErrorCodes(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	addr := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	lc.putAnnounce(&node{ethAddr: addr, ipAddr: []string{"10.0.0.1:10002"}, protocolVersion: 1})
+
+	code := func(err error) codes.Code {
+		st, ok := status.FromError(err)
+		require.True(t, ok, "not a gRPC status: %v", err)
+		return st.Code()
+	}
+
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: "0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5"})
+	assert.Equal(t, codes.NotFound, code(err))
+
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, code(err))
+
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex(), MinProtocolVersion: 2})
+	assert.Equal(t, codes.FailedPrecondition, code(err))
+
+	_, err = lc.ResolvePrefix(context.Background(), &pb.ResolvePrefixRequest{Prefix: "0xffffff"})
+	assert.Equal(t, codes.NotFound, code(err))
+}
+
+func TestLocator_AnnounceIPLimit(t *testing.T) {
+	makeIPs := func(count int) []string {
+		ips := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			ips = append(ips, fmt.Sprintf("10.0.0.%d", i))
+		}
+		return ips
+	}
+
+	addr := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+
+	for _, strict := range []bool{false, true} {
+		conf := DefaultConfig(":9090")
+		conf.MaxIPsPerNode = 3
+		conf.StrictIPLimit = strict
+
+		lc, err := NewLocator(context.Background(), conf, key)
+		require.NoError(t, err)
+
+		announce := func(count int) ([]string, error) {
+			_, err := lc.Announce(locatortest.ContextWithWallet(addr), &pb.AnnounceRequest{IpAddr: makeIPs(count)})
+			if err != nil {
+				return nil, err
+			}
+
+			n, err := lc.getResolve(addr)
+			require.NoError(t, err)
+			return n.ipAddr, nil
+		}
+
+		ips, err := announce(2)
+		require.NoError(t, err)
+		assert.Equal(t, makeIPs(2), ips)
+
+		ips, err = announce(3)
+		require.NoError(t, err)
+		assert.Equal(t, makeIPs(3), ips)
+
+		ips, err = announce(5)
+		if strict {
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			// The previous announce is kept.
+			n, err := lc.getResolve(addr)
+			require.NoError(t, err)
+			assert.Equal(t, makeIPs(3), n.ipAddr)
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, makeIPs(3), ips)
+		}
+	}
+}
+
+func TestLocator_AnnounceStripsInvalidIPs(t *testing.T) {
+	conf := DefaultConfig(":9090")
+	conf.MaxIPsPerNode = 2
+	conf.StrictIPLimit = true
+
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	addr := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	ips := []string{"garbage", "10.0.0.1:10002", "example.com:10002", "", "[2001:db8::1]:10002", "10.0.0.256"}
+
+	// Invalid addresses do not count against the limit.
+	_, err = lc.Announce(locatortest.ContextWithWallet(addr), &pb.AnnounceRequest{IpAddr: ips})
+	require.NoError(t, err)
+
+	reply, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:10002", "[2001:db8::1]:10002"}, reply.GetIpAddr())
+}
+
+func TestLocator_AnnounceAllowedAnnouncers(t *testing.T) {
+	allowed := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	other := common.HexToAddress("0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5")
+
+	conf := DefaultConfig(":9090")
+	conf.AllowedAnnouncers = []common.Address{allowed}
+
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	_, err = lc.Announce(locatortest.ContextWithWallet(allowed), &pb.AnnounceRequest{IpAddr: []string{"10.0.0.1:10002"}})
+	assert.NoError(t, err)
+
+	_, err = lc.Announce(locatortest.ContextWithWallet(other), &pb.AnnounceRequest{IpAddr: []string{"10.0.0.2:10002"}})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+
+	_, err = lc.getResolve(other)
+	assert.Equal(t, errNodeNotFound, err)
+}
+
+func TestLocator_ReadOnlyWithoutSnapshot(t *testing.T) {
+	conf := DefaultConfig(":9090")
+	conf.ReadOnly = true
+
+	_, err := NewLocator(context.Background(), conf, key)
+	assert.Error(t, err)
+}
+
+func TestLocator_ReadOnlyReplica(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locator")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "locator.snapshot")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	primaryConf := DefaultConfig(":9090")
+	primaryConf.SnapshotPath = path
+	primary, err := NewLocator(ctx, primaryConf, key)
+	require.NoError(t, err)
+
+	worker := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	primary.putAnnounce(&node{
+		ethAddr:         worker,
+		ipAddr:          []string{"1.2.3.4:10002"},
+		tags:            newTagSet([]string{"gpu"}),
+		protocolVersion: 2,
+	})
+	require.NoError(t, primary.saveSnapshot(path))
+
+	replicaConf := DefaultConfig(":9090")
+	replicaConf.SnapshotPath = path
+	replicaConf.ReadOnly = true
+	replica, err := NewLocator(ctx, replicaConf, key)
+	require.NoError(t, err)
+
+	reply, err := replica.Resolve(ctx, &pb.ResolveRequest{EthAddr: worker.Hex(), Tags: []string{"gpu"}, MinProtocolVersion: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.2.3.4:10002"}, reply.GetIpAddr())
+	assert.True(t, reply.GetCacheTTLSeconds() > 0)
+
+	_, err = replica.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"5.6.7.8:10002"}})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+
+	_, err = replica.AnnounceBatch(ctx, &pb.AnnounceBatchRequest{})
+	st, _ = status.FromError(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+
+	// Later changes of the primary reach the replica with the next sync.
+	other := common.HexToAddress("0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5")
+	primary.putAnnounce(&node{ethAddr: other, ipAddr: []string{"5.6.7.8:10002"}})
+	require.NoError(t, primary.syncSnapshot())
+	require.NoError(t, replica.syncSnapshot())
+
+	_, err = replica.Resolve(ctx, &pb.ResolveRequest{EthAddr: other.Hex()})
+	assert.NoError(t, err)
+}
+
+func TestLocator_RestoreAfterRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locator")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := DefaultConfig(":9090")
+	conf.SnapshotPath = filepath.Join(dir, "locator.snapshot")
+
+	// Nothing to restore on the first start.
+	lc, err := NewLocator(ctx, conf, key)
+	require.NoError(t, err)
+
+	fresh := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	stale := common.HexToAddress("0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5")
+	lc.putAnnounce(&node{ethAddr: fresh, ipAddr: []string{"1.2.3.4:10002"}})
+	lc.putAnnounce(&node{ethAddr: stale, ipAddr: []string{"5.6.7.8:10002"}})
+	lc.db[stale].ts = time.Now().Add(-2 * conf.NodeTTL)
+	require.NoError(t, lc.syncSnapshot())
+
+	restarted, err := NewLocator(ctx, conf, key)
+	require.NoError(t, err)
+
+	reply, err := restarted.Resolve(ctx, &pb.ResolveRequest{EthAddr: fresh.Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.2.3.4:10002"}, reply.GetIpAddr())
+
+	_, err = restarted.Resolve(ctx, &pb.ResolveRequest{EthAddr: stale.Hex()})
+	assert.Error(t, err)
+}
+
+func TestLocator_RestoreLimitsIPs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locator")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "locator.snapshot")
+	ts := time.Now().Format(time.RFC3339Nano)
+	snapshot := `{"nodes": [
+		{"eth_addr": "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD", "ts": "` + ts + `",
+		 "ip_addr": ["1.2.3.4:10002", "garbage", "5.6.7.8:10002", "9.9.9.9:10002"],
+		 "reachable": {"9.9.9.9:10002": "` + ts + `"}}
+	]}`
+	require.NoError(t, ioutil.WriteFile(path, []byte(snapshot), 0600))
+
+	conf := DefaultConfig(":9090")
+	conf.MaxIPsPerNode = 2
+
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+	require.NoError(t, lc.loadSnapshot(path))
+
+	n, err := lc.getResolve(common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.2.3.4:10002", "5.6.7.8:10002"}, n.ipAddr)
+	assert.Empty(t, n.reachable)
+
+	// Nodes rejected by strict limiting are not restored.
+	conf.StrictIPLimit = true
+	require.NoError(t, lc.loadSnapshot(path))
+	assert.Empty(t, lc.db)
+}
+
+func TestLocator_ResolveMetrics(t *testing.T) {
+	conf := DefaultConfig(":9090")
+	conf.NodeTTL = time.Millisecond
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	worker := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	lc.putAnnounce(&node{ethAddr: worker, ipAddr: []string{"1.2.3.4:10002"}, protocolVersion: 1})
+	assert.Equal(t, int64(1), lc.metrics.announces.Count())
+	assert.Equal(t, int64(1), lc.metrics.dbSize.Value())
+
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: worker.Hex()})
+	require.NoError(t, err)
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: worker.Hex(), MinProtocolVersion: 2})
+	require.Error(t, err)
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: "0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5"})
+	require.Error(t, err)
+
+	assert.Equal(t, int64(3), lc.metrics.resolves.Count())
+	assert.Equal(t, int64(2), lc.metrics.resolveMisses.Count())
+	latency := &dto.Metric{}
+	require.NoError(t, lc.metrics.resolveLatency.Write(latency))
+	assert.Equal(t, uint64(3), latency.GetHistogram().GetSampleCount())
+
+	time.Sleep(2 * time.Millisecond)
+	lc.traverseAndClean()
+	assert.Equal(t, int64(0), lc.metrics.dbSize.Value())
+}
+
+func TestLocator_AnnounceTTL(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	addr := util.PubKeyToAddr(key.PublicKey)
+	ctx := locatortest.ContextWithWallet(addr)
+
+	_, err = lc.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}, TtlSeconds: 60})
+	require.NoError(t, err)
+
+	reply, err := lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex()})
+	require.NoError(t, err)
+	assert.True(t, reply.GetCacheTTLSeconds() <= 60)
+
+	// Expired by its own TTL, while the default one is an hour.
+	lc.db[addr].ts = time.Now().Add(-2 * time.Minute)
+	lc.traverseAndClean()
+
+	_, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex()})
+	assert.Equal(t, errNodeNotFound, err)
+
+	// The default TTL applies when none is announced.
+	_, err = lc.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}})
+	require.NoError(t, err)
+	lc.db[addr].ts = time.Now().Add(-2 * time.Minute)
+	lc.traverseAndClean()
+
+	reply, err = lc.Resolve(context.Background(), &pb.ResolveRequest{EthAddr: addr.Hex()})
+	require.NoError(t, err)
+	assert.True(t, reply.GetCacheTTLSeconds() > 60)
+
+	_, err = lc.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}, TtlSeconds: math.MaxUint64})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	// Four times the node TTL by default.
+	_, err = lc.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}, TtlSeconds: 4 * 3600})
+	require.NoError(t, err)
+	_, err = lc.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}, TtlSeconds: 4*3600 + 1})
+	st, _ = status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	lc.conf.MaxAnnounceTTL = 10 * time.Minute
+	_, err = lc.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}, TtlSeconds: 601})
+	st, _ = status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestLocator_AnnounceRateLimit(t *testing.T) {
+	conf := DefaultConfig(":9090")
+	conf.AnnounceRateLimit = 1
+	lc, err := NewLocator(context.Background(), conf, key)
+	require.NoError(t, err)
+
+	ctx := locatortest.ContextWithWallet(util.PubKeyToAddr(key.PublicKey))
+
+	_, err = lc.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}})
+	require.NoError(t, err)
+
+	_, err = lc.Announce(ctx, &pb.AnnounceRequest{IpAddr: []string{"1.2.3.4:10002"}})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+}
+
+func TestLocator_Stats(t *testing.T) {
+	lc, err := NewLocator(context.Background(), DefaultConfig(":9090"), key)
+	require.NoError(t, err)
+
+	reply, err := lc.Stats(context.Background(), &pb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), reply.GetNodes())
+	assert.Nil(t, reply.GetOldestAnnounce())
+	assert.Nil(t, reply.GetNewestAnnounce())
+	assert.Equal(t, uint64(3600), reply.GetNodeTTLSeconds())
+
+	oldest := common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	lc.putAnnounce(&node{ethAddr: oldest})
+	lc.putAnnounce(&node{ethAddr: common.HexToAddress("0xB8ae39b3B6bb2F6A5b5f6BC1B8B4b1E7C7a2d4e5")})
+	lc.putAnnounce(&node{ethAddr: common.HexToAddress("0x1E476b24eAA3D42d5A2bA6Df440D7A9e555F7Cd1")})
+	lc.db[oldest].ts = time.Now().Add(-10 * time.Minute)
+
+	reply, err = lc.Stats(context.Background(), &pb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), reply.GetNodes())
+	assert.Equal(t, lc.db[oldest].ts.Unix(), reply.GetOldestAnnounce().GetSeconds())
+	assert.InDelta(t, time.Now().Unix(), reply.GetNewestAnnounce().GetSeconds(), 2)
+}
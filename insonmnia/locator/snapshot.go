@@ -0,0 +1,223 @@
+package locator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// corruptSnapshotSuffix is appended to the name of a snapshot the primary
+// has failed to load, so it is kept for investigation.
+const corruptSnapshotSuffix = ".corrupt"
+
+var (
+	errReadOnly                = status.Error(codes.FailedPrecondition, "locator is a read-only replica")
+	errReadOnlyWithoutSnapshot = errors.New("read-only mode requires a snapshot path")
+)
+
+// nodeSnapshot is a node db entry as stored in a snapshot.
+type nodeSnapshot struct {
+	EthAddr         string    `json:"eth_addr"`
+	IPAddr          []string  `json:"ip_addr"`
+	Tags            []string  `json:"tags,omitempty"`
+	ProtocolVersion uint32    `json:"protocol_version,omitempty"`
+	Timestamp       time.Time `json:"ts"`
+	// TTL is the TTL announced by the node, zero for the default one.
+	TTL time.Duration `json:"ttl,omitempty"`
+	// Reachable keeps reachability reports, so replicas order IPs the same
+	// way as the primary does.
+	Reachable map[string]time.Time `json:"reachable,omitempty"`
+}
+
+// dbSnapshot is the node db saved by the primary locator for its
+// read-only replicas.
+type dbSnapshot struct {
+	Nodes []*nodeSnapshot `json:"nodes"`
+}
+
+// saveSnapshot atomically replaces the snapshot at the given path with
+// the current node db.
+func (l *Locator) saveSnapshot(path string) error {
+	l.mx.Lock()
+	snapshot := &dbSnapshot{Nodes: make([]*nodeSnapshot, 0, len(l.db))}
+	for addr, n := range l.db {
+		tags := make([]string, 0, len(n.tags))
+		for tag := range n.tags {
+			tags = append(tags, tag)
+		}
+
+		snapshot.Nodes = append(snapshot.Nodes, &nodeSnapshot{
+			EthAddr:         addr.Hex(),
+			IPAddr:          n.ipAddr,
+			Tags:            tags,
+			ProtocolVersion: n.protocolVersion,
+			Timestamp:       n.ts,
+			TTL:             n.ttl,
+			Reachable:       n.reachable,
+		})
+	}
+	l.mx.Unlock()
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// loadSnapshot replaces the node db with the snapshot at the given path.
+// Nodes expired since the snapshot has been saved are skipped.
+//
+// Restored addresses are validated and limited the same way as announced
+// ones, because the snapshot may have been saved with other settings.
+// Nodes that would be rejected by strict limiting are skipped.
+func (l *Locator) loadSnapshot(path string) (err error) {
+	defer func() {
+		if err != nil && !os.IsNotExist(err) {
+			l.metrics.snapshotLoadFailures.Inc(1)
+		}
+	}()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	snapshot := &dbSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return errors.Wrap(err, "malformed snapshot")
+	}
+
+	db := make(map[common.Address]*node, len(snapshot.Nodes))
+	for _, n := range snapshot.Nodes {
+		if !common.IsHexAddress(n.EthAddr) {
+			return errors.Errorf("malformed snapshot: invalid eth address %q", n.EthAddr)
+		}
+
+		ethAddr := common.HexToAddress(n.EthAddr)
+		ipAddr, err := l.limitIPs(ethAddr, n.IPAddr)
+		if err != nil {
+			continue
+		}
+
+		restored := &node{
+			ethAddr:         ethAddr,
+			ipAddr:          ipAddr,
+			tags:            newTagSet(n.Tags),
+			protocolVersion: n.ProtocolVersion,
+			ts:              n.Timestamp,
+			ttl:             n.TTL,
+		}
+		restored.reachable = (&node{reachable: n.Reachable}).reachableFor(ipAddr)
+		// The maximum TTL may have been lowered since the snapshot has been
+		// saved.
+		if max := l.maxAnnounceTTL(); restored.ttl > max {
+			restored.ttl = max
+		}
+
+		if !l.expired(restored) {
+			db[ethAddr] = restored
+		}
+	}
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.db = db
+	l.byIP = newIPIndex(db)
+	l.dbPeak = len(db)
+	l.metrics.dbSize.Update(int64(len(db)))
+	l.health.setDBLoaded()
+
+	return nil
+}
+
+// restoreSnapshot loads the node db snapshot on start.
+//
+// The primary restores nodes announced before its restart, so they keep
+// being resolved until they announce again. Replicas start serving as soon
+// as possible, while the primary may not have saved the snapshot yet, so
+// this is not fatal for either of them. Health checks report replicas as not
+// serving until the snapshot has been loaded though.
+//
+// The primary is the only one saving the snapshot, so it starts with an
+// empty node db if there is no snapshot. A snapshot it fails to load is
+// moved aside first, otherwise the next save would overwrite it, losing all
+// the nodes for good. The failure is counted in metrics.
+func (l *Locator) restoreSnapshot() error {
+	path := l.conf.SnapshotPath
+
+	err := l.loadSnapshot(path)
+	switch {
+	case err == nil:
+		return nil
+	case os.IsNotExist(err) && !l.conf.ReadOnly:
+		l.health.setDBLoaded()
+		return nil
+	}
+
+	log.G(l.ctx).Warn("cannot load node db snapshot", zap.String("path", path), zap.Error(err))
+	if l.conf.ReadOnly {
+		return nil
+	}
+
+	if err := os.Rename(path, path+corruptSnapshotSuffix); err != nil {
+		return errors.Wrap(err, "cannot move the corrupt node db snapshot aside")
+	}
+
+	log.G(l.ctx).Warn("starting with an empty node db", zap.String("moved_to", path+corruptSnapshotSuffix))
+	l.health.setDBLoaded()
+
+	return nil
+}
+
+// syncSnapshot saves the node db on the primary and loads it on
+// read-only replicas.
+func (l *Locator) syncSnapshot() error {
+	if l.conf.ReadOnly {
+		return l.loadSnapshot(l.conf.SnapshotPath)
+	}
+
+	return l.saveSnapshot(l.conf.SnapshotPath)
+}
+
+func (l *Locator) syncSnapshots() {
+	t := time.NewTicker(l.conf.SnapshotPeriod)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			if err := l.syncSnapshot(); err != nil {
+				log.G(l.ctx).Warn("cannot sync node db snapshot",
+					zap.String("path", l.conf.SnapshotPath), zap.Bool("read_only", l.conf.ReadOnly), zap.Error(err))
+			}
+		case <-l.ctx.Done():
+			return
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
 	"github.com/sonm-io/core/insonmnia/hardware"
+	"github.com/sonm-io/core/insonmnia/hardware/gpu"
 	"github.com/sonm-io/core/insonmnia/resource"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
@@ -74,8 +75,11 @@ func (b *MinerBuilder) Build() (miner *Miner, err error) {
 
 	log.G(b.ctx).Debug("building a miner", zap.Any("config", b.cfg))
 
+	// GPU telemetry makes sense only for the real hardware.
+	var gpus *gpu.CachedEnumerator
 	if b.hardware == nil {
 		b.hardware = hardware.New()
+		gpus = gpu.NewCachedEnumerator(gpuTelemetryTTL)
 	}
 
 	publicIPs, err := b.getPublicIPs()
@@ -154,6 +158,7 @@ func (b *MinerBuilder) Build() (miner *Miner, err error) {
 		name:      b.uuid,
 		hardware:  hardwareInfo,
 		resources: resource.NewPool(hardwareInfo),
+		gpus:      gpus,
 
 		publicIPs:  publicIPs,
 		natType:    b.nat,
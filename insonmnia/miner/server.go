@@ -29,10 +29,15 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/gliderlabs/ssh"
 	"github.com/sonm-io/core/insonmnia/hardware"
+	"github.com/sonm-io/core/insonmnia/hardware/gpu"
 	"github.com/sonm-io/core/insonmnia/resource"
 	"github.com/sonm-io/core/insonmnia/structs"
 )
 
+// gpuTelemetryTTL is how long the number of alive GPUs is cached, because
+// detecting them is expensive while Info may be called often.
+const gpuTelemetryTTL = time.Minute
+
 // Miner holds information about jobs, make orders to Observer and communicates with Hub
 type Miner struct {
 	ctx        context.Context
@@ -43,6 +48,9 @@ type Miner struct {
 	name      string
 	hardware  *hardware.Hardware
 	resources *resource.Pool
+	// gpus detects GPUs again to report how many of them are alive, nil
+	// when the hardware is not detected for real.
+	gpus *gpu.CachedEnumerator
 
 	hubAddress string
 	hubKey     *ecdsa.PublicKey
@@ -191,6 +199,15 @@ func (m *Miner) Info(ctx context.Context, request *pb.Empty) (*pb.InfoReply, err
 		}
 	}
 
+	if m.gpus != nil {
+		devices, err := m.gpus.Devices()
+		if err != nil {
+			log.G(m.ctx).Warn("cannot detect GPUs for telemetry", zap.Error(err))
+		} else {
+			result.GpuTelemetry = &pb.GPUTelemetry{ReportingCount: uint32(len(devices))}
+		}
+	}
+
 	return result, nil
 }
 
@@ -2,12 +2,17 @@ package node
 
 import (
 	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/blockchain"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+var errDealNotFound = status.Error(codes.NotFound, "deal not found")
+
 type dealsAPI struct {
 	ctx     context.Context
 	remotes *remoteOptions
@@ -15,6 +20,16 @@ type dealsAPI struct {
 
 func (d *dealsAPI) List(ctx context.Context, req *pb.DealListRequest) (*pb.DealListReply, error) {
 	log.G(d.ctx).Info("handling Deals_List request", zap.Any("req", req))
+	// no owner given, so show deals where we are either buyer or supplier
+	if req.GetOwner() == "" {
+		deals, err := blockchain.GetMyDeals(d.remotes.eth, req.GetStatus(), d.ownAddr())
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.DealListReply{Deal: deals}, nil
+	}
+
 	IDs, err := d.remotes.eth.GetDeals(req.Owner)
 	if err != nil {
 		return nil, err
@@ -63,6 +78,23 @@ func (d *dealsAPI) Finish(ctx context.Context, id *pb.ID) (*pb.Empty, error) {
 	return &pb.Empty{}, nil
 }
 
+func (d *dealsAPI) FindBySpecHash(ctx context.Context, req *pb.DealFindRequest) (*pb.Deal, error) {
+	log.G(d.ctx).Info("handling Deals_FindBySpecHash request", zap.Any("req", req))
+	deal, err := blockchain.FindDealBySpecHash(d.remotes.eth, []string{d.ownAddr()}, req.GetCounterparty(), req.GetSpecHash())
+	if err != nil {
+		return nil, err
+	}
+	if deal == nil {
+		return nil, errDealNotFound
+	}
+
+	return deal, nil
+}
+
+func (d *dealsAPI) ownAddr() string {
+	return util.PubKeyToAddr(d.remotes.key.PublicKey).Hex()
+}
+
 func newDealsAPI(opts *remoteOptions) (pb.DealManagementServer, error) {
 	return &dealsAPI{
 		remotes: opts,
@@ -48,6 +48,13 @@ var statusMap = map[uint8]string{
 	statusFailed:         "Failed",
 }
 
+// HandlerStatusDone and HandlerStatusFailed are final statuses of order
+// processing as reported by GetProcessing.
+const (
+	HandlerStatusDone   = uint32(statusDone)
+	HandlerStatusFailed = uint32(statusFailed)
+)
+
 func HandlerStatusString(status uint8) string {
 	s, ok := statusMap[status]
 	if !ok {
@@ -508,6 +515,7 @@ func (m *marketAPI) GetProcessing(ctx context.Context, req *pb.Empty) (*pb.GetPr
 			Status:    uint32(task.status),
 			Timestamp: &pb.Timestamp{Seconds: task.ts.Unix()},
 			Extra:     extra,
+			DealID:    task.dealID,
 		}
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	log "github.com/noxiouz/zapctx/ctxlog"
 	"github.com/sonm-io/core/blockchain"
+	"github.com/sonm-io/core/insonmnia/locator"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
@@ -60,7 +61,7 @@ func newRemoteOptions(ctx context.Context, key *ecdsa.PrivateKey, conf Config, c
 		conf:           conf,
 		ctx:            ctx,
 		creds:          creds,
-		locator:        pb.NewLocatorClient(locatorCC),
+		locator:        locator.NewClient(pb.NewLocatorClient(locatorCC)),
 		market:         pb.NewMarketClient(marketCC),
 		eth:            bcAPI,
 		approveTimeout: 900 * time.Second,
@@ -45,6 +45,7 @@ It has these top-level messages:
 	PullTaskRequest
 	DealInfoReply
 	CompletedTask
+	RegisteredWorker
 	Empty
 	ID
 	TaskID
@@ -65,9 +66,24 @@ It has these top-level messages:
 	Timestamp
 	Chunk
 	Progress
+	GPUTelemetry
 	AnnounceRequest
 	ResolveRequest
 	ResolveReply
+	SignedAnnounce
+	AnnounceBatchRequest
+	AnnounceResult
+	AnnounceBatchReply
+	ResolvePrefixRequest
+	ResolvedNode
+	ResolvePrefixReply
+	ResolveBatchRequest
+	ResolveBatchResult
+	ResolveBatchReply
+	ReverseResolveRequest
+	ReverseResolveReply
+	ReportReachableRequest
+	LocatorStatsReply
 	GetOrdersRequest
 	GetOrdersReply
 	GetProcessingReply
@@ -83,6 +99,7 @@ It has these top-level messages:
 	TaskListRequest
 	DealListRequest
 	DealListReply
+	DealFindRequest
 */
 package sonm
 
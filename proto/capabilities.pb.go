@@ -173,6 +173,16 @@ type GPUDevice struct {
 	OpenCLDeviceVersionMajor int32 `protobuf:"varint,6,opt,name=openCLDeviceVersionMajor" json:"openCLDeviceVersionMajor,omitempty"`
 	// OpenCL minor version.
 	OpenCLDeviceVersionMinor int32 `protobuf:"varint,7,opt,name=openCLDeviceVersionMinor" json:"openCLDeviceVersionMinor,omitempty"`
+	// Device type as the OpenCL CL_DEVICE_TYPE bitfield, zero if unknown.
+	DeviceType uint64 `protobuf:"varint,8,opt,name=deviceType" json:"deviceType,omitempty"`
+	// PCIe generation of the link the device is attached to, zero if unknown.
+	PcieGeneration uint32 `protobuf:"varint,9,opt,name=pcieGeneration" json:"pcieGeneration,omitempty"`
+	// PCIe link width in lanes, zero if unknown.
+	PcieLinkWidth uint32 `protobuf:"varint,10,opt,name=pcieLinkWidth" json:"pcieLinkWidth,omitempty"`
+	// GPU utilization in percent at the moment of detection, -1 if unknown.
+	UtilizationPercent int32 `protobuf:"varint,11,opt,name=utilizationPercent" json:"utilizationPercent,omitempty"`
+	// GPU temperature in Celsius at the moment of detection, -1 if unknown.
+	TemperatureCelsius int32 `protobuf:"varint,12,opt,name=temperatureCelsius" json:"temperatureCelsius,omitempty"`
 }
 
 func (m *GPUDevice) Reset()                    { *m = GPUDevice{} }
@@ -229,6 +239,41 @@ func (m *GPUDevice) GetOpenCLDeviceVersionMinor() int32 {
 	return 0
 }
 
+func (m *GPUDevice) GetDeviceType() uint64 {
+	if m != nil {
+		return m.DeviceType
+	}
+	return 0
+}
+
+func (m *GPUDevice) GetPcieGeneration() uint32 {
+	if m != nil {
+		return m.PcieGeneration
+	}
+	return 0
+}
+
+func (m *GPUDevice) GetPcieLinkWidth() uint32 {
+	if m != nil {
+		return m.PcieLinkWidth
+	}
+	return 0
+}
+
+func (m *GPUDevice) GetUtilizationPercent() int32 {
+	if m != nil {
+		return m.UtilizationPercent
+	}
+	return 0
+}
+
+func (m *GPUDevice) GetTemperatureCelsius() int32 {
+	if m != nil {
+		return m.TemperatureCelsius
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Capabilities)(nil), "sonm.Capabilities")
 	proto.RegisterType((*CPUDevice)(nil), "sonm.CPUDevice")
@@ -239,30 +284,35 @@ func init() {
 func init() { proto.RegisterFile("capabilities.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 396 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x4d, 0x8b, 0xd4, 0x40,
-	0x10, 0xa5, 0x37, 0xc9, 0xba, 0x29, 0xbf, 0x1b, 0x0f, 0x8d, 0x88, 0xc4, 0x41, 0x64, 0x0e, 0x32,
-	0x07, 0xc5, 0x8b, 0x37, 0x89, 0x28, 0x82, 0x23, 0xd2, 0xa2, 0xf7, 0xde, 0x9e, 0x32, 0xb6, 0xa6,
-	0x3f, 0xec, 0x24, 0xeb, 0xae, 0x7f, 0xc8, 0x1f, 0xe9, 0x45, 0xba, 0xa2, 0x49, 0xd6, 0x61, 0x6e,
-	0xf5, 0xea, 0xbd, 0x7e, 0x55, 0xf5, 0x12, 0xe0, 0x5a, 0x05, 0x75, 0x6a, 0x5a, 0xd3, 0x1b, 0xec,
-	0x36, 0x21, 0xfa, 0xde, 0xf3, 0xbc, 0xf3, 0xce, 0xae, 0x7e, 0xc0, 0xb5, 0x7a, 0xc1, 0xf1, 0x07,
-	0x90, 0xe9, 0x30, 0x08, 0x56, 0x65, 0xeb, 0xab, 0x4f, 0x6e, 0x6e, 0x92, 0x66, 0x53, 0xbf, 0xff,
-	0xf8, 0x12, 0xcf, 0x8c, 0x46, 0x99, 0xb8, 0x24, 0xb1, 0x68, 0xc5, 0x51, 0xc5, 0x66, 0x89, 0x7c,
-	0xb1, 0xfd, 0x27, 0xb1, 0x68, 0x93, 0xa4, 0x09, 0x83, 0xc8, 0x96, 0x2e, 0xaf, 0x67, 0x97, 0x26,
-	0x0c, 0xab, 0xdf, 0x0c, 0xca, 0xc9, 0x98, 0xdf, 0x82, 0xcc, 0x0d, 0x56, 0xb0, 0x8a, 0xad, 0x0b,
-	0x99, 0x4a, 0x7e, 0x17, 0x4e, 0xce, 0xd0, 0xed, 0x7c, 0x7c, 0xb3, 0xa3, 0x51, 0xa5, 0x9c, 0x30,
-	0xbf, 0x03, 0x85, 0xf5, 0x3b, 0x6c, 0x45, 0x46, 0xc4, 0x08, 0xf8, 0x3d, 0x28, 0xa9, 0x78, 0xa7,
-	0x2c, 0x8a, 0x9c, 0x98, 0xb9, 0x91, 0xde, 0x68, 0x1f, 0xb1, 0x13, 0x05, 0xcd, 0x18, 0x01, 0x7f,
-	0x04, 0x37, 0x74, 0xeb, 0xf5, 0xb7, 0x57, 0x11, 0xbf, 0x0f, 0xe8, 0xf4, 0x85, 0x38, 0xae, 0xd8,
-	0x9a, 0xc9, 0xff, 0xba, 0xc9, 0x5b, 0x2b, 0xfd, 0x05, 0x3f, 0x98, 0x9f, 0x28, 0xae, 0x90, 0xc3,
-	0xdc, 0x48, 0xbb, 0x76, 0x3d, 0x86, 0x60, 0x5c, 0x23, 0x4e, 0x88, 0x9c, 0x70, 0x9a, 0xfb, 0xb9,
-	0x55, 0x4d, 0x27, 0xca, 0x2a, 0x4b, 0xbb, 0x12, 0x58, 0x3d, 0x83, 0x72, 0x8a, 0x2c, 0x49, 0x7a,
-	0xdf, 0xab, 0x96, 0xce, 0xcf, 0xe5, 0x08, 0x38, 0x87, 0x7c, 0xe8, 0x70, 0x3c, 0x3e, 0x97, 0x54,
-	0xaf, 0x7e, 0x1d, 0x41, 0x39, 0xe5, 0x98, 0x14, 0x2e, 0xdd, 0xca, 0xe8, 0x56, 0xaa, 0xf7, 0x62,
-	0xcb, 0x17, 0xb1, 0xdd, 0x07, 0x18, 0x6b, 0x4a, 0x68, 0xcc, 0x6e, 0xd1, 0xe1, 0x0f, 0xe1, 0xba,
-	0x55, 0xe7, 0x5b, 0xb4, 0x3e, 0x5e, 0xd0, 0xa1, 0x39, 0x19, 0x5c, 0x6e, 0xf2, 0xc7, 0x70, 0xdb,
-	0xaa, 0xf3, 0xfa, 0x72, 0x6a, 0x05, 0x29, 0xf7, 0x09, 0xfe, 0x1c, 0x84, 0x0f, 0xe8, 0xea, 0xb7,
-	0xe3, 0xce, 0x9f, 0x30, 0x76, 0xc6, 0xbb, 0xad, 0xfa, 0xea, 0x23, 0x45, 0x5d, 0xc8, 0x83, 0xfc,
-	0xa1, 0xb7, 0xc6, 0xf9, 0xf8, 0xf7, 0x1b, 0x1c, 0xe4, 0x4f, 0x8f, 0xe9, 0x27, 0x7f, 0xfa, 0x27,
-	0x00, 0x00, 0xff, 0xff, 0xba, 0x2c, 0xe9, 0x3a, 0xfa, 0x02, 0x00, 0x00,
+	// 480 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x4b, 0x6f, 0x13, 0x31,
+	0x10, 0xd6, 0xb2, 0x9b, 0xd2, 0x75, 0x5b, 0x1e, 0x16, 0x07, 0x0b, 0x21, 0xb4, 0x44, 0x08, 0xe5,
+	0x80, 0x72, 0x00, 0x71, 0xe1, 0x86, 0x82, 0xa8, 0x90, 0x1a, 0x54, 0x99, 0xd7, 0xd9, 0x75, 0x86,
+	0xd4, 0x74, 0xfd, 0xc0, 0xeb, 0x2d, 0x4d, 0xff, 0x37, 0x27, 0x2e, 0x68, 0x66, 0x61, 0xb3, 0x69,
+	0xc8, 0x6d, 0xe6, 0xfb, 0x3e, 0xcf, 0xe3, 0x1b, 0x99, 0x71, 0xad, 0x82, 0x3a, 0x33, 0xb5, 0x49,
+	0x06, 0x9a, 0x69, 0x88, 0x3e, 0x79, 0x5e, 0x34, 0xde, 0xd9, 0xf1, 0x4f, 0x76, 0x38, 0x1b, 0x70,
+	0xfc, 0x09, 0xcb, 0x75, 0x68, 0x45, 0x56, 0xe5, 0x93, 0x83, 0x17, 0x77, 0xa7, 0xa8, 0x99, 0xce,
+	0x4e, 0x3f, 0xbf, 0x85, 0x4b, 0xa3, 0x41, 0x22, 0x87, 0x12, 0x0b, 0x56, 0xdc, 0xaa, 0xb2, 0xb5,
+	0x44, 0xbe, 0x99, 0xff, 0x93, 0x58, 0xb0, 0x28, 0x59, 0x86, 0x56, 0xe4, 0xc3, 0x2a, 0xc7, 0xeb,
+	0x2a, 0xcb, 0xd0, 0x8e, 0x7f, 0x67, 0xac, 0xec, 0x0b, 0xf3, 0x7b, 0x2c, 0x77, 0xad, 0x15, 0x59,
+	0x95, 0x4d, 0x46, 0x12, 0x43, 0xfe, 0x90, 0xed, 0x5f, 0x82, 0x5b, 0xf8, 0xf8, 0x7e, 0x41, 0xad,
+	0x4a, 0xd9, 0xe7, 0xfc, 0x01, 0x1b, 0x59, 0xbf, 0x80, 0x5a, 0xe4, 0x44, 0x74, 0x09, 0x7f, 0xc4,
+	0x4a, 0x0a, 0x3e, 0x28, 0x0b, 0xa2, 0x20, 0x66, 0x0d, 0xe0, 0x1b, 0xed, 0x23, 0x34, 0x62, 0x44,
+	0x3d, 0xba, 0x84, 0x3f, 0x63, 0x77, 0x74, 0xed, 0xf5, 0xc5, 0xbb, 0x08, 0x3f, 0x5a, 0x70, 0x7a,
+	0x25, 0xf6, 0xaa, 0x6c, 0x92, 0xc9, 0x1b, 0x28, 0xd6, 0xd6, 0x4a, 0x9f, 0xc3, 0x47, 0x73, 0x0d,
+	0xe2, 0x36, 0x55, 0x58, 0x03, 0x38, 0x6b, 0x93, 0x20, 0x04, 0xe3, 0x96, 0x62, 0x9f, 0xc8, 0x3e,
+	0xc7, 0xbe, 0xdf, 0x6a, 0xb5, 0x6c, 0x44, 0x59, 0xe5, 0x38, 0x2b, 0x25, 0xe3, 0x57, 0xac, 0xec,
+	0x2d, 0x43, 0x49, 0xf2, 0x49, 0xd5, 0xb4, 0x7e, 0x21, 0xbb, 0x84, 0x73, 0x56, 0xb4, 0x0d, 0x74,
+	0xcb, 0x17, 0x92, 0xe2, 0xf1, 0xaf, 0x9c, 0x95, 0xbd, 0x8f, 0xa8, 0x70, 0xb8, 0x6b, 0x46, 0xbb,
+	0x52, 0xbc, 0x65, 0x5b, 0x31, 0xb0, 0xed, 0x31, 0x63, 0x5d, 0x4c, 0x0e, 0x75, 0xde, 0x0d, 0x10,
+	0xfe, 0x94, 0x1d, 0x59, 0x75, 0x35, 0x07, 0xeb, 0xe3, 0x8a, 0x16, 0x2d, 0xa8, 0xc0, 0x26, 0xc8,
+	0x9f, 0xb3, 0xfb, 0x56, 0x5d, 0xcd, 0x36, 0x5d, 0x1b, 0x91, 0x72, 0x9b, 0xe0, 0xaf, 0x99, 0xf0,
+	0x01, 0xdc, 0xec, 0xa4, 0x9b, 0xf9, 0x0b, 0xc4, 0xc6, 0x78, 0x37, 0x57, 0xdf, 0x7d, 0x24, 0xab,
+	0x47, 0x72, 0x27, 0xbf, 0xeb, 0xad, 0x71, 0x3e, 0xfe, 0xbd, 0xc1, 0x4e, 0x1e, 0x77, 0x5d, 0x10,
+	0xfa, 0x69, 0x15, 0x80, 0x8e, 0x52, 0xc8, 0x01, 0x82, 0x87, 0x0f, 0xda, 0xc0, 0x31, 0x38, 0x88,
+	0x2a, 0x19, 0xef, 0x44, 0x59, 0x65, 0x93, 0x23, 0x79, 0x03, 0x45, 0x4f, 0x10, 0x39, 0x31, 0xee,
+	0xe2, 0xab, 0x59, 0xa4, 0x73, 0xc1, 0x48, 0xb6, 0x09, 0xf2, 0x29, 0xe3, 0x6d, 0x32, 0xb5, 0xb9,
+	0xa6, 0x47, 0xa7, 0x10, 0x35, 0xb8, 0x24, 0x0e, 0x68, 0xc6, 0xff, 0x30, 0xa8, 0x4f, 0x60, 0x03,
+	0x36, 0x69, 0x23, 0xcc, 0xa0, 0x6e, 0x4c, 0xdb, 0x88, 0xc3, 0x4e, 0xbf, 0xcd, 0x9c, 0xed, 0xd1,
+	0x97, 0x7d, 0xf9, 0x27, 0x00, 0x00, 0xff, 0xff, 0x1b, 0xdc, 0x31, 0x06, 0xc8, 0x03, 0x00, 0x00,
 }
@@ -150,10 +150,11 @@ func (m *HubStartTaskReply) GetHubAddr() string {
 
 type HubStatusReply struct {
 	MinerCount uint64 `protobuf:"varint,1,opt,name=minerCount" json:"minerCount,omitempty"`
-	Uptime     uint64 `protobuf:"varint,2,opt,name=uptime" json:"uptime,omitempty"`
-	Version    string `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
-	Platform   string `protobuf:"bytes,4,opt,name=platform" json:"platform,omitempty"`
-	EthAddr    string `protobuf:"bytes,5,opt,name=ethAddr" json:"ethAddr,omitempty"`
+	// Uptime in nanoseconds, the same as task uptime.
+	Uptime   uint64 `protobuf:"varint,2,opt,name=uptime" json:"uptime,omitempty"`
+	Version  string `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	Platform string `protobuf:"bytes,4,opt,name=platform" json:"platform,omitempty"`
+	EthAddr  string `protobuf:"bytes,5,opt,name=ethAddr" json:"ethAddr,omitempty"`
 }
 
 func (m *HubStatusReply) Reset()                    { *m = HubStatusReply{} }
@@ -378,6 +379,8 @@ func (m *RemoveSlotRequest) GetSlot() *Slot {
 
 type GetRegisteredWorkersReply struct {
 	Ids []*ID `protobuf:"bytes,1,rep,name=ids" json:"ids,omitempty"`
+	// Detailed info about workers, in the same order as ids.
+	Workers []*RegisteredWorker `protobuf:"bytes,2,rep,name=workers" json:"workers,omitempty"`
 }
 
 func (m *GetRegisteredWorkersReply) Reset()                    { *m = GetRegisteredWorkersReply{} }
@@ -392,6 +395,13 @@ func (m *GetRegisteredWorkersReply) GetIds() []*ID {
 	return nil
 }
 
+func (m *GetRegisteredWorkersReply) GetWorkers() []*RegisteredWorker {
+	if m != nil {
+		return m.Workers
+	}
+	return nil
+}
+
 type TaskListReply struct {
 	Info map[string]*TaskListReply_TaskInfo `protobuf:"bytes,1,rep,name=info" json:"info,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -626,6 +636,31 @@ func (m *CompletedTask) GetEndTime() *Timestamp {
 	return nil
 }
 
+type RegisteredWorker struct {
+	Id *ID `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Time the worker has been registered at, if known by the hub.
+	RegisteredAt *Timestamp `protobuf:"bytes,2,opt,name=registeredAt" json:"registeredAt,omitempty"`
+}
+
+func (m *RegisteredWorker) Reset()                    { *m = RegisteredWorker{} }
+func (m *RegisteredWorker) String() string            { return proto.CompactTextString(m) }
+func (*RegisteredWorker) ProtoMessage()               {}
+func (*RegisteredWorker) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{20} }
+
+func (m *RegisteredWorker) GetId() *ID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RegisteredWorker) GetRegisteredAt() *Timestamp {
+	if m != nil {
+		return m.RegisteredAt
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ListReply)(nil), "sonm.ListReply")
 	proto.RegisterType((*ListReply_ListValue)(nil), "sonm.ListReply.ListValue")
@@ -650,6 +685,7 @@ func init() {
 	proto.RegisterType((*PullTaskRequest)(nil), "sonm.PullTaskRequest")
 	proto.RegisterType((*DealInfoReply)(nil), "sonm.DealInfoReply")
 	proto.RegisterType((*CompletedTask)(nil), "sonm.CompletedTask")
+	proto.RegisterType((*RegisteredWorker)(nil), "sonm.RegisteredWorker")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1662,96 +1698,99 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("hub.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 1452 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x72, 0xdb, 0xc4,
-	0x17, 0xb7, 0x64, 0x3b, 0xb1, 0x8f, 0x13, 0x27, 0x59, 0xe7, 0xdf, 0xaa, 0xfa, 0x97, 0x92, 0x6e,
-	0x81, 0xa6, 0xb4, 0x75, 0xd3, 0x00, 0x2d, 0x53, 0xa6, 0x33, 0x64, 0xe2, 0xd6, 0xf5, 0xd0, 0x52,
-	0x8f, 0xd2, 0xc2, 0xf4, 0x52, 0x8e, 0xb6, 0x89, 0x88, 0x2c, 0x09, 0x69, 0xe5, 0x21, 0x0f, 0xc0,
-	0x2d, 0xd7, 0x0c, 0x6f, 0xc0, 0x75, 0x67, 0xe0, 0x8a, 0x97, 0xe0, 0x29, 0x78, 0x0c, 0x66, 0xbf,
-	0xa4, 0x95, 0x23, 0xa7, 0x30, 0x1d, 0xee, 0x74, 0xce, 0x9e, 0x8f, 0xdf, 0xf9, 0xd8, 0xb3, 0xc7,
-	0x86, 0xf6, 0x71, 0x36, 0xe9, 0xc7, 0x49, 0x44, 0x23, 0xd4, 0x48, 0xa3, 0x70, 0x6a, 0xb7, 0x27,
-	0xbe, 0x27, 0x18, 0x36, 0x3a, 0x74, 0x63, 0x77, 0xe2, 0x07, 0x3e, 0xf5, 0x49, 0x2a, 0x79, 0xe0,
-	0x11, 0x37, 0x90, 0xdf, 0x6b, 0x7e, 0xc8, 0x54, 0x42, 0xdf, 0x15, 0x0c, 0xfc, 0xc6, 0x80, 0xf6,
-	0x53, 0x3f, 0xa5, 0x0e, 0x89, 0x83, 0x53, 0x74, 0x1b, 0x1a, 0x7e, 0xf8, 0x3a, 0xb2, 0x8c, 0xad,
-	0xfa, 0x76, 0x67, 0xf7, 0x52, 0x9f, 0xc9, 0xf6, 0xf3, 0xe3, 0xfe, 0x28, 0x7c, 0x1d, 0x3d, 0x0a,
-	0x69, 0x72, 0xea, 0x70, 0x31, 0xfb, 0x9a, 0xd0, 0xfd, 0xc6, 0x0d, 0x32, 0x82, 0x2e, 0xc0, 0xd2,
-	0x8c, 0x7d, 0xa4, 0x5c, 0xbb, 0xed, 0x48, 0xca, 0x76, 0xa0, 0x9d, 0xeb, 0xa1, 0x75, 0xa8, 0x9f,
-	0x90, 0x53, 0xcb, 0xd8, 0x32, 0xb6, 0xdb, 0x0e, 0xfb, 0x44, 0x77, 0xa0, 0xc9, 0x05, 0x2d, 0x73,
-	0xcb, 0xa8, 0xf2, 0x99, 0x3b, 0x70, 0x84, 0xdc, 0x03, 0xf3, 0x73, 0x03, 0xbf, 0x31, 0xa1, 0xf7,
-	0x24, 0x9b, 0x1c, 0x50, 0x37, 0xa1, 0x2f, 0xdc, 0xf4, 0xc4, 0x21, 0xdf, 0x67, 0x24, 0xa5, 0xe8,
-	0x0a, 0x34, 0x58, 0xb0, 0xdc, 0x7e, 0x67, 0x17, 0x84, 0xad, 0x01, 0x71, 0x03, 0x87, 0xf3, 0x91,
-	0x0d, 0xad, 0x84, 0x1c, 0xf9, 0x29, 0x4d, 0x4e, 0xb9, 0xbf, 0xb6, 0x93, 0xd3, 0x68, 0x13, 0x9a,
-	0xfe, 0xd4, 0x3d, 0x22, 0x56, 0x9d, 0x1f, 0x08, 0x02, 0x21, 0x68, 0xb8, 0x19, 0x3d, 0xb6, 0x1a,
-	0x9c, 0xc9, 0xbf, 0xd1, 0x07, 0xb0, 0x3a, 0xce, 0x26, 0x81, 0x7f, 0xf8, 0x15, 0x39, 0x1d, 0xb8,
-	0xd4, 0xb5, 0x9a, 0xfc, 0xb0, 0xcc, 0x44, 0x18, 0x56, 0x0e, 0xa3, 0xe9, 0xd4, 0xa7, 0xcf, 0xc3,
-	0x03, 0x1a, 0xc5, 0xd6, 0xd2, 0x96, 0xb1, 0xdd, 0x72, 0x4a, 0x3c, 0xf4, 0x29, 0xd4, 0x49, 0x38,
-	0xb3, 0x96, 0x79, 0xba, 0xb1, 0x80, 0x5b, 0x11, 0x57, 0xff, 0x51, 0x38, 0x13, 0x79, 0x67, 0xe2,
-	0xf6, 0x3d, 0x68, 0x29, 0x46, 0x45, 0x42, 0x37, 0xf5, 0x84, 0xb6, 0xf5, 0xac, 0xbd, 0x82, 0x8d,
-	0xb2, 0x71, 0x56, 0xf2, 0x2e, 0x98, 0xbe, 0x27, 0xf5, 0x4d, 0xdf, 0x63, 0x29, 0x22, 0xa1, 0x17,
-	0x47, 0x7e, 0x48, 0x2d, 0x93, 0x17, 0x32, 0xa7, 0x91, 0x05, 0xcb, 0xc7, 0xd9, 0x64, 0xcf, 0xf3,
-	0x12, 0x99, 0x24, 0x45, 0xe2, 0x9f, 0x0d, 0xe8, 0x0a, 0xdb, 0x34, 0x4b, 0x85, 0xe1, 0x2b, 0x00,
-	0x53, 0x3f, 0x24, 0xc9, 0x7e, 0x94, 0x85, 0x94, 0x3b, 0x68, 0x38, 0x1a, 0x87, 0xf5, 0x4b, 0x16,
-	0x53, 0x7f, 0x2a, 0x80, 0x36, 0x1c, 0x49, 0x31, 0x27, 0x33, 0x92, 0xa4, 0x7e, 0x14, 0x2a, 0x27,
-	0x92, 0x64, 0xd0, 0xe2, 0xc0, 0xa5, 0xaf, 0xa3, 0x64, 0x2a, 0xeb, 0x91, 0xd3, 0x4c, 0x8b, 0xd0,
-	0x63, 0x0e, 0x4d, 0x54, 0x43, 0x91, 0xf8, 0x07, 0xe8, 0xf0, 0x0e, 0x90, 0x2d, 0xb2, 0x09, 0xcd,
-	0x89, 0xef, 0x8d, 0x54, 0xc8, 0x82, 0x60, 0x5c, 0x37, 0x3d, 0x19, 0x79, 0x2a, 0x69, 0x9c, 0x40,
-	0x57, 0xa1, 0x19, 0x25, 0x1e, 0x11, 0xd1, 0x76, 0x76, 0x3b, 0xa2, 0x40, 0xcf, 0x19, 0xcb, 0x11,
-	0x27, 0x0c, 0x53, 0x1a, 0x93, 0xc3, 0x27, 0x6e, 0xaa, 0x7a, 0x24, 0xa7, 0xf1, 0xaf, 0x06, 0x58,
-	0x43, 0x42, 0x07, 0x64, 0xe6, 0x1f, 0x92, 0x71, 0x12, 0xc5, 0x24, 0x61, 0xd7, 0x52, 0xa4, 0xe7,
-	0x6b, 0x80, 0x38, 0x67, 0xc9, 0x0b, 0xd7, 0x17, 0x0e, 0x16, 0xe9, 0xf4, 0x0b, 0x5a, 0x74, 0x83,
-	0x66, 0xc1, 0x7e, 0x08, 0x6b, 0x73, 0xc7, 0x6f, 0xeb, 0x0d, 0x43, 0xef, 0x8d, 0x3f, 0x0c, 0xb0,
-	0x0f, 0xaa, 0xfc, 0x8a, 0xac, 0x75, 0xc1, 0x1c, 0x0d, 0x54, 0x97, 0x8c, 0x06, 0x68, 0x5c, 0x42,
-	0x6f, 0x72, 0xf4, 0x3b, 0x02, 0xfd, 0x62, 0x2b, 0xff, 0x25, 0xfe, 0x1f, 0x0d, 0x80, 0x83, 0x20,
-	0xa2, 0x32, 0xbb, 0x77, 0xa1, 0x99, 0x32, 0x4a, 0x26, 0xf6, 0xff, 0x12, 0x5a, 0x2e, 0x20, 0x3e,
-	0x05, 0x0a, 0x21, 0x69, 0x0f, 0xa4, 0x81, 0x45, 0xbe, 0xb7, 0xca, 0x83, 0x0a, 0x0a, 0x93, 0x3a,
-	0x8e, 0x3f, 0x0d, 0x58, 0x1f, 0x12, 0xba, 0x17, 0x04, 0x1a, 0x9a, 0xfb, 0x65, 0x34, 0x57, 0xf3,
-	0x32, 0x97, 0xc4, 0x2a, 0x30, 0x7d, 0x0c, 0x2d, 0xc6, 0x64, 0x33, 0x90, 0xcd, 0x36, 0xc6, 0x94,
-	0x36, 0x74, 0xf7, 0x9c, 0x6f, 0xbf, 0x7a, 0x0b, 0xfe, 0xcf, 0xca, 0xf8, 0xdf, 0x3f, 0x07, 0x04,
-	0x9f, 0xbe, 0x5a, 0x50, 0x5f, 0x42, 0x77, 0xcf, 0xf3, 0xb8, 0xaf, 0x05, 0xfd, 0xa0, 0xc0, 0x9d,
-	0xcd, 0x0d, 0xe7, 0xe3, 0x7d, 0xd8, 0x70, 0xc8, 0x34, 0x9a, 0x91, 0x77, 0x31, 0x72, 0x1f, 0x2e,
-	0x0d, 0x09, 0x75, 0xf8, 0xc0, 0x26, 0x09, 0xf1, 0xbe, 0x8d, 0x92, 0x13, 0x92, 0xc8, 0x1c, 0xdb,
-	0x50, 0xf7, 0x3d, 0x95, 0xe1, 0x96, 0xd0, 0x1d, 0x0d, 0x1c, 0xc6, 0xc4, 0xbf, 0x99, 0xb0, 0xca,
-	0x26, 0x5e, 0xf1, 0xd0, 0xdd, 0x2d, 0x3d, 0x74, 0xef, 0x09, 0xf1, 0x92, 0xc8, 0x99, 0xc7, 0xee,
-	0x17, 0x03, 0x5a, 0x4c, 0x82, 0xf1, 0xd1, 0x43, 0x68, 0x52, 0x37, 0x3d, 0x51, 0xfe, 0xae, 0x57,
-	0x19, 0x50, 0xc2, 0xfc, 0x43, 0xd5, 0x95, 0x6b, 0xd9, 0xcf, 0x01, 0x0a, 0x66, 0x45, 0xad, 0x6e,
-	0x96, 0x6b, 0xf5, 0xbf, 0xc2, 0xbc, 0x36, 0x61, 0xb5, 0x0a, 0xd9, 0x2f, 0xcf, 0x7f, 0x64, 0x77,
-	0xcb, 0xf6, 0x2e, 0x9f, 0x07, 0x57, 0x2f, 0xfc, 0x18, 0x56, 0xf7, 0xc7, 0x2f, 0xc5, 0x75, 0xe6,
-	0x71, 0x5f, 0x80, 0x25, 0x3e, 0xc2, 0xf3, 0x47, 0x5e, 0x50, 0xe8, 0x3a, 0x2c, 0x79, 0x5c, 0x4a,
-	0x7a, 0x58, 0x13, 0x1e, 0x72, 0x65, 0x47, 0x1e, 0x33, 0x8b, 0xc3, 0x77, 0xb1, 0x38, 0x3c, 0x63,
-	0xf1, 0x27, 0x13, 0x56, 0x04, 0x4b, 0x76, 0xc2, 0x0e, 0x34, 0xf6, 0xc7, 0x2f, 0x55, 0x69, 0x2e,
-	0xab, 0x25, 0xa0, 0x90, 0x60, 0xb0, 0x64, 0x3d, 0xb8, 0x24, 0xd3, 0x18, 0x32, 0x0d, 0x73, 0xa1,
-	0xc6, 0xb0, 0xd0, 0x60, 0x9f, 0xf6, 0x53, 0x68, 0xe7, 0x46, 0x2a, 0xf2, 0x7d, 0xa3, 0x9c, 0xef,
-	0xde, 0x5c, 0x36, 0xe6, 0xd2, 0xcc, 0xac, 0x0d, 0xff, 0xb5, 0xb5, 0xe1, 0x02, 0x6b, 0x78, 0x04,
-	0x1b, 0xa3, 0x30, 0x25, 0x09, 0xd5, 0xef, 0x5a, 0x31, 0x3d, 0x2a, 0xef, 0x16, 0x9b, 0xac, 0x71,
-	0xa2, 0xb2, 0xdd, 0x76, 0x04, 0x81, 0xf7, 0x60, 0x6d, 0x9c, 0x05, 0x81, 0xbe, 0x62, 0x5d, 0x60,
-	0x75, 0x71, 0x83, 0xfc, 0x01, 0x95, 0x14, 0xe3, 0x53, 0xfd, 0x09, 0x95, 0x14, 0xfe, 0xdd, 0x80,
-	0x55, 0xf6, 0xfe, 0x72, 0x94, 0xbc, 0x3e, 0x56, 0xbe, 0x71, 0xe8, 0x17, 0x95, 0xed, 0x1e, 0xf9,
-	0x7b, 0x6b, 0x2e, 0x7c, 0x6f, 0x6f, 0xc1, 0x0a, 0xbf, 0x42, 0x4e, 0x16, 0x86, 0x7e, 0x78, 0x64,
-	0xd5, 0xe7, 0xee, 0x7b, 0xe9, 0x14, 0x7d, 0x01, 0x5d, 0x4e, 0xef, 0x47, 0xd3, 0x38, 0x20, 0x94,
-	0x78, 0x56, 0x83, 0xcb, 0xab, 0x82, 0x28, 0x36, 0x0f, 0x70, 0x4e, 0x14, 0x7f, 0x07, 0xab, 0x25,
-	0x81, 0x73, 0x80, 0xe7, 0xbb, 0xa3, 0xa9, 0xef, 0x8e, 0x37, 0x60, 0x99, 0x84, 0xde, 0x0b, 0xb6,
-	0xe2, 0xd4, 0xf5, 0x1e, 0x66, 0x9c, 0x94, 0xba, 0xd3, 0xd8, 0x51, 0xe7, 0xbb, 0x7f, 0xb5, 0xa1,
-	0xfe, 0x24, 0x9b, 0xa0, 0x8f, 0xa0, 0x31, 0x66, 0xc0, 0x65, 0xe8, 0x8f, 0xa6, 0x31, 0x3d, 0xb5,
-	0xa5, 0x1a, 0x3b, 0xe0, 0x19, 0xc4, 0x35, 0x74, 0x1b, 0x96, 0xc4, 0x24, 0x28, 0x4b, 0x6e, 0xea,
-	0x2b, 0xa4, 0x9a, 0x13, 0xb8, 0xc6, 0xcc, 0xf2, 0x37, 0xa4, 0xca, 0x6c, 0x3e, 0x01, 0x70, 0x0d,
-	0x5d, 0x83, 0x06, 0xbf, 0x94, 0x79, 0x74, 0x4a, 0x28, 0xaf, 0x1e, 0xae, 0xa1, 0xbe, 0x98, 0x83,
-	0x67, 0x0d, 0xf6, 0x2a, 0xc6, 0x0a, 0xc7, 0xda, 0x1a, 0x67, 0xe9, 0x31, 0x4f, 0xa1, 0x94, 0xdf,
-	0x3f, 0xce, 0xc2, 0x13, 0xbb, 0x2b, 0xe3, 0x4a, 0xa2, 0xa3, 0x84, 0xa4, 0x29, 0xae, 0x6d, 0x1b,
-	0x3b, 0x06, 0xda, 0x65, 0xe2, 0xa2, 0xe7, 0x90, 0x1c, 0x7c, 0x73, 0x3d, 0x68, 0xeb, 0x56, 0x70,
-	0x6d, 0xc7, 0x40, 0x7b, 0xd0, 0xce, 0xd7, 0x5a, 0x74, 0x69, 0xe1, 0x1e, 0x6d, 0x5f, 0xac, 0x3a,
-	0x52, 0xa1, 0xb7, 0xd8, 0x4a, 0xce, 0x2d, 0x14, 0xe1, 0xeb, 0xf1, 0xe1, 0x1a, 0xba, 0x23, 0xe6,
-	0xb6, 0x4c, 0x7d, 0x21, 0x56, 0x3d, 0xa0, 0xb9, 0x42, 0xe7, 0x19, 0x9b, 0x67, 0x67, 0x34, 0x64,
-	0xa5, 0x04, 0xff, 0x99, 0x1b, 0x2b, 0x85, 0x07, 0x32, 0xb9, 0xd1, 0x51, 0x8a, 0x34, 0xab, 0x8c,
-	0x56, 0x41, 0xf4, 0xca, 0xec, 0x22, 0x0b, 0x77, 0xa0, 0xc3, 0x56, 0xa8, 0x28, 0x25, 0xec, 0xc2,
-	0xa1, 0x0d, 0xed, 0xe7, 0x4f, 0x39, 0x71, 0x2a, 0x9c, 0x3e, 0x74, 0xf8, 0xae, 0x29, 0x6e, 0xa7,
-	0x86, 0xae, 0x57, 0xa8, 0xea, 0x95, 0xbf, 0x07, 0x9d, 0x81, 0x9f, 0x1e, 0x46, 0x33, 0x92, 0xb0,
-	0x66, 0xb5, 0xa4, 0x54, 0xc1, 0x5a, 0xe0, 0xe7, 0x16, 0x2c, 0xcb, 0x69, 0x5a, 0x6e, 0x18, 0x74,
-	0x76, 0xd2, 0x72, 0x54, 0x2b, 0x3c, 0x67, 0x4a, 0xa5, 0x80, 0x55, 0x2d, 0xbf, 0x07, 0xbd, 0x8a,
-	0x8d, 0x59, 0x53, 0xbb, 0x72, 0xfe, 0x5a, 0x8d, 0x6b, 0xe8, 0x31, 0xf4, 0x2a, 0xd6, 0x56, 0xb4,
-	0xf5, 0xb6, 0x8d, 0x76, 0x3e, 0xd0, 0xc7, 0xb0, 0x59, 0xb5, 0xa1, 0x94, 0xa3, 0x2e, 0x36, 0xaf,
-	0xea, 0x55, 0x06, 0xd7, 0xd0, 0x0d, 0xe8, 0xaa, 0x33, 0x71, 0xb2, 0xb8, 0x25, 0x6f, 0xc2, 0xfa,
-	0x80, 0x24, 0xff, 0x50, 0x78, 0x1b, 0x9a, 0x7c, 0xd5, 0x2b, 0x03, 0x5a, 0x9f, 0xdf, 0x8e, 0x71,
-	0x0d, 0xdd, 0x05, 0x28, 0x1e, 0x11, 0x74, 0x51, 0x4d, 0x81, 0xb9, 0x67, 0xc5, 0xce, 0x3d, 0xe1,
-	0x1a, 0xfa, 0x10, 0xa0, 0xd8, 0xf1, 0x16, 0x62, 0x98, 0x2c, 0xf1, 0x3f, 0x1e, 0x3e, 0xf9, 0x3b,
-	0x00, 0x00, 0xff, 0xff, 0xb3, 0xb3, 0xf0, 0x0f, 0xc7, 0x10, 0x00, 0x00,
+	// 1489 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0xb6, 0x64, 0x3b, 0xb1, 0x8f, 0x13, 0x27, 0x59, 0x87, 0x54, 0x15, 0xa5, 0xb8, 0x2a, 0xd0,
+	0x94, 0xb6, 0x6e, 0x9a, 0x42, 0x61, 0xca, 0x74, 0x06, 0x4f, 0xdc, 0xba, 0x1e, 0x5a, 0xea, 0x51,
+	0x1a, 0x98, 0x5e, 0xca, 0xd1, 0x36, 0x11, 0x91, 0x25, 0x21, 0xad, 0x0c, 0x79, 0x00, 0x6e, 0xb9,
+	0x66, 0x78, 0x03, 0xae, 0x3b, 0x03, 0x57, 0xbc, 0x04, 0x4f, 0xc1, 0x63, 0x30, 0xfb, 0x27, 0xad,
+	0x1c, 0x39, 0x81, 0xe9, 0x70, 0xa7, 0x73, 0xf6, 0xfc, 0x7c, 0xe7, 0x67, 0xcf, 0x1e, 0x1b, 0x9a,
+	0xc7, 0xe9, 0xa4, 0x17, 0xc5, 0x21, 0x09, 0x51, 0x2d, 0x09, 0x83, 0xa9, 0xd9, 0x9c, 0x78, 0x2e,
+	0x67, 0x98, 0xe8, 0xd0, 0x89, 0x9c, 0x89, 0xe7, 0x7b, 0xc4, 0xc3, 0x89, 0xe0, 0x81, 0x8b, 0x1d,
+	0x5f, 0x7c, 0xaf, 0x79, 0x01, 0x55, 0x09, 0x3c, 0x87, 0x33, 0xac, 0x37, 0x1a, 0x34, 0x9f, 0x79,
+	0x09, 0xb1, 0x71, 0xe4, 0x9f, 0xa2, 0x3b, 0x50, 0xf3, 0x82, 0xd7, 0xa1, 0xa1, 0x75, 0xab, 0xdb,
+	0xad, 0xdd, 0xcb, 0x3d, 0x2a, 0xdb, 0xcb, 0x8e, 0x7b, 0xa3, 0xe0, 0x75, 0xf8, 0x38, 0x20, 0xf1,
+	0xa9, 0xcd, 0xc4, 0xcc, 0xeb, 0x5c, 0xf7, 0x1b, 0xc7, 0x4f, 0x31, 0xda, 0x82, 0xa5, 0x19, 0xfd,
+	0x48, 0x98, 0x76, 0xd3, 0x16, 0x94, 0x69, 0x43, 0x33, 0xd3, 0x43, 0xeb, 0x50, 0x3d, 0xc1, 0xa7,
+	0x86, 0xd6, 0xd5, 0xb6, 0x9b, 0x36, 0xfd, 0x44, 0x77, 0xa1, 0xce, 0x04, 0x0d, 0xbd, 0xab, 0x95,
+	0xf9, 0xcc, 0x1c, 0xd8, 0x5c, 0xee, 0xa1, 0xfe, 0xb9, 0x66, 0xbd, 0xd1, 0xa1, 0xf3, 0x34, 0x9d,
+	0xec, 0x13, 0x27, 0x26, 0x2f, 0x9d, 0xe4, 0xc4, 0xc6, 0xdf, 0xa7, 0x38, 0x21, 0xe8, 0x2a, 0xd4,
+	0x68, 0xb0, 0xcc, 0x7e, 0x6b, 0x17, 0xb8, 0xad, 0x01, 0x76, 0x7c, 0x9b, 0xf1, 0x91, 0x09, 0x8d,
+	0x18, 0x1f, 0x79, 0x09, 0x89, 0x4f, 0x99, 0xbf, 0xa6, 0x9d, 0xd1, 0x68, 0x13, 0xea, 0xde, 0xd4,
+	0x39, 0xc2, 0x46, 0x95, 0x1d, 0x70, 0x02, 0x21, 0xa8, 0x39, 0x29, 0x39, 0x36, 0x6a, 0x8c, 0xc9,
+	0xbe, 0xd1, 0x07, 0xb0, 0x3a, 0x4e, 0x27, 0xbe, 0x77, 0xf8, 0x15, 0x3e, 0x1d, 0x38, 0xc4, 0x31,
+	0xea, 0xec, 0xb0, 0xc8, 0x44, 0x16, 0xac, 0x1c, 0x86, 0xd3, 0xa9, 0x47, 0x5e, 0x04, 0xfb, 0x24,
+	0x8c, 0x8c, 0xa5, 0xae, 0xb6, 0xdd, 0xb0, 0x0b, 0x3c, 0xf4, 0x09, 0x54, 0x71, 0x30, 0x33, 0x96,
+	0x59, 0xba, 0x2d, 0x0e, 0xb7, 0x24, 0xae, 0xde, 0xe3, 0x60, 0xc6, 0xf3, 0x4e, 0xc5, 0xcd, 0x07,
+	0xd0, 0x90, 0x8c, 0x92, 0x84, 0x6e, 0xaa, 0x09, 0x6d, 0xaa, 0x59, 0x7b, 0x05, 0x1b, 0x45, 0xe3,
+	0xb4, 0xe4, 0x6d, 0xd0, 0x3d, 0x57, 0xe8, 0xeb, 0x9e, 0x4b, 0x53, 0x84, 0x03, 0x37, 0x0a, 0xbd,
+	0x80, 0x18, 0x3a, 0x2b, 0x64, 0x46, 0x23, 0x03, 0x96, 0x8f, 0xd3, 0x49, 0xdf, 0x75, 0x63, 0x91,
+	0x24, 0x49, 0x5a, 0xbf, 0x68, 0xd0, 0xe6, 0xb6, 0x49, 0x9a, 0x70, 0xc3, 0x57, 0x01, 0xa6, 0x5e,
+	0x80, 0xe3, 0xbd, 0x30, 0x0d, 0x08, 0x73, 0x50, 0xb3, 0x15, 0x0e, 0xed, 0x97, 0x34, 0x22, 0xde,
+	0x94, 0x03, 0xad, 0xd9, 0x82, 0xa2, 0x4e, 0x66, 0x38, 0x4e, 0xbc, 0x30, 0x90, 0x4e, 0x04, 0x49,
+	0xa1, 0x45, 0xbe, 0x43, 0x5e, 0x87, 0xf1, 0x54, 0xd4, 0x23, 0xa3, 0xa9, 0x16, 0x26, 0xc7, 0x0c,
+	0x1a, 0xaf, 0x86, 0x24, 0xad, 0x1f, 0xa1, 0xc5, 0x3a, 0x40, 0xb4, 0xc8, 0x26, 0xd4, 0x27, 0x9e,
+	0x3b, 0x92, 0x21, 0x73, 0x82, 0x72, 0x9d, 0xe4, 0x64, 0xe4, 0xca, 0xa4, 0x31, 0x02, 0x5d, 0x83,
+	0x7a, 0x18, 0xbb, 0x98, 0x47, 0xdb, 0xda, 0x6d, 0xf1, 0x02, 0xbd, 0xa0, 0x2c, 0x9b, 0x9f, 0x50,
+	0x4c, 0x49, 0x84, 0x0f, 0x9f, 0x3a, 0x89, 0xec, 0x91, 0x8c, 0xb6, 0x7e, 0xd3, 0xc0, 0x18, 0x62,
+	0x32, 0xc0, 0x33, 0xef, 0x10, 0x8f, 0xe3, 0x30, 0xc2, 0x31, 0xbd, 0x96, 0x3c, 0x3d, 0x5f, 0x03,
+	0x44, 0x19, 0x4b, 0x5c, 0xb8, 0x1e, 0x77, 0xb0, 0x48, 0xa7, 0x97, 0xd3, 0xbc, 0x1b, 0x14, 0x0b,
+	0xe6, 0x23, 0x58, 0x9b, 0x3b, 0xbe, 0xa8, 0x37, 0x34, 0xb5, 0x37, 0xfe, 0xd4, 0xc0, 0xdc, 0x2f,
+	0xf3, 0xcb, 0xb3, 0xd6, 0x06, 0x7d, 0x34, 0x90, 0x5d, 0x32, 0x1a, 0xa0, 0x71, 0x01, 0xbd, 0xce,
+	0xd0, 0xef, 0x70, 0xf4, 0x8b, 0xad, 0xfc, 0x9f, 0xf8, 0x7f, 0xd2, 0x00, 0xf6, 0xfd, 0x90, 0x88,
+	0xec, 0xde, 0x83, 0x7a, 0x42, 0x29, 0x91, 0xd8, 0x77, 0x05, 0xb4, 0x4c, 0x80, 0x7f, 0x72, 0x14,
+	0x5c, 0xd2, 0x1c, 0x00, 0xe4, 0xcc, 0x12, 0xdf, 0xdd, 0xe2, 0xa0, 0x82, 0xdc, 0xa4, 0x8a, 0xe3,
+	0x2f, 0x0d, 0xd6, 0x87, 0x98, 0xf4, 0x7d, 0x5f, 0x41, 0xf3, 0x59, 0x11, 0xcd, 0xb5, 0xac, 0xcc,
+	0x05, 0xb1, 0x12, 0x4c, 0x1f, 0x43, 0x83, 0x32, 0x9f, 0x79, 0x7c, 0xb6, 0x51, 0xa6, 0xb0, 0xa1,
+	0xba, 0x67, 0x7c, 0xf3, 0xd5, 0x05, 0xf8, 0x3f, 0x2d, 0xe2, 0x7f, 0xff, 0x1c, 0x10, 0x6c, 0xfa,
+	0x2a, 0x41, 0x7d, 0x09, 0xed, 0xbe, 0xeb, 0x32, 0x5f, 0x0b, 0xfa, 0x41, 0x82, 0x3b, 0x9b, 0x1b,
+	0xc6, 0xb7, 0xf6, 0x60, 0xc3, 0xc6, 0xd3, 0x70, 0x86, 0xdf, 0xc6, 0x88, 0x07, 0x97, 0x87, 0x98,
+	0xd8, 0x6c, 0x60, 0xe3, 0x18, 0xbb, 0xdf, 0x86, 0xf1, 0x09, 0x8e, 0x45, 0x8e, 0x4d, 0xa8, 0x7a,
+	0xae, 0xcc, 0x70, 0x83, 0xeb, 0x8e, 0x06, 0x36, 0x65, 0xa2, 0x1d, 0x58, 0xfe, 0x81, 0xcb, 0x8a,
+	0x56, 0xdd, 0xe2, 0xe7, 0xf3, 0xa6, 0x6c, 0x29, 0x66, 0xfd, 0xae, 0xc3, 0x2a, 0x9d, 0x91, 0xf9,
+	0xd3, 0x78, 0xaf, 0xf0, 0x34, 0xbe, 0xc7, 0x0d, 0x14, 0x44, 0xce, 0x3c, 0x8f, 0xbf, 0x6a, 0xd0,
+	0xa0, 0x12, 0x94, 0x8f, 0x1e, 0x41, 0x9d, 0x38, 0xc9, 0x89, 0x44, 0x78, 0xa3, 0xcc, 0x80, 0x14,
+	0x66, 0x1f, 0xb2, 0x13, 0x98, 0x96, 0xf9, 0x02, 0x20, 0x67, 0x96, 0x54, 0xf7, 0x56, 0xb1, 0xba,
+	0xef, 0xe4, 0xe6, 0x95, 0x99, 0xac, 0xd4, 0xd4, 0x3c, 0x38, 0xff, 0x59, 0xde, 0x2d, 0xda, 0xbb,
+	0x72, 0x1e, 0x5c, 0xb5, 0x55, 0xc6, 0xb0, 0xba, 0x37, 0x3e, 0xe0, 0x03, 0x80, 0xc5, 0xbd, 0x05,
+	0x4b, 0x6c, 0xe8, 0x67, 0x6b, 0x01, 0xa7, 0xd0, 0x0d, 0x58, 0x72, 0x99, 0x94, 0xf0, 0xb0, 0xc6,
+	0x3d, 0x64, 0xca, 0xb6, 0x38, 0xa6, 0x16, 0x87, 0x6f, 0x63, 0x71, 0x78, 0xc6, 0xe2, 0xcf, 0x3a,
+	0xac, 0x70, 0x96, 0xe8, 0x9d, 0x1d, 0xa8, 0xed, 0x8d, 0x0f, 0x64, 0x69, 0xae, 0xc8, 0xb5, 0x21,
+	0x97, 0xa0, 0xb0, 0x44, 0x3d, 0x98, 0x24, 0xd5, 0x18, 0x8e, 0x0f, 0x64, 0x3b, 0x95, 0x69, 0x0c,
+	0x73, 0x0d, 0xfa, 0x69, 0x3e, 0x83, 0x66, 0x66, 0xa4, 0x24, 0xdf, 0x37, 0x8b, 0xf9, 0xee, 0xcc,
+	0x65, 0x63, 0x2e, 0xcd, 0xd4, 0xda, 0xf0, 0x3f, 0x5b, 0x1b, 0x2e, 0xb0, 0x66, 0x8d, 0x60, 0x63,
+	0x14, 0x24, 0x38, 0x26, 0xea, 0xed, 0xcc, 0xe7, 0x4d, 0xe9, 0x6d, 0xa4, 0xb3, 0x38, 0x8a, 0x65,
+	0xb6, 0x9b, 0x36, 0x27, 0xac, 0x3e, 0xac, 0x8d, 0x53, 0xdf, 0x57, 0x97, 0xb2, 0x2d, 0x5a, 0x17,
+	0xc7, 0xcf, 0x9e, 0x5c, 0x41, 0x51, 0x3e, 0x51, 0x1f, 0x5d, 0x41, 0x59, 0x7f, 0x68, 0xb0, 0x4a,
+	0x5f, 0x6c, 0x86, 0x92, 0xd5, 0xc7, 0xc8, 0x76, 0x14, 0xf5, 0x6a, 0xeb, 0x9e, 0xf2, 0x42, 0xeb,
+	0x0b, 0x5f, 0xe8, 0xdb, 0xb0, 0xc2, 0xae, 0x90, 0x9d, 0x06, 0x81, 0x17, 0x1c, 0x19, 0xd5, 0xb9,
+	0x09, 0x51, 0x38, 0x45, 0x5f, 0x40, 0x9b, 0xd1, 0x7b, 0xe1, 0x34, 0xf2, 0x31, 0xc1, 0xae, 0x51,
+	0xeb, 0x56, 0xf3, 0x14, 0x66, 0x6c, 0x16, 0xe0, 0x9c, 0xa8, 0xf5, 0x1d, 0xac, 0x16, 0x04, 0xce,
+	0x01, 0x9e, 0x6d, 0x9b, 0xba, 0xba, 0x6d, 0xde, 0x84, 0x65, 0x1c, 0xb8, 0x2f, 0xe9, 0x52, 0x54,
+	0x55, 0x7b, 0x98, 0x72, 0x12, 0xe2, 0x4c, 0x23, 0x5b, 0x9e, 0x5b, 0x0e, 0xac, 0xcf, 0x8f, 0xaf,
+	0x73, 0xdc, 0xdd, 0x87, 0x95, 0x38, 0x93, 0xee, 0x93, 0xe2, 0x0d, 0xc9, 0xad, 0x17, 0x84, 0x76,
+	0xff, 0x6e, 0x42, 0xf5, 0x69, 0x3a, 0x41, 0x1f, 0x41, 0x6d, 0x4c, 0x73, 0x23, 0xb2, 0xfb, 0x78,
+	0x1a, 0x91, 0x53, 0x53, 0xe8, 0xd2, 0x03, 0x56, 0x24, 0xab, 0x82, 0xee, 0xc0, 0x12, 0x1f, 0x36,
+	0x45, 0xc9, 0x4d, 0x75, 0xaf, 0x95, 0xa3, 0xc8, 0xaa, 0x50, 0xb3, 0xec, 0x61, 0x2b, 0x33, 0x9b,
+	0x0d, 0x19, 0xab, 0x82, 0xae, 0x43, 0x8d, 0xdd, 0xfb, 0x2c, 0x22, 0x29, 0x94, 0x35, 0x88, 0x55,
+	0x41, 0x3d, 0x3e, 0x6a, 0xcf, 0x1a, 0xec, 0x94, 0x4c, 0x2e, 0x86, 0xb5, 0x31, 0x4e, 0x93, 0x63,
+	0x56, 0x25, 0x21, 0xbf, 0x77, 0x9c, 0x06, 0x27, 0x66, 0x5b, 0xc4, 0x15, 0x87, 0x47, 0x31, 0x4e,
+	0x12, 0xab, 0xb2, 0xad, 0xed, 0x68, 0x68, 0x17, 0x1a, 0xb2, 0xad, 0x91, 0x98, 0xad, 0x73, 0x6d,
+	0x6e, 0xaa, 0x56, 0xac, 0xca, 0x8e, 0x86, 0xfa, 0xd0, 0xcc, 0x76, 0x6d, 0x74, 0x79, 0xe1, 0x72,
+	0x6f, 0x5e, 0x2a, 0x3b, 0x92, 0xa1, 0x37, 0xe8, 0xef, 0x04, 0x66, 0x21, 0x0f, 0x5f, 0x8d, 0xcf,
+	0xaa, 0xa0, 0xbb, 0xfc, 0x69, 0x10, 0xa9, 0xcf, 0xc5, 0xca, 0xdf, 0x00, 0xa6, 0xd0, 0x7a, 0x4e,
+	0x47, 0xe6, 0x19, 0x0d, 0x51, 0x29, 0xce, 0x7f, 0xee, 0x44, 0x52, 0xe1, 0xa1, 0x48, 0x6e, 0x78,
+	0x94, 0x20, 0xc5, 0x2a, 0xa5, 0x65, 0x10, 0x9d, 0x22, 0x3b, 0xcf, 0xc2, 0x5d, 0x68, 0xd1, 0xbd,
+	0x2e, 0x4c, 0x30, 0xbd, 0xd3, 0x68, 0x43, 0xf9, 0x4d, 0x56, 0x4c, 0x9c, 0x0c, 0xa7, 0x07, 0x2d,
+	0xb6, 0x00, 0xf3, 0x01, 0xa0, 0xa0, 0xeb, 0xe4, 0xaa, 0x6a, 0xe5, 0x1f, 0x40, 0x6b, 0xe0, 0x25,
+	0x87, 0xe1, 0x0c, 0xc7, 0xb4, 0x59, 0x0d, 0x21, 0x95, 0xb3, 0x16, 0xf8, 0xb9, 0x0d, 0xcb, 0x62,
+	0x60, 0x17, 0x1b, 0x06, 0x9d, 0x1d, 0xe6, 0x0c, 0xd5, 0x0a, 0xcb, 0x99, 0x54, 0xc9, 0x61, 0x95,
+	0xcb, 0xf7, 0xa1, 0x53, 0xb2, 0xc6, 0x2b, 0x6a, 0x57, 0xcf, 0xdf, 0xf5, 0xad, 0x0a, 0x7a, 0x02,
+	0x9d, 0x92, 0x5d, 0x1a, 0x75, 0x2f, 0x5a, 0xb3, 0xe7, 0x03, 0x7d, 0x02, 0x9b, 0x65, 0x6b, 0x53,
+	0x31, 0xea, 0x7c, 0x1d, 0x2c, 0xdf, 0xaf, 0xac, 0x0a, 0xba, 0x09, 0x6d, 0x79, 0xc6, 0x4f, 0x16,
+	0xb7, 0xe4, 0x2d, 0x58, 0x1f, 0xe0, 0xf8, 0x5f, 0x0a, 0x6f, 0x43, 0x9d, 0xed, 0x9f, 0x45, 0x40,
+	0xeb, 0xf3, 0x2b, 0xbb, 0x55, 0x41, 0xf7, 0x00, 0xf2, 0x77, 0x0a, 0x5d, 0x92, 0x53, 0x60, 0xee,
+	0xe5, 0x32, 0x33, 0x4f, 0x56, 0x05, 0x7d, 0x08, 0x90, 0x2f, 0x9e, 0x0b, 0x31, 0x4c, 0x96, 0xd8,
+	0xbf, 0x21, 0xf7, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0x4d, 0xea, 0xda, 0x03, 0x5c, 0x11, 0x00,
+	0x00,
 }
@@ -323,6 +323,8 @@ type InfoReply struct {
 	Usage        map[string]*ResourceUsage `protobuf:"bytes,1,rep,name=usage" json:"usage,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Name         string                    `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
 	Capabilities *Capabilities             `protobuf:"bytes,3,opt,name=capabilities" json:"capabilities,omitempty"`
+	// GPUs currently reported by the worker, absent if unknown.
+	GpuTelemetry *GPUTelemetry `protobuf:"bytes,4,opt,name=gpuTelemetry" json:"gpuTelemetry,omitempty"`
 }
 
 func (m *InfoReply) Reset()                    { *m = InfoReply{} }
@@ -351,14 +353,24 @@ func (m *InfoReply) GetCapabilities() *Capabilities {
 	return nil
 }
 
+func (m *InfoReply) GetGpuTelemetry() *GPUTelemetry {
+	if m != nil {
+		return m.GpuTelemetry
+	}
+	return nil
+}
+
 type TaskStatusReply struct {
-	Status             TaskStatusReply_Status `protobuf:"varint,1,opt,name=status,enum=sonm.TaskStatusReply_Status" json:"status,omitempty"`
-	ImageName          string                 `protobuf:"bytes,2,opt,name=imageName" json:"imageName,omitempty"`
-	Ports              string                 `protobuf:"bytes,3,opt,name=ports" json:"ports,omitempty"`
-	Uptime             uint64                 `protobuf:"varint,4,opt,name=uptime" json:"uptime,omitempty"`
-	Usage              *ResourceUsage         `protobuf:"bytes,5,opt,name=usage" json:"usage,omitempty"`
-	AvailableResources *AvailableResources    `protobuf:"bytes,6,opt,name=availableResources" json:"availableResources,omitempty"`
-	MinerID            string                 `protobuf:"bytes,7,opt,name=minerID" json:"minerID,omitempty"`
+	Status    TaskStatusReply_Status `protobuf:"varint,1,opt,name=status,enum=sonm.TaskStatusReply_Status" json:"status,omitempty"`
+	ImageName string                 `protobuf:"bytes,2,opt,name=imageName" json:"imageName,omitempty"`
+	Ports     string                 `protobuf:"bytes,3,opt,name=ports" json:"ports,omitempty"`
+	// Uptime in nanoseconds.
+	Uptime             uint64              `protobuf:"varint,4,opt,name=uptime" json:"uptime,omitempty"`
+	Usage              *ResourceUsage      `protobuf:"bytes,5,opt,name=usage" json:"usage,omitempty"`
+	AvailableResources *AvailableResources `protobuf:"bytes,6,opt,name=availableResources" json:"availableResources,omitempty"`
+	MinerID            string              `protobuf:"bytes,7,opt,name=minerID" json:"minerID,omitempty"`
+	Restarts           uint32              `protobuf:"varint,8,opt,name=restarts" json:"restarts,omitempty"`
+	ExitCode           int32               `protobuf:"varint,9,opt,name=exitCode" json:"exitCode,omitempty"`
 }
 
 func (m *TaskStatusReply) Reset()                    { *m = TaskStatusReply{} }
@@ -415,6 +427,20 @@ func (m *TaskStatusReply) GetMinerID() string {
 	return ""
 }
 
+func (m *TaskStatusReply) GetRestarts() uint32 {
+	if m != nil {
+		return m.Restarts
+	}
+	return 0
+}
+
+func (m *TaskStatusReply) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
 type AvailableResources struct {
 	NumCPUs            int64  `protobuf:"varint,1,opt,name=numCPUs" json:"numCPUs,omitempty"`
 	NumGPUs            int64  `protobuf:"varint,2,opt,name=numGPUs" json:"numGPUs,omitempty"`
@@ -792,6 +818,24 @@ func (m *Progress) GetSize() int64 {
 	return 0
 }
 
+type GPUTelemetry struct {
+	// Number of GPUs the worker detects right now, which is less than
+	// advertised in its capabilities when some cards have failed.
+	ReportingCount uint32 `protobuf:"varint,1,opt,name=reportingCount" json:"reportingCount,omitempty"`
+}
+
+func (m *GPUTelemetry) Reset()                    { *m = GPUTelemetry{} }
+func (m *GPUTelemetry) String() string            { return proto.CompactTextString(m) }
+func (*GPUTelemetry) ProtoMessage()               {}
+func (*GPUTelemetry) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{20} }
+
+func (m *GPUTelemetry) GetReportingCount() uint32 {
+	if m != nil {
+		return m.ReportingCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "sonm.Empty")
 	proto.RegisterType((*ID)(nil), "sonm.ID")
@@ -813,6 +857,7 @@ func init() {
 	proto.RegisterType((*Timestamp)(nil), "sonm.Timestamp")
 	proto.RegisterType((*Chunk)(nil), "sonm.Chunk")
 	proto.RegisterType((*Progress)(nil), "sonm.Progress")
+	proto.RegisterType((*GPUTelemetry)(nil), "sonm.GPUTelemetry")
 	proto.RegisterEnum("sonm.NetworkType", NetworkType_name, NetworkType_value)
 	proto.RegisterEnum("sonm.GPUCount", GPUCount_name, GPUCount_value)
 	proto.RegisterEnum("sonm.TaskStatusReply_Status", TaskStatusReply_Status_name, TaskStatusReply_Status_value)
@@ -822,89 +867,93 @@ func init() {
 func init() { proto.RegisterFile("insonmnia.proto", fileDescriptor4) }
 
 var fileDescriptor4 = []byte{
-	// 1329 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xae, 0xff, 0xed, 0x63, 0x27, 0x75, 0x87, 0x50, 0xad, 0xac, 0x52, 0x45, 0x5b, 0x2e, 0xd2,
-	0x52, 0x59, 0x55, 0x40, 0xa8, 0x14, 0x09, 0x29, 0xb1, 0xdd, 0xc4, 0x4a, 0xbc, 0x5e, 0xc6, 0x5e,
-	0x15, 0x71, 0x53, 0x4d, 0xec, 0x21, 0x1d, 0xc5, 0xfb, 0xc3, 0xee, 0x6c, 0x1a, 0x73, 0xcd, 0x3b,
-	0x70, 0x8f, 0x78, 0x28, 0x1e, 0x81, 0x6b, 0xae, 0xb9, 0x40, 0x33, 0x67, 0x76, 0xbd, 0x26, 0x16,
-	0x37, 0xd6, 0x7c, 0xe7, 0x3b, 0xb3, 0x73, 0x7e, 0xe6, 0x7c, 0x1e, 0x78, 0x28, 0x82, 0x24, 0x0c,
-	0xfc, 0x40, 0xb0, 0x7e, 0x14, 0x87, 0x32, 0x24, 0x55, 0x05, 0x7b, 0x64, 0xc1, 0x22, 0x76, 0x25,
-	0x56, 0x42, 0x0a, 0x9e, 0x20, 0x63, 0x37, 0xa0, 0x36, 0xf2, 0x23, 0xb9, 0xb6, 0x0f, 0xa0, 0x3c,
-	0x1e, 0x92, 0x7d, 0x28, 0x8b, 0xa5, 0x55, 0x3a, 0x2c, 0x1d, 0xb5, 0x68, 0x59, 0x2c, 0xed, 0x63,
-	0xa8, 0xcf, 0x59, 0x72, 0x73, 0x9f, 0x21, 0x16, 0x34, 0x3e, 0xa4, 0x57, 0x27, 0xcb, 0x65, 0x6c,
-	0x95, 0xb5, 0x31, 0x83, 0xf6, 0x33, 0x68, 0xb9, 0x22, 0xb8, 0xa6, 0x3c, 0x5a, 0xad, 0xc9, 0x63,
-	0xa8, 0x27, 0x92, 0xc9, 0x34, 0x31, 0x5b, 0x0d, 0xb2, 0x0f, 0xa1, 0x39, 0x70, 0x3d, 0x2f, 0x61,
-	0xd7, 0x9c, 0x1c, 0x40, 0x4d, 0x86, 0x92, 0xad, 0xb4, 0x4b, 0x95, 0x22, 0xb0, 0x9f, 0x43, 0x7b,
-	0xc2, 0xfd, 0x30, 0x5e, 0xa3, 0x53, 0x0f, 0x9a, 0x3e, 0xbb, 0xd3, 0x6b, 0xe3, 0x97, 0x63, 0xfb,
-	0xef, 0x12, 0x74, 0x1c, 0x2e, 0x3f, 0x86, 0xf1, 0x0d, 0x3a, 0x5b, 0xd0, 0x90, 0x77, 0xa7, 0x6b,
-	0xc9, 0x13, 0xe3, 0x9b, 0x41, 0xc5, 0xc4, 0x86, 0x29, 0x23, 0x63, 0x20, 0x79, 0x02, 0x2d, 0x79,
-	0xe7, 0xb2, 0xc5, 0x0d, 0x97, 0x89, 0x55, 0xd1, 0xdc, 0xc6, 0xa0, 0xd8, 0x38, 0x67, 0xab, 0xc8,
-	0xe6, 0x06, 0x15, 0x9c, 0xbc, 0x1b, 0xc5, 0x71, 0x18, 0x27, 0x56, 0x0d, 0x83, 0xcb, 0xb0, 0xe2,
-	0xe2, 0x8c, 0xab, 0x23, 0x97, 0x61, 0x3c, 0x73, 0x18, 0x87, 0x51, 0xc4, 0x97, 0x56, 0x23, 0x3b,
-	0xd3, 0x18, 0xf0, 0xcc, 0x8c, 0x6d, 0x66, 0x67, 0x1a, 0x83, 0xfd, 0x57, 0x09, 0xf6, 0x28, 0x4f,
-	0xc2, 0x34, 0x5e, 0x70, 0xcc, 0xfa, 0x10, 0x2a, 0x8b, 0x28, 0xd5, 0x19, 0xb7, 0x8f, 0xf7, 0xfb,
-	0xaa, 0xe7, 0xfd, 0xac, 0xc8, 0x54, 0x51, 0xe4, 0x39, 0xd4, 0x7d, 0x5d, 0x53, 0x9d, 0x7c, 0xfb,
-	0xf8, 0x11, 0x3a, 0x15, 0xea, 0x4c, 0x8d, 0x03, 0x79, 0x03, 0x8d, 0x00, 0x4b, 0x6a, 0x55, 0x0e,
-	0x2b, 0x47, 0xed, 0xe3, 0x43, 0xf4, 0xdd, 0x3a, 0xb2, 0x6f, 0xaa, 0x3e, 0x0a, 0x64, 0xbc, 0xa6,
-	0xd9, 0x86, 0x9e, 0x93, 0xb7, 0x43, 0x13, 0xa4, 0x0b, 0x95, 0x1b, 0xbe, 0x36, 0x37, 0x40, 0x2d,
-	0xc9, 0x11, 0xd4, 0x6e, 0xd9, 0x2a, 0xe5, 0x26, 0x0e, 0x82, 0xdf, 0x2e, 0xf6, 0x90, 0xa2, 0xc3,
-	0x9b, 0xf2, 0xeb, 0x92, 0xfd, 0x67, 0x09, 0x5a, 0xe3, 0xe0, 0xa7, 0x10, 0xaf, 0xd4, 0x2b, 0xa8,
-	0xa5, 0xe6, 0x1a, 0xa8, 0xb8, 0x7a, 0xb8, 0x37, 0xe7, 0xfb, 0x7a, 0x3b, 0x46, 0x84, 0x8e, 0x84,
-	0x40, 0x35, 0x60, 0x3e, 0x37, 0x17, 0x55, 0xaf, 0xc9, 0xd7, 0xd0, 0x29, 0x8e, 0x83, 0xee, 0x78,
-	0x1e, 0xc8, 0xa0, 0xc0, 0xd0, 0x2d, 0xbf, 0xde, 0x04, 0x60, 0x73, 0xc0, 0x8e, 0xcc, 0x9e, 0x6f,
-	0x67, 0xf6, 0xc9, 0x8e, 0xaa, 0x15, 0x53, 0xfb, 0xa7, 0x0c, 0x0f, 0xd5, 0x84, 0xcd, 0xf4, 0x58,
-	0x60, 0x82, 0x5f, 0x6d, 0xcd, 0xcc, 0xfe, 0xf1, 0x13, 0xfc, 0xc6, 0x7f, 0xdc, 0xfa, 0x66, 0x6d,
-	0x7c, 0xd5, 0x6d, 0x11, 0x3e, 0xbb, 0xe6, 0xce, 0x26, 0xd3, 0x8d, 0x41, 0xcd, 0x58, 0x14, 0xc6,
-	0xe6, 0x66, 0xb7, 0x28, 0x02, 0x35, 0x9d, 0x69, 0x24, 0x85, 0xcf, 0xcd, 0x95, 0x36, 0x48, 0x25,
-	0x81, 0x25, 0xae, 0xfd, 0x4f, 0x12, 0x58, 0xdb, 0x73, 0x20, 0xec, 0x96, 0x89, 0x15, 0xbb, 0x5a,
-	0xf1, 0xcc, 0x01, 0x2f, 0x7a, 0xfb, 0xd8, 0xc2, 0x7d, 0x27, 0xf7, 0x78, 0xba, 0x63, 0x8f, 0x1a,
-	0x4d, 0x5f, 0x04, 0x3c, 0x1e, 0x0f, 0xf5, 0x28, 0xb4, 0x68, 0x06, 0xed, 0x1f, 0xa0, 0x8e, 0xc9,
-	0x92, 0x36, 0x34, 0x3c, 0xe7, 0xc2, 0x99, 0xbe, 0x73, 0xba, 0x0f, 0x48, 0x07, 0x9a, 0x33, 0x77,
-	0x3a, 0xbd, 0x1c, 0x3b, 0x67, 0xdd, 0x12, 0xa2, 0x93, 0x77, 0x8e, 0x42, 0x65, 0xe5, 0x48, 0x3d,
-	0x47, 0x83, 0x8a, 0xa2, 0xde, 0x8e, 0x9d, 0xf1, 0xec, 0x7c, 0x34, 0xec, 0x56, 0x09, 0x40, 0xfd,
-	0x94, 0x4e, 0x2f, 0x46, 0x4e, 0xb7, 0x66, 0xff, 0x5e, 0x05, 0x72, 0xb2, 0x33, 0x94, 0x20, 0xf5,
-	0x07, 0xae, 0x87, 0x2d, 0xa8, 0xd0, 0x0c, 0x1a, 0xe6, 0x4c, 0x31, 0xe5, 0x9c, 0x51, 0x50, 0xd5,
-	0xd2, 0xcc, 0x16, 0x8a, 0x47, 0x36, 0x48, 0x4f, 0xa0, 0x35, 0x70, 0x3d, 0x97, 0xc7, 0x22, 0x5c,
-	0xea, 0x32, 0x57, 0xe8, 0xc6, 0xa0, 0xd4, 0x61, 0xe0, 0x7a, 0xdf, 0xa7, 0xa1, 0x64, 0xba, 0xd8,
-	0x15, 0x9a, 0x63, 0xf2, 0x12, 0x1e, 0x0d, 0x5c, 0x8f, 0x72, 0xb6, 0x52, 0x4d, 0x31, 0x5f, 0xa8,
-	0x6b, 0xa7, 0xfb, 0x04, 0xe9, 0x03, 0x29, 0x18, 0x69, 0x1a, 0xe8, 0xbe, 0x36, 0xb4, 0xfb, 0x0e,
-	0x86, 0x3c, 0x05, 0x18, 0x44, 0x69, 0xc2, 0xa5, 0xfa, 0xd5, 0xf2, 0xd2, 0xa2, 0x05, 0xcb, 0x86,
-	0x9f, 0x70, 0x3f, 0xb1, 0x5a, 0x45, 0x5e, 0x59, 0x54, 0x5e, 0x43, 0x91, 0xdc, 0x60, 0xe8, 0x80,
-	0x79, 0xe5, 0x06, 0x62, 0x43, 0xe7, 0x82, 0xc7, 0x01, 0x5f, 0xa1, 0xb6, 0x58, 0x6d, 0xed, 0xb0,
-	0x65, 0x53, 0xf9, 0xe1, 0x8a, 0xf2, 0x84, 0xc7, 0xb7, 0x4c, 0x8a, 0x30, 0xb0, 0x3a, 0x98, 0xdf,
-	0x3d, 0x42, 0xc5, 0x83, 0xc6, 0xd9, 0x47, 0x16, 0x59, 0x7b, 0xda, 0xad, 0x60, 0x51, 0xf1, 0xb8,
-	0x62, 0x99, 0x5c, 0x0a, 0x5f, 0x48, 0x6b, 0x1f, 0xe3, 0xc9, 0x0d, 0xaa, 0x3b, 0x8b, 0xeb, 0x38,
-	0x4c, 0x23, 0xeb, 0x21, 0xfe, 0x0f, 0x21, 0x52, 0x71, 0xe2, 0xca, 0x65, 0x31, 0x0f, 0xa4, 0xd5,
-	0xd5, 0xec, 0x96, 0xcd, 0xfe, 0xa3, 0x04, 0xfb, 0x78, 0xff, 0x26, 0x2c, 0xc2, 0x11, 0xfd, 0x0e,
-	0x9a, 0x38, 0x76, 0xfa, 0x1f, 0x46, 0xc9, 0x90, 0x8d, 0x77, 0x7d, 0xdb, 0xcf, 0x40, 0x9e, 0xa0,
-	0x1c, 0xe5, 0x7b, 0x7a, 0x14, 0xf6, 0xb6, 0xa8, 0x1d, 0x42, 0xf2, 0xc5, 0xb6, 0x90, 0x7c, 0xba,
-	0x53, 0x04, 0x8a, 0x52, 0xf2, 0x23, 0x3c, 0x1e, 0x84, 0x81, 0x64, 0x6a, 0x68, 0x28, 0x4f, 0x24,
-	0x8b, 0xa5, 0x1b, 0xae, 0xc4, 0x62, 0x9d, 0xeb, 0x5f, 0xa9, 0xa0, 0x7f, 0x2f, 0xe1, 0x91, 0xcf,
-	0xee, 0x84, 0x9f, 0xfa, 0x94, 0xcb, 0x78, 0x3d, 0x08, 0xd3, 0x40, 0xea, 0xa3, 0xf6, 0xe8, 0x7d,
-	0xc2, 0xfe, 0xcd, 0xc8, 0xd4, 0x65, 0x78, 0x9d, 0x50, 0xfe, 0x73, 0xca, 0x13, 0x49, 0xfa, 0x50,
-	0x95, 0xeb, 0x88, 0x1b, 0x91, 0xea, 0x6d, 0xe2, 0x2b, 0x38, 0xf5, 0xe7, 0xeb, 0x88, 0x53, 0xed,
-	0x67, 0x5e, 0x10, 0xe5, 0xfc, 0x05, 0x71, 0x00, 0xb5, 0x44, 0x04, 0x0b, 0x9e, 0x49, 0x92, 0x06,
-	0xe4, 0x73, 0xd8, 0x63, 0xcb, 0xe5, 0x5c, 0xf8, 0x2a, 0x03, 0x3f, 0xc2, 0x3f, 0xdb, 0x26, 0xdd,
-	0x36, 0xaa, 0x76, 0xbe, 0x0d, 0x57, 0xab, 0xf0, 0xa3, 0x1e, 0x9a, 0x26, 0x35, 0x48, 0x65, 0x3a,
-	0x67, 0x62, 0xa5, 0xa7, 0xa4, 0x45, 0xf5, 0x5a, 0x8d, 0xec, 0x90, 0x4b, 0x26, 0x56, 0x89, 0x9e,
-	0x86, 0x26, 0xcd, 0x60, 0xf1, 0x0d, 0xd3, 0xdc, 0x7e, 0xc3, 0x1c, 0x41, 0x55, 0x45, 0xae, 0xb4,
-	0x62, 0x36, 0x1f, 0x4e, 0xbd, 0x79, 0xf7, 0x81, 0x59, 0x8f, 0x28, 0xed, 0x96, 0x48, 0x13, 0xaa,
-	0xa7, 0xd3, 0xf9, 0x79, 0xb7, 0x6c, 0x3f, 0x83, 0xbd, 0x2c, 0xe7, 0xc1, 0x87, 0x34, 0xb8, 0x51,
-	0x21, 0x2c, 0x99, 0x64, 0xba, 0x2c, 0x1d, 0xaa, 0xd7, 0xf6, 0x2b, 0x20, 0x43, 0x91, 0x2c, 0xc2,
-	0x5b, 0x1e, 0x9f, 0xa7, 0x57, 0x59, 0x01, 0x7b, 0xd0, 0xe4, 0xc1, 0x32, 0x0a, 0x45, 0x20, 0x4d,
-	0x6b, 0x72, 0x6c, 0xff, 0x5a, 0x02, 0x4b, 0x7d, 0x37, 0xd3, 0x24, 0xb5, 0x47, 0xc4, 0xdc, 0xe7,
-	0x01, 0x3e, 0x37, 0x06, 0xae, 0x37, 0x08, 0xe3, 0xfc, 0x7d, 0x93, 0x63, 0x35, 0x06, 0x3e, 0xbb,
-	0x9b, 0x6c, 0xfe, 0xe5, 0x2b, 0x74, 0x63, 0x20, 0x7d, 0x80, 0x33, 0xd7, 0x9b, 0xa5, 0x91, 0xd2,
-	0x7f, 0x5d, 0xf8, 0xfd, 0xec, 0xa5, 0x70, 0xa6, 0xbe, 0x90, 0x06, 0x92, 0x16, 0x3c, 0xec, 0x6f,
-	0xa1, 0x95, 0x57, 0x5d, 0x95, 0x2b, 0xe1, 0x8b, 0x30, 0x58, 0xe6, 0xaa, 0x68, 0xa0, 0x6a, 0x65,
-	0xc0, 0x82, 0x10, 0x35, 0xb1, 0x46, 0x11, 0xd8, 0x9f, 0x41, 0x0d, 0x4b, 0x72, 0x00, 0xb5, 0x85,
-	0x5a, 0x98, 0x9a, 0x20, 0xb0, 0x9f, 0x42, 0xd3, 0x8d, 0xc3, 0xeb, 0x98, 0x27, 0x89, 0x2a, 0x5a,
-	0x22, 0x7e, 0xe1, 0xe6, 0xbb, 0x7a, 0xfd, 0xe2, 0x1b, 0x68, 0x9b, 0x07, 0xc1, 0x1c, 0xaf, 0x0f,
-	0x38, 0xd3, 0xf7, 0xce, 0x68, 0xfe, 0x6e, 0x4a, 0x2f, 0x50, 0xfd, 0xa7, 0xde, 0xfc, 0x74, 0xea,
-	0x39, 0x43, 0x54, 0xff, 0xb1, 0x33, 0x98, 0x4e, 0xb4, 0xfa, 0xbf, 0x78, 0x0d, 0xcd, 0x2c, 0x1d,
-	0xd5, 0x36, 0x67, 0xfa, 0xfe, 0xcc, 0xf5, 0xba, 0x0f, 0xd4, 0x37, 0x66, 0x63, 0xe7, 0xec, 0x72,
-	0xa4, 0x71, 0x89, 0x74, 0xa1, 0x33, 0xf1, 0x2e, 0xe7, 0x63, 0xd7, 0x58, 0xca, 0x57, 0x75, 0xfd,
-	0x2c, 0xfe, 0xf2, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd7, 0xa8, 0x64, 0x21, 0x43, 0x0b, 0x00,
-	0x00,
+	// 1397 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0xdd, 0x6e, 0xdb, 0xc6,
+	0x12, 0x8e, 0x7e, 0x2d, 0x8d, 0x64, 0x47, 0xd9, 0xe3, 0x13, 0x10, 0x46, 0x4e, 0x60, 0x30, 0x07,
+	0x07, 0x4e, 0x4e, 0x20, 0x04, 0x6e, 0x11, 0xa4, 0x29, 0x50, 0xc0, 0x96, 0x14, 0x5b, 0xb0, 0x4d,
+	0xb1, 0x6b, 0x11, 0x29, 0x7a, 0x13, 0xac, 0xa5, 0xad, 0x42, 0x58, 0xfc, 0x29, 0xb9, 0x74, 0xac,
+	0x5e, 0xf7, 0x01, 0x7a, 0xd7, 0xfb, 0xa2, 0x8f, 0xd0, 0x87, 0xea, 0x75, 0x9f, 0xa0, 0x98, 0x9d,
+	0x25, 0x45, 0xc5, 0x46, 0x6f, 0xa4, 0xf9, 0xe6, 0x9b, 0x5d, 0xce, 0xcf, 0xce, 0xec, 0xc2, 0x43,
+	0x3f, 0x4c, 0xa3, 0x30, 0x08, 0x7d, 0xd1, 0x8f, 0x93, 0x48, 0x45, 0xac, 0x8e, 0x70, 0x8f, 0xcd,
+	0x44, 0x2c, 0xae, 0xfc, 0xa5, 0xaf, 0x7c, 0x99, 0x12, 0x63, 0x6f, 0x41, 0x63, 0x14, 0xc4, 0x6a,
+	0x65, 0xef, 0x42, 0x75, 0x3c, 0x64, 0x3b, 0x50, 0xf5, 0xe7, 0x56, 0x65, 0xbf, 0x72, 0xd0, 0xe6,
+	0x55, 0x7f, 0x6e, 0x1f, 0x42, 0x73, 0x2a, 0xd2, 0xeb, 0xbb, 0x0c, 0xb3, 0x60, 0xeb, 0x63, 0x76,
+	0x75, 0x34, 0x9f, 0x27, 0x56, 0x55, 0x2b, 0x73, 0x68, 0x3f, 0x83, 0xb6, 0xeb, 0x87, 0x0b, 0x2e,
+	0xe3, 0xe5, 0x8a, 0x3d, 0x86, 0x66, 0xaa, 0x84, 0xca, 0x52, 0xb3, 0xd4, 0x20, 0x7b, 0x1f, 0x5a,
+	0x03, 0xd7, 0xf3, 0x52, 0xb1, 0x90, 0x6c, 0x17, 0x1a, 0x2a, 0x52, 0x62, 0xa9, 0x4d, 0xea, 0x9c,
+	0x80, 0xfd, 0x1c, 0x3a, 0x17, 0x32, 0x88, 0x92, 0x15, 0x19, 0xed, 0x41, 0x2b, 0x10, 0xb7, 0x5a,
+	0x36, 0x76, 0x05, 0xb6, 0xff, 0xaa, 0x40, 0xd7, 0x91, 0xea, 0x53, 0x94, 0x5c, 0x93, 0xb1, 0x05,
+	0x5b, 0xea, 0xf6, 0x78, 0xa5, 0x64, 0x6a, 0x6c, 0x73, 0x88, 0x4c, 0x62, 0x98, 0x2a, 0x31, 0x06,
+	0xb2, 0x27, 0xd0, 0x56, 0xb7, 0xae, 0x98, 0x5d, 0x4b, 0x95, 0x5a, 0x35, 0xcd, 0xad, 0x15, 0xc8,
+	0x26, 0x05, 0x5b, 0x27, 0xb6, 0x50, 0xa0, 0x73, 0xea, 0x76, 0x94, 0x24, 0x51, 0x92, 0x5a, 0x0d,
+	0x72, 0x2e, 0xc7, 0xc8, 0x25, 0x39, 0xd7, 0x24, 0x2e, 0xc7, 0xf4, 0xcd, 0x61, 0x12, 0xc5, 0xb1,
+	0x9c, 0x5b, 0x5b, 0xf9, 0x37, 0x8d, 0x82, 0xbe, 0x99, 0xb3, 0xad, 0xfc, 0x9b, 0x46, 0x61, 0xff,
+	0x59, 0x81, 0x6d, 0x2e, 0xd3, 0x28, 0x4b, 0x66, 0x92, 0xa2, 0xde, 0x87, 0xda, 0x2c, 0xce, 0x74,
+	0xc4, 0x9d, 0xc3, 0x9d, 0x3e, 0xd6, 0xbc, 0x9f, 0x27, 0x99, 0x23, 0xc5, 0x9e, 0x43, 0x33, 0xd0,
+	0x39, 0xd5, 0xc1, 0x77, 0x0e, 0x1f, 0x91, 0x51, 0x29, 0xcf, 0xdc, 0x18, 0xb0, 0xb7, 0xb0, 0x15,
+	0x52, 0x4a, 0xad, 0xda, 0x7e, 0xed, 0xa0, 0x73, 0xb8, 0x4f, 0xb6, 0x1b, 0x9f, 0xec, 0x9b, 0xac,
+	0x8f, 0x42, 0x95, 0xac, 0x78, 0xbe, 0x60, 0xcf, 0x81, 0x6e, 0x99, 0x60, 0x3d, 0xa8, 0x5d, 0xcb,
+	0x95, 0x39, 0x01, 0x28, 0xb2, 0x03, 0x68, 0xdc, 0x88, 0x65, 0x26, 0x8d, 0x1f, 0x8c, 0xf6, 0x2e,
+	0xd7, 0x90, 0x93, 0xc1, 0xdb, 0xea, 0x9b, 0x8a, 0xfd, 0x4b, 0x15, 0xda, 0xe3, 0xf0, 0x87, 0x88,
+	0x8e, 0xd4, 0x2b, 0x68, 0x64, 0xe6, 0x18, 0xa0, 0x5f, 0x7b, 0xb4, 0xb6, 0xe0, 0xfb, 0x7a, 0x39,
+	0x79, 0x44, 0x86, 0x8c, 0x41, 0x3d, 0x14, 0x81, 0x34, 0x07, 0x55, 0xcb, 0xec, 0x35, 0x74, 0xcb,
+	0xed, 0x60, 0xd5, 0xca, 0x8e, 0x0c, 0x4a, 0x0c, 0xdf, 0xb0, 0xc3, 0x75, 0x8b, 0x38, 0x9b, 0xca,
+	0xa5, 0x0c, 0xa4, 0x4a, 0x56, 0x56, 0xbd, 0xbc, 0xee, 0xc4, 0xf5, 0x0a, 0x86, 0x6f, 0xd8, 0xed,
+	0x5d, 0x00, 0xac, 0x1d, 0xbb, 0x27, 0x23, 0xcf, 0x37, 0x33, 0xf2, 0xaf, 0x7b, 0xb2, 0x5d, 0x4e,
+	0xc9, 0x1f, 0x35, 0x78, 0x88, 0x9d, 0x79, 0xa9, 0xdb, 0x89, 0x12, 0xf3, 0xe5, 0x46, 0xaf, 0xed,
+	0x1c, 0x3e, 0xa1, 0x3d, 0x3e, 0x33, 0xeb, 0x1b, 0xd9, 0xd8, 0xe2, 0x29, 0xf3, 0x03, 0xb1, 0x90,
+	0xce, 0x3a, 0x43, 0x6b, 0x05, 0xf6, 0x66, 0x1c, 0x25, 0xa6, 0x23, 0xda, 0x9c, 0x00, 0x76, 0x75,
+	0x16, 0x2b, 0x3f, 0x90, 0xa6, 0x15, 0x0c, 0xc2, 0x20, 0xa8, 0x34, 0x8d, 0x7f, 0x08, 0x82, 0x6a,
+	0x72, 0x0a, 0x4c, 0xdc, 0x08, 0x7f, 0x29, 0xae, 0x96, 0x32, 0x37, 0xa0, 0x06, 0xe9, 0x1c, 0x5a,
+	0xb4, 0xee, 0xe8, 0x0e, 0xcf, 0xef, 0x59, 0x83, 0x2d, 0x1d, 0xf8, 0xa1, 0x4c, 0xc6, 0x43, 0xdd,
+	0x42, 0x6d, 0x9e, 0x43, 0xdd, 0x7a, 0x32, 0x55, 0x02, 0xfd, 0xc7, 0xfe, 0xd9, 0xe6, 0x05, 0x46,
+	0x4e, 0xde, 0xfa, 0x6a, 0x10, 0xcd, 0xa5, 0xd5, 0xde, 0xaf, 0x1c, 0x34, 0x78, 0x81, 0xed, 0xef,
+	0xa0, 0x49, 0x49, 0x62, 0x1d, 0xd8, 0xf2, 0x9c, 0x33, 0x67, 0xf2, 0xde, 0xe9, 0x3d, 0x60, 0x5d,
+	0x68, 0x5d, 0xba, 0x93, 0xc9, 0xf9, 0xd8, 0x39, 0xe9, 0x55, 0x08, 0x1d, 0xbd, 0x77, 0x10, 0x55,
+	0xd1, 0x90, 0x7b, 0x8e, 0x06, 0x35, 0xa4, 0xde, 0x8d, 0x9d, 0xf1, 0xe5, 0xe9, 0x68, 0xd8, 0xab,
+	0x33, 0x80, 0xe6, 0x31, 0x9f, 0x9c, 0x8d, 0x9c, 0x5e, 0xc3, 0xfe, 0xad, 0x0e, 0xec, 0xe8, 0xde,
+	0x10, 0xc2, 0x2c, 0x18, 0xb8, 0x1e, 0x95, 0xae, 0xc6, 0x73, 0x68, 0x98, 0x13, 0x64, 0xaa, 0x05,
+	0x83, 0x10, 0x6b, 0x60, 0x7a, 0x99, 0x86, 0x95, 0x41, 0x58, 0xcf, 0x81, 0xeb, 0xb9, 0x32, 0xf1,
+	0xa3, 0xb9, 0x2e, 0x4f, 0x8d, 0xaf, 0x15, 0x18, 0xf6, 0xc0, 0xf5, 0xbe, 0xcd, 0x22, 0x25, 0x74,
+	0x91, 0x6a, 0xbc, 0xc0, 0xec, 0x25, 0x3c, 0x1a, 0xb8, 0x1e, 0x97, 0x62, 0x89, 0xc5, 0x34, 0x3b,
+	0x34, 0xb5, 0xd1, 0x5d, 0x82, 0xf5, 0x81, 0x95, 0x94, 0x3c, 0x0b, 0xf1, 0x4f, 0x57, 0xa0, 0xc6,
+	0xef, 0x61, 0xd8, 0x53, 0x80, 0x41, 0x9c, 0xa5, 0x52, 0xe1, 0xaf, 0x2e, 0x47, 0x9b, 0x97, 0x34,
+	0x6b, 0xfe, 0x42, 0x06, 0xa9, 0xd5, 0x2e, 0xf3, 0xa8, 0xc1, 0xb8, 0x86, 0x7e, 0x7a, 0x4d, 0xae,
+	0x03, 0xc5, 0x55, 0x28, 0x98, 0x0d, 0xdd, 0x33, 0x99, 0x84, 0x72, 0x49, 0xb3, 0xcc, 0xea, 0x68,
+	0x83, 0x0d, 0x1d, 0xc6, 0x47, 0x12, 0x97, 0xa9, 0x4c, 0x6e, 0x84, 0xf2, 0xa3, 0xd0, 0xea, 0x52,
+	0x7c, 0x77, 0x08, 0xf4, 0x87, 0x94, 0x97, 0x9f, 0x44, 0x6c, 0x6d, 0x6b, 0xb3, 0x92, 0x06, 0xfd,
+	0x71, 0xfd, 0x79, 0x7a, 0xee, 0x07, 0xbe, 0xb2, 0x76, 0xc8, 0x9f, 0x42, 0x81, 0xd5, 0x99, 0x2d,
+	0x92, 0x28, 0x8b, 0xad, 0x87, 0x74, 0xef, 0x11, 0x42, 0x3f, 0x49, 0x72, 0x45, 0x22, 0x43, 0x65,
+	0xf5, 0x34, 0xbb, 0xa1, 0xb3, 0x7f, 0xaf, 0xc0, 0x0e, 0x9d, 0xbf, 0x0b, 0x11, 0x53, 0x6b, 0x7f,
+	0x03, 0x2d, 0x6a, 0x57, 0x99, 0x9a, 0xb1, 0x67, 0x53, 0x8f, 0x6c, 0xda, 0x19, 0x28, 0x53, 0x1a,
+	0x7f, 0xc5, 0x9a, 0x3d, 0x0e, 0xdb, 0x1b, 0xd4, 0x3d, 0x03, 0xe8, 0xff, 0x9b, 0x03, 0xe8, 0xdf,
+	0xf7, 0x0e, 0x8f, 0xf2, 0x08, 0xfa, 0x1e, 0x1e, 0x0f, 0xa2, 0x50, 0x09, 0x6c, 0x36, 0x4e, 0x6d,
+	0xe5, 0x46, 0x4b, 0x7f, 0xb6, 0x2a, 0xe6, 0x6d, 0xa5, 0x34, 0x6f, 0x5f, 0xc2, 0xa3, 0x40, 0xdc,
+	0xfa, 0x41, 0x16, 0x70, 0x9c, 0x87, 0x83, 0x28, 0x0b, 0x95, 0xfe, 0xd4, 0x36, 0xbf, 0x4b, 0xd8,
+	0xbf, 0x56, 0x69, 0xbc, 0x9d, 0x47, 0x8b, 0x94, 0xcb, 0x1f, 0x33, 0x99, 0x2a, 0xd6, 0x87, 0xba,
+	0x5a, 0xc5, 0xd2, 0x0c, 0xb7, 0xbd, 0xb5, 0x7f, 0x25, 0xa3, 0xfe, 0x74, 0x15, 0x4b, 0xae, 0xed,
+	0xcc, 0x8b, 0xa5, 0x5a, 0xbc, 0x58, 0x76, 0xa1, 0x91, 0xfa, 0xe1, 0x4c, 0xe6, 0xa3, 0x4c, 0x03,
+	0xf6, 0x5f, 0xd8, 0x16, 0xf3, 0xf9, 0xd4, 0x0f, 0x30, 0x82, 0x20, 0xa6, 0xcb, 0xbd, 0xc5, 0x37,
+	0x95, 0x58, 0xce, 0x77, 0xd1, 0x72, 0x19, 0x7d, 0xd2, 0x4d, 0xd3, 0xe2, 0x06, 0x61, 0xa4, 0x53,
+	0xe1, 0x2f, 0x75, 0x97, 0xb4, 0xb9, 0x96, 0xb1, 0x65, 0x87, 0x52, 0x09, 0x7f, 0x99, 0xea, 0x6e,
+	0x68, 0xf1, 0x1c, 0x96, 0xdf, 0x4c, 0xad, 0xcd, 0x37, 0xd3, 0x01, 0xd4, 0xd1, 0x73, 0x9c, 0x15,
+	0x97, 0xd3, 0xe1, 0xc4, 0x9b, 0xf6, 0x1e, 0x18, 0x79, 0xc4, 0x79, 0xaf, 0xc2, 0x5a, 0x50, 0x3f,
+	0x9e, 0x4c, 0x4f, 0x7b, 0x55, 0xfb, 0x19, 0x6c, 0xe7, 0x31, 0x0f, 0x3e, 0x66, 0xe1, 0x35, 0xba,
+	0x30, 0x17, 0x4a, 0xe8, 0xb4, 0x74, 0xb9, 0x96, 0xed, 0x57, 0xc0, 0x86, 0x7e, 0x3a, 0x8b, 0x6e,
+	0x64, 0x72, 0x9a, 0x5d, 0xe5, 0x09, 0xc4, 0x91, 0x17, 0xce, 0xe3, 0xc8, 0x0f, 0x95, 0x29, 0x4d,
+	0x81, 0xed, 0x9f, 0x2b, 0x60, 0xe1, 0xbe, 0xf9, 0x4c, 0xc2, 0x35, 0x7e, 0x22, 0x03, 0x19, 0xd2,
+	0xac, 0x1c, 0xb8, 0xde, 0x20, 0x4a, 0x8a, 0xf7, 0x54, 0x81, 0xb1, 0x0d, 0x02, 0x71, 0x7b, 0xb1,
+	0x7e, 0x55, 0xd4, 0xf8, 0x5a, 0xc1, 0xfa, 0x00, 0x27, 0xae, 0x77, 0x99, 0xc5, 0x78, 0x6f, 0xe8,
+	0xc4, 0xef, 0xe4, 0x2f, 0x93, 0x13, 0xdc, 0x21, 0x0b, 0x15, 0x2f, 0x59, 0xd8, 0x5f, 0x43, 0xbb,
+	0xc8, 0x3a, 0xa6, 0x2b, 0x95, 0xb3, 0x28, 0x9c, 0x17, 0x53, 0xd1, 0x40, 0x2c, 0x65, 0x28, 0xc2,
+	0x88, 0x66, 0x62, 0x83, 0x13, 0xb0, 0xff, 0x03, 0x0d, 0x4a, 0xc9, 0x2e, 0x34, 0x66, 0x28, 0x98,
+	0x9c, 0x10, 0xb0, 0x9f, 0x42, 0xcb, 0x4d, 0xa2, 0x45, 0x22, 0xd3, 0x14, 0x93, 0x96, 0xfa, 0x3f,
+	0x49, 0xb3, 0xaf, 0x96, 0xed, 0xd7, 0xd0, 0x2d, 0xdf, 0xdf, 0xec, 0x7f, 0xb0, 0x93, 0x48, 0xf4,
+	0xca, 0x0f, 0x17, 0x74, 0x5c, 0x2b, 0xfa, 0xb8, 0x7e, 0xa6, 0x7d, 0xf1, 0x15, 0x74, 0xcc, 0xc3,
+	0x65, 0x4a, 0xc7, 0x0e, 0x9c, 0xc9, 0x07, 0x67, 0x34, 0x7d, 0x3f, 0xe1, 0x67, 0x74, 0x6b, 0x4c,
+	0xbc, 0xe9, 0xf1, 0xc4, 0x73, 0x86, 0x74, 0x6b, 0x8c, 0x9d, 0xc1, 0xe4, 0x42, 0xdf, 0x1a, 0x2f,
+	0xde, 0x40, 0x2b, 0x4f, 0x03, 0x96, 0xdb, 0x99, 0x7c, 0x38, 0x71, 0xbd, 0xde, 0x03, 0xdc, 0xe3,
+	0x72, 0xec, 0x9c, 0x9c, 0x8f, 0x34, 0xae, 0xb0, 0x1e, 0x74, 0x2f, 0xbc, 0xf3, 0xe9, 0xd8, 0x35,
+	0x9a, 0xea, 0x55, 0x53, 0x3f, 0xdf, 0xbf, 0xf8, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xf6, 0xa0, 0xa1,
+	0x57, 0xeb, 0x0b, 0x00, 0x00,
 }
@@ -20,6 +20,13 @@ var _ = math.Inf
 type AnnounceRequest struct {
 	// todo: remove repeated
 	IpAddr []string `protobuf:"bytes,2,rep,name=ipAddr" json:"ipAddr,omitempty"`
+	// tags are coarse node capabilities, like "gpu" or "eu-region".
+	Tags []string `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+	// protocolVersion is the version of the protocol the node speaks.
+	ProtocolVersion uint32 `protobuf:"varint,4,opt,name=protocolVersion" json:"protocolVersion,omitempty"`
+	// ttlSeconds overrides the locator's node TTL for this announce, zero
+	// means the default one.
+	TtlSeconds uint64 `protobuf:"varint,5,opt,name=ttlSeconds" json:"ttlSeconds,omitempty"`
 }
 
 func (m *AnnounceRequest) Reset()                    { *m = AnnounceRequest{} }
@@ -34,8 +41,34 @@ func (m *AnnounceRequest) GetIpAddr() []string {
 	return nil
 }
 
+func (m *AnnounceRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *AnnounceRequest) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *AnnounceRequest) GetTtlSeconds() uint64 {
+	if m != nil {
+		return m.TtlSeconds
+	}
+	return 0
+}
+
 type ResolveRequest struct {
 	EthAddr string `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
+	// tags, when not empty, restrict resolving to nodes having all of them.
+	Tags []string `protobuf:"bytes,2,rep,name=tags" json:"tags,omitempty"`
+	// minProtocolVersion, when not zero, restricts resolving to nodes
+	// speaking at least this protocol version.
+	MinProtocolVersion uint32 `protobuf:"varint,3,opt,name=minProtocolVersion" json:"minProtocolVersion,omitempty"`
 }
 
 func (m *ResolveRequest) Reset()                    { *m = ResolveRequest{} }
@@ -50,8 +83,26 @@ func (m *ResolveRequest) GetEthAddr() string {
 	return ""
 }
 
+func (m *ResolveRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ResolveRequest) GetMinProtocolVersion() uint32 {
+	if m != nil {
+		return m.MinProtocolVersion
+	}
+	return 0
+}
+
 type ResolveReply struct {
 	IpAddr []string `protobuf:"bytes,1,rep,name=ipAddr" json:"ipAddr,omitempty"`
+	// cacheTTLSeconds hints how long the result may be cached by clients.
+	CacheTTLSeconds uint64 `protobuf:"varint,2,opt,name=cacheTTLSeconds" json:"cacheTTLSeconds,omitempty"`
+	// ageSeconds is how long ago the node has announced itself.
+	AgeSeconds uint64 `protobuf:"varint,3,opt,name=ageSeconds" json:"ageSeconds,omitempty"`
 }
 
 func (m *ResolveReply) Reset()                    { *m = ResolveReply{} }
@@ -66,10 +117,399 @@ func (m *ResolveReply) GetIpAddr() []string {
 	return nil
 }
 
+func (m *ResolveReply) GetCacheTTLSeconds() uint64 {
+	if m != nil {
+		return m.CacheTTLSeconds
+	}
+	return 0
+}
+
+func (m *ResolveReply) GetAgeSeconds() uint64 {
+	if m != nil {
+		return m.AgeSeconds
+	}
+	return 0
+}
+
+// SignedAnnounce is an announce of a single worker, signed by the worker's
+// own key, because the connection is authenticated by the agent's key.
+type SignedAnnounce struct {
+	EthAddr string   `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
+	IpAddr  []string `protobuf:"bytes,2,rep,name=ipAddr" json:"ipAddr,omitempty"`
+	// timestamp is a unix time the announce was signed at.
+	Timestamp int64  `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Signature []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	// tags, protocolVersion and ttlSeconds are the same as in
+	// AnnounceRequest, and are signed as well.
+	Tags            []string `protobuf:"bytes,5,rep,name=tags" json:"tags,omitempty"`
+	ProtocolVersion uint32   `protobuf:"varint,6,opt,name=protocolVersion" json:"protocolVersion,omitempty"`
+	TtlSeconds      uint64   `protobuf:"varint,7,opt,name=ttlSeconds" json:"ttlSeconds,omitempty"`
+}
+
+func (m *SignedAnnounce) Reset()                    { *m = SignedAnnounce{} }
+func (m *SignedAnnounce) String() string            { return proto.CompactTextString(m) }
+func (*SignedAnnounce) ProtoMessage()               {}
+func (*SignedAnnounce) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{3} }
+
+func (m *SignedAnnounce) GetEthAddr() string {
+	if m != nil {
+		return m.EthAddr
+	}
+	return ""
+}
+
+func (m *SignedAnnounce) GetIpAddr() []string {
+	if m != nil {
+		return m.IpAddr
+	}
+	return nil
+}
+
+func (m *SignedAnnounce) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *SignedAnnounce) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *SignedAnnounce) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *SignedAnnounce) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *SignedAnnounce) GetTtlSeconds() uint64 {
+	if m != nil {
+		return m.TtlSeconds
+	}
+	return 0
+}
+
+type AnnounceBatchRequest struct {
+	Announces []*SignedAnnounce `protobuf:"bytes,1,rep,name=announces" json:"announces,omitempty"`
+}
+
+func (m *AnnounceBatchRequest) Reset()                    { *m = AnnounceBatchRequest{} }
+func (m *AnnounceBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*AnnounceBatchRequest) ProtoMessage()               {}
+func (*AnnounceBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{4} }
+
+func (m *AnnounceBatchRequest) GetAnnounces() []*SignedAnnounce {
+	if m != nil {
+		return m.Announces
+	}
+	return nil
+}
+
+type AnnounceResult struct {
+	EthAddr string `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
+	// error is empty if the announce has been accepted.
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *AnnounceResult) Reset()                    { *m = AnnounceResult{} }
+func (m *AnnounceResult) String() string            { return proto.CompactTextString(m) }
+func (*AnnounceResult) ProtoMessage()               {}
+func (*AnnounceResult) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{5} }
+
+func (m *AnnounceResult) GetEthAddr() string {
+	if m != nil {
+		return m.EthAddr
+	}
+	return ""
+}
+
+func (m *AnnounceResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type AnnounceBatchReply struct {
+	Results []*AnnounceResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *AnnounceBatchReply) Reset()                    { *m = AnnounceBatchReply{} }
+func (m *AnnounceBatchReply) String() string            { return proto.CompactTextString(m) }
+func (*AnnounceBatchReply) ProtoMessage()               {}
+func (*AnnounceBatchReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{6} }
+
+func (m *AnnounceBatchReply) GetResults() []*AnnounceResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type ResolvePrefixRequest struct {
+	// prefix is a hex address prefix, "0x" is optional.
+	Prefix string `protobuf:"bytes,1,opt,name=prefix" json:"prefix,omitempty"`
+}
+
+func (m *ResolvePrefixRequest) Reset()                    { *m = ResolvePrefixRequest{} }
+func (m *ResolvePrefixRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResolvePrefixRequest) ProtoMessage()               {}
+func (*ResolvePrefixRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{7} }
+
+func (m *ResolvePrefixRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+type ResolvedNode struct {
+	EthAddr string   `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
+	IpAddr  []string `protobuf:"bytes,2,rep,name=ipAddr" json:"ipAddr,omitempty"`
+}
+
+func (m *ResolvedNode) Reset()                    { *m = ResolvedNode{} }
+func (m *ResolvedNode) String() string            { return proto.CompactTextString(m) }
+func (*ResolvedNode) ProtoMessage()               {}
+func (*ResolvedNode) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{8} }
+
+func (m *ResolvedNode) GetEthAddr() string {
+	if m != nil {
+		return m.EthAddr
+	}
+	return ""
+}
+
+func (m *ResolvedNode) GetIpAddr() []string {
+	if m != nil {
+		return m.IpAddr
+	}
+	return nil
+}
+
+type ResolvePrefixReply struct {
+	Nodes []*ResolvedNode `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	// ambiguous is true when more than one node matches the prefix.
+	Ambiguous bool `protobuf:"varint,2,opt,name=ambiguous" json:"ambiguous,omitempty"`
+	// truncated is true when there are more matching nodes than returned.
+	Truncated bool `protobuf:"varint,3,opt,name=truncated" json:"truncated,omitempty"`
+}
+
+func (m *ResolvePrefixReply) Reset()                    { *m = ResolvePrefixReply{} }
+func (m *ResolvePrefixReply) String() string            { return proto.CompactTextString(m) }
+func (*ResolvePrefixReply) ProtoMessage()               {}
+func (*ResolvePrefixReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{9} }
+
+func (m *ResolvePrefixReply) GetNodes() []*ResolvedNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *ResolvePrefixReply) GetAmbiguous() bool {
+	if m != nil {
+		return m.Ambiguous
+	}
+	return false
+}
+
+func (m *ResolvePrefixReply) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+type ResolveBatchRequest struct {
+	EthAddrs []string `protobuf:"bytes,1,rep,name=ethAddrs" json:"ethAddrs,omitempty"`
+}
+
+func (m *ResolveBatchRequest) Reset()                    { *m = ResolveBatchRequest{} }
+func (m *ResolveBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResolveBatchRequest) ProtoMessage()               {}
+func (*ResolveBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{10} }
+
+func (m *ResolveBatchRequest) GetEthAddrs() []string {
+	if m != nil {
+		return m.EthAddrs
+	}
+	return nil
+}
+
+type ResolveBatchResult struct {
+	// reply is empty if the node cannot be resolved.
+	Reply *ResolveReply `protobuf:"bytes,1,opt,name=reply" json:"reply,omitempty"`
+	Error string        `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ResolveBatchResult) Reset()                    { *m = ResolveBatchResult{} }
+func (m *ResolveBatchResult) String() string            { return proto.CompactTextString(m) }
+func (*ResolveBatchResult) ProtoMessage()               {}
+func (*ResolveBatchResult) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{11} }
+
+func (m *ResolveBatchResult) GetReply() *ResolveReply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (m *ResolveBatchResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ResolveBatchReply struct {
+	// results are keyed by the requested addresses as is.
+	Results map[string]*ResolveBatchResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ResolveBatchReply) Reset()                    { *m = ResolveBatchReply{} }
+func (m *ResolveBatchReply) String() string            { return proto.CompactTextString(m) }
+func (*ResolveBatchReply) ProtoMessage()               {}
+func (*ResolveBatchReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{12} }
+
+func (m *ResolveBatchReply) GetResults() map[string]*ResolveBatchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type ReverseResolveRequest struct {
+	// ip is a bare IP address, a port, if given, is ignored.
+	Ip string `protobuf:"bytes,1,opt,name=ip" json:"ip,omitempty"`
+}
+
+func (m *ReverseResolveRequest) Reset()                    { *m = ReverseResolveRequest{} }
+func (m *ReverseResolveRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReverseResolveRequest) ProtoMessage()               {}
+func (*ReverseResolveRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{13} }
+
+func (m *ReverseResolveRequest) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+type ReverseResolveReply struct {
+	// ethAddrs are all nodes that have announced the IP, sorted.
+	EthAddrs []string `protobuf:"bytes,1,rep,name=ethAddrs" json:"ethAddrs,omitempty"`
+}
+
+func (m *ReverseResolveReply) Reset()                    { *m = ReverseResolveReply{} }
+func (m *ReverseResolveReply) String() string            { return proto.CompactTextString(m) }
+func (*ReverseResolveReply) ProtoMessage()               {}
+func (*ReverseResolveReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{14} }
+
+func (m *ReverseResolveReply) GetEthAddrs() []string {
+	if m != nil {
+		return m.EthAddrs
+	}
+	return nil
+}
+
+type ReportReachableRequest struct {
+	EthAddr string `protobuf:"bytes,1,opt,name=ethAddr" json:"ethAddr,omitempty"`
+	// ipAddr is the reachable address exactly as announced by the node.
+	IpAddr string `protobuf:"bytes,2,opt,name=ipAddr" json:"ipAddr,omitempty"`
+}
+
+func (m *ReportReachableRequest) Reset()                    { *m = ReportReachableRequest{} }
+func (m *ReportReachableRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReportReachableRequest) ProtoMessage()               {}
+func (*ReportReachableRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{15} }
+
+func (m *ReportReachableRequest) GetEthAddr() string {
+	if m != nil {
+		return m.EthAddr
+	}
+	return ""
+}
+
+func (m *ReportReachableRequest) GetIpAddr() string {
+	if m != nil {
+		return m.IpAddr
+	}
+	return ""
+}
+
+type LocatorStatsReply struct {
+	// nodes is the number of registered nodes.
+	Nodes uint64 `protobuf:"varint,1,opt,name=nodes" json:"nodes,omitempty"`
+	// oldestAnnounce and newestAnnounce are empty when there are no nodes.
+	OldestAnnounce *Timestamp `protobuf:"bytes,2,opt,name=oldestAnnounce" json:"oldestAnnounce,omitempty"`
+	NewestAnnounce *Timestamp `protobuf:"bytes,3,opt,name=newestAnnounce" json:"newestAnnounce,omitempty"`
+	// nodeTTLSeconds is the TTL of nodes that have not announced their own.
+	NodeTTLSeconds uint64 `protobuf:"varint,4,opt,name=nodeTTLSeconds" json:"nodeTTLSeconds,omitempty"`
+}
+
+func (m *LocatorStatsReply) Reset()                    { *m = LocatorStatsReply{} }
+func (m *LocatorStatsReply) String() string            { return proto.CompactTextString(m) }
+func (*LocatorStatsReply) ProtoMessage()               {}
+func (*LocatorStatsReply) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{16} }
+
+func (m *LocatorStatsReply) GetNodes() uint64 {
+	if m != nil {
+		return m.Nodes
+	}
+	return 0
+}
+
+func (m *LocatorStatsReply) GetOldestAnnounce() *Timestamp {
+	if m != nil {
+		return m.OldestAnnounce
+	}
+	return nil
+}
+
+func (m *LocatorStatsReply) GetNewestAnnounce() *Timestamp {
+	if m != nil {
+		return m.NewestAnnounce
+	}
+	return nil
+}
+
+func (m *LocatorStatsReply) GetNodeTTLSeconds() uint64 {
+	if m != nil {
+		return m.NodeTTLSeconds
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*AnnounceRequest)(nil), "sonm.AnnounceRequest")
 	proto.RegisterType((*ResolveRequest)(nil), "sonm.ResolveRequest")
 	proto.RegisterType((*ResolveReply)(nil), "sonm.ResolveReply")
+	proto.RegisterType((*SignedAnnounce)(nil), "sonm.SignedAnnounce")
+	proto.RegisterType((*AnnounceBatchRequest)(nil), "sonm.AnnounceBatchRequest")
+	proto.RegisterType((*AnnounceResult)(nil), "sonm.AnnounceResult")
+	proto.RegisterType((*AnnounceBatchReply)(nil), "sonm.AnnounceBatchReply")
+	proto.RegisterType((*ResolvePrefixRequest)(nil), "sonm.ResolvePrefixRequest")
+	proto.RegisterType((*ResolvedNode)(nil), "sonm.ResolvedNode")
+	proto.RegisterType((*ResolvePrefixReply)(nil), "sonm.ResolvePrefixReply")
+	proto.RegisterType((*ResolveBatchRequest)(nil), "sonm.ResolveBatchRequest")
+	proto.RegisterType((*ResolveBatchResult)(nil), "sonm.ResolveBatchResult")
+	proto.RegisterType((*ResolveBatchReply)(nil), "sonm.ResolveBatchReply")
+	proto.RegisterType((*ReverseResolveRequest)(nil), "sonm.ReverseResolveRequest")
+	proto.RegisterType((*ReverseResolveReply)(nil), "sonm.ReverseResolveReply")
+	proto.RegisterType((*ReportReachableRequest)(nil), "sonm.ReportReachableRequest")
+	proto.RegisterType((*LocatorStatsReply)(nil), "sonm.LocatorStatsReply")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -85,6 +525,22 @@ const _ = grpc.SupportPackageIsVersion4
 type LocatorClient interface {
 	Announce(ctx context.Context, in *AnnounceRequest, opts ...grpc.CallOption) (*Empty, error)
 	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveReply, error)
+	// AnnounceBatch announces several workers at once, for example when
+	// they are managed by a single agent.
+	AnnounceBatch(ctx context.Context, in *AnnounceBatchRequest, opts ...grpc.CallOption) (*AnnounceBatchReply, error)
+	// ResolvePrefix resolves all nodes whose address starts with the given
+	// hex prefix.
+	ResolvePrefix(ctx context.Context, in *ResolvePrefixRequest, opts ...grpc.CallOption) (*ResolvePrefixReply, error)
+	// ResolveBatch resolves several nodes at once. Unknown nodes do not
+	// fail the whole batch.
+	ResolveBatch(ctx context.Context, in *ResolveBatchRequest, opts ...grpc.CallOption) (*ResolveBatchReply, error)
+	// ReverseResolve finds nodes which have announced the given IP.
+	ReverseResolve(ctx context.Context, in *ReverseResolveRequest, opts ...grpc.CallOption) (*ReverseResolveReply, error)
+	// ReportReachable reports that a node has been successfully connected
+	// to at one of its announced IPs, so resolves list that IP first.
+	ReportReachable(ctx context.Context, in *ReportReachableRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Stats reports the size and the freshness of the node db.
+	Stats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LocatorStatsReply, error)
 }
 
 type locatorClient struct {
@@ -113,11 +569,81 @@ func (c *locatorClient) Resolve(ctx context.Context, in *ResolveRequest, opts ..
 	return out, nil
 }
 
+func (c *locatorClient) AnnounceBatch(ctx context.Context, in *AnnounceBatchRequest, opts ...grpc.CallOption) (*AnnounceBatchReply, error) {
+	out := new(AnnounceBatchReply)
+	err := grpc.Invoke(ctx, "/sonm.Locator/AnnounceBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locatorClient) ResolvePrefix(ctx context.Context, in *ResolvePrefixRequest, opts ...grpc.CallOption) (*ResolvePrefixReply, error) {
+	out := new(ResolvePrefixReply)
+	err := grpc.Invoke(ctx, "/sonm.Locator/ResolvePrefix", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locatorClient) ResolveBatch(ctx context.Context, in *ResolveBatchRequest, opts ...grpc.CallOption) (*ResolveBatchReply, error) {
+	out := new(ResolveBatchReply)
+	err := grpc.Invoke(ctx, "/sonm.Locator/ResolveBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locatorClient) ReverseResolve(ctx context.Context, in *ReverseResolveRequest, opts ...grpc.CallOption) (*ReverseResolveReply, error) {
+	out := new(ReverseResolveReply)
+	err := grpc.Invoke(ctx, "/sonm.Locator/ReverseResolve", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locatorClient) ReportReachable(ctx context.Context, in *ReportReachableRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.Locator/ReportReachable", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locatorClient) Stats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LocatorStatsReply, error) {
+	out := new(LocatorStatsReply)
+	err := grpc.Invoke(ctx, "/sonm.Locator/Stats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Locator service
 
 type LocatorServer interface {
 	Announce(context.Context, *AnnounceRequest) (*Empty, error)
 	Resolve(context.Context, *ResolveRequest) (*ResolveReply, error)
+	// AnnounceBatch announces several workers at once, for example when
+	// they are managed by a single agent.
+	AnnounceBatch(context.Context, *AnnounceBatchRequest) (*AnnounceBatchReply, error)
+	// ResolvePrefix resolves all nodes whose address starts with the given
+	// hex prefix.
+	ResolvePrefix(context.Context, *ResolvePrefixRequest) (*ResolvePrefixReply, error)
+	// ResolveBatch resolves several nodes at once. Unknown nodes do not
+	// fail the whole batch.
+	ResolveBatch(context.Context, *ResolveBatchRequest) (*ResolveBatchReply, error)
+	// ReverseResolve finds nodes which have announced the given IP.
+	ReverseResolve(context.Context, *ReverseResolveRequest) (*ReverseResolveReply, error)
+	// ReportReachable reports that a node has been successfully connected
+	// to at one of its announced IPs, so resolves list that IP first.
+	ReportReachable(context.Context, *ReportReachableRequest) (*Empty, error)
+	// Stats reports the size and the freshness of the node db.
+	Stats(context.Context, *Empty) (*LocatorStatsReply, error)
 }
 
 func RegisterLocatorServer(s *grpc.Server, srv LocatorServer) {
@@ -160,6 +686,114 @@ func _Locator_Resolve_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Locator_AnnounceBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnounceBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocatorServer).AnnounceBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Locator/AnnounceBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocatorServer).AnnounceBatch(ctx, req.(*AnnounceBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locator_ResolvePrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolvePrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocatorServer).ResolvePrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Locator/ResolvePrefix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocatorServer).ResolvePrefix(ctx, req.(*ResolvePrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locator_ResolveBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocatorServer).ResolveBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Locator/ResolveBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocatorServer).ResolveBatch(ctx, req.(*ResolveBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locator_ReverseResolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseResolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocatorServer).ReverseResolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Locator/ReverseResolve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocatorServer).ReverseResolve(ctx, req.(*ReverseResolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locator_ReportReachable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportReachableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocatorServer).ReportReachable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Locator/ReportReachable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocatorServer).ReportReachable(ctx, req.(*ReportReachableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locator_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocatorServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Locator/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocatorServer).Stats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Locator_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.Locator",
 	HandlerType: (*LocatorServer)(nil),
@@ -172,6 +806,30 @@ var _Locator_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Resolve",
 			Handler:    _Locator_Resolve_Handler,
 		},
+		{
+			MethodName: "AnnounceBatch",
+			Handler:    _Locator_AnnounceBatch_Handler,
+		},
+		{
+			MethodName: "ResolvePrefix",
+			Handler:    _Locator_ResolvePrefix_Handler,
+		},
+		{
+			MethodName: "ResolveBatch",
+			Handler:    _Locator_ResolveBatch_Handler,
+		},
+		{
+			MethodName: "ReverseResolve",
+			Handler:    _Locator_ReverseResolve_Handler,
+		},
+		{
+			MethodName: "ReportReachable",
+			Handler:    _Locator_ReportReachable_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _Locator_Stats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "locator.proto",
@@ -180,18 +838,56 @@ var _Locator_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("locator.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 195 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcd, 0xc9, 0x4f, 0x4e,
-	0x2c, 0xc9, 0x2f, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x29, 0xce, 0xcf, 0xcb, 0x95,
-	0xe2, 0xcf, 0xcc, 0x03, 0xd1, 0x79, 0x99, 0x89, 0x10, 0x61, 0x25, 0x4d, 0x2e, 0x7e, 0xc7, 0xbc,
-	0xbc, 0xfc, 0xd2, 0xbc, 0xe4, 0xd4, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12, 0x21, 0x31, 0x2e,
-	0xb6, 0xcc, 0x02, 0xc7, 0x94, 0x94, 0x22, 0x09, 0x26, 0x05, 0x66, 0x0d, 0xce, 0x20, 0x28, 0x4f,
-	0x49, 0x8b, 0x8b, 0x2f, 0x28, 0xb5, 0x38, 0x3f, 0xa7, 0x0c, 0xae, 0x52, 0x82, 0x8b, 0x3d, 0xb5,
-	0x24, 0x03, 0xac, 0x94, 0x51, 0x81, 0x51, 0x83, 0x33, 0x08, 0xc6, 0x55, 0x52, 0xe3, 0xe2, 0x81,
-	0xab, 0x2d, 0xc8, 0xa9, 0x44, 0x32, 0x93, 0x11, 0xd9, 0x4c, 0xa3, 0x22, 0x2e, 0x76, 0x1f, 0x88,
-	0x33, 0x85, 0x0c, 0xb8, 0x38, 0x60, 0x2e, 0x11, 0x12, 0xd5, 0x03, 0xb9, 0x52, 0x0f, 0xcd, 0x65,
-	0x52, 0xdc, 0x10, 0x61, 0xd7, 0xdc, 0x82, 0x92, 0x4a, 0x25, 0x06, 0x21, 0x53, 0x2e, 0x76, 0xa8,
-	0x25, 0x42, 0x22, 0x10, 0x19, 0x54, 0xf7, 0x49, 0x09, 0xa1, 0x89, 0x16, 0xe4, 0x54, 0x2a, 0x31,
-	0x24, 0xb1, 0x81, 0x7d, 0x6e, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0xde, 0xdf, 0xda, 0x6d, 0x21,
-	0x01, 0x00, 0x00,
+	// 810 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdf, 0x6e, 0xd3, 0x3e,
+	0x14, 0x6e, 0xfa, 0x67, 0x6d, 0xcf, 0xb6, 0xf6, 0x37, 0xff, 0xba, 0x91, 0x85, 0x09, 0x55, 0x16,
+	0x82, 0x5c, 0x05, 0x28, 0x42, 0x20, 0x2e, 0xa6, 0x0d, 0x6d, 0x42, 0x9a, 0x26, 0x34, 0x79, 0x15,
+	0xf7, 0x59, 0x62, 0xda, 0x88, 0xd4, 0x0e, 0x89, 0x33, 0x28, 0x2f, 0xb0, 0x67, 0xe1, 0x39, 0x78,
+	0x0d, 0x1e, 0x06, 0xc5, 0x4e, 0xd2, 0x24, 0x4b, 0xab, 0x89, 0xab, 0xd6, 0x9f, 0x8f, 0x8f, 0xbf,
+	0x73, 0xbe, 0xef, 0x38, 0xb0, 0xeb, 0x73, 0xc7, 0x16, 0x3c, 0xb4, 0x82, 0x90, 0x0b, 0x8e, 0xda,
+	0x11, 0x67, 0x0b, 0x63, 0xe8, 0xb1, 0xe4, 0x97, 0x79, 0xb6, 0x82, 0xf1, 0x9d, 0x06, 0xc3, 0x53,
+	0xc6, 0x78, 0xcc, 0x1c, 0x4a, 0xe8, 0xb7, 0x98, 0x46, 0x02, 0x1d, 0xc0, 0x96, 0x17, 0x9c, 0xba,
+	0x6e, 0xa8, 0x37, 0xc7, 0x2d, 0xb3, 0x4f, 0xd2, 0x15, 0x42, 0xd0, 0x16, 0xf6, 0x2c, 0xd2, 0x5b,
+	0x12, 0x95, 0xff, 0x91, 0x09, 0x43, 0x99, 0xc8, 0xe1, 0xfe, 0x67, 0x1a, 0x46, 0x1e, 0x67, 0x7a,
+	0x7b, 0xac, 0x99, 0xbb, 0xa4, 0x0a, 0xa3, 0x27, 0x00, 0x42, 0xf8, 0xd7, 0xd4, 0xe1, 0xcc, 0x8d,
+	0xf4, 0xce, 0x58, 0x33, 0xdb, 0xa4, 0x80, 0x60, 0x06, 0x03, 0x42, 0x23, 0xee, 0xdf, 0xe6, 0x3c,
+	0x74, 0xe8, 0x52, 0x31, 0x97, 0x44, 0xb4, 0xb1, 0x66, 0xf6, 0x49, 0xb6, 0xcc, 0x99, 0x34, 0x0b,
+	0x4c, 0x2c, 0x40, 0x0b, 0x8f, 0x5d, 0x55, 0xc8, 0xb4, 0x24, 0x99, 0x9a, 0x1d, 0x1c, 0xc0, 0x4e,
+	0x7e, 0x5f, 0xe0, 0x2f, 0x0b, 0x55, 0x6b, 0xa5, 0xaa, 0x4d, 0x18, 0x3a, 0xb6, 0x33, 0xa7, 0xd3,
+	0xe9, 0x65, 0x46, 0xbe, 0x29, 0xc9, 0x57, 0xe1, 0xa4, 0x42, 0x7b, 0x46, 0xb3, 0xa0, 0x96, 0xaa,
+	0x70, 0x85, 0xe0, 0x3f, 0x1a, 0x0c, 0xae, 0xbd, 0x19, 0xa3, 0x6e, 0xd6, 0xf1, 0x0d, 0x25, 0xae,
+	0x13, 0xe1, 0x08, 0xfa, 0xc2, 0x5b, 0xd0, 0x48, 0xd8, 0x8b, 0x40, 0xde, 0xd1, 0x22, 0x2b, 0x20,
+	0xd9, 0x8d, 0xbc, 0x19, 0xb3, 0x45, 0x1c, 0x52, 0x29, 0xc4, 0x0e, 0x59, 0x01, 0x79, 0xdb, 0x3a,
+	0x9b, 0x05, 0xdc, 0x7a, 0x88, 0x80, 0xdd, 0x7b, 0x02, 0x5e, 0xc0, 0x28, 0xab, 0xeb, 0x83, 0x2d,
+	0x9c, 0x79, 0x26, 0xe3, 0x04, 0xfa, 0x76, 0x8a, 0x47, 0xb2, 0xb7, 0xdb, 0x93, 0x91, 0x95, 0xb8,
+	0xd0, 0x2a, 0x37, 0x83, 0xac, 0xc2, 0xf0, 0x09, 0x0c, 0x72, 0x98, 0x46, 0xb1, 0xbf, 0xc9, 0x0c,
+	0x23, 0xe8, 0xd0, 0x30, 0xe4, 0xa1, 0x94, 0xa5, 0x4f, 0xd4, 0x02, 0x9f, 0x01, 0xaa, 0xb0, 0x49,
+	0x44, 0xb6, 0xa0, 0x1b, 0xca, 0x7c, 0x15, 0x26, 0xe5, 0xcb, 0x48, 0x16, 0x84, 0x2d, 0x18, 0xa5,
+	0x26, 0xb9, 0x0a, 0xe9, 0x17, 0xef, 0x47, 0x61, 0x44, 0x02, 0x09, 0xa4, 0x64, 0xd2, 0x15, 0x3e,
+	0xc9, 0x4d, 0xe5, 0x7e, 0xe2, 0xee, 0x3f, 0xe8, 0x8b, 0x7f, 0x02, 0xaa, 0xdc, 0x98, 0xf0, 0x36,
+	0xa1, 0xc3, 0xb8, 0x9b, 0xf7, 0x0f, 0x29, 0xd6, 0xc5, 0xab, 0x88, 0x0a, 0x48, 0x1c, 0x60, 0x2f,
+	0x6e, 0xbc, 0x59, 0xcc, 0x63, 0x65, 0xd4, 0x1e, 0x59, 0x01, 0xc9, 0xae, 0x08, 0x63, 0xe6, 0xd8,
+	0x82, 0xba, 0xd2, 0x3d, 0x3d, 0xb2, 0x02, 0xf0, 0x2b, 0xf8, 0x3f, 0x4d, 0x59, 0x12, 0xd0, 0x80,
+	0x5e, 0xca, 0x3a, 0x4a, 0x67, 0x23, 0x5f, 0xe3, 0x29, 0xa0, 0xf2, 0x11, 0x29, 0x96, 0x09, 0x9d,
+	0x30, 0xe1, 0x2d, 0x8b, 0xae, 0xd2, 0x95, 0x15, 0x11, 0x15, 0xb0, 0x46, 0xbc, 0x5f, 0x1a, 0xec,
+	0x95, 0xd3, 0x26, 0xb1, 0xc7, 0x55, 0xf1, 0x9e, 0x96, 0xf2, 0xae, 0x22, 0x2d, 0x45, 0x23, 0x3a,
+	0x67, 0x22, 0x5c, 0xe6, 0x62, 0x1a, 0x53, 0x29, 0x4e, 0xbe, 0x81, 0xfe, 0x83, 0xd6, 0x57, 0xba,
+	0x4c, 0x85, 0x49, 0xfe, 0x22, 0x0b, 0x3a, 0xb7, 0xb6, 0x1f, 0x53, 0xc9, 0x66, 0x7b, 0xa2, 0xd7,
+	0xe5, 0x97, 0x06, 0x51, 0x61, 0xef, 0x9b, 0xef, 0x34, 0xfc, 0x1c, 0xf6, 0x09, 0xbd, 0xa5, 0x61,
+	0x44, 0x2b, 0xcf, 0xd7, 0x00, 0x9a, 0x5e, 0x90, 0x66, 0x6f, 0x7a, 0x81, 0xea, 0x6e, 0x39, 0x30,
+	0xa9, 0x6a, 0x53, 0x77, 0x2f, 0xe0, 0x80, 0xd0, 0x80, 0x87, 0x82, 0x50, 0xdb, 0x99, 0xdb, 0x37,
+	0xfe, 0x03, 0xde, 0xc6, 0xa2, 0xb1, 0xb4, 0x82, 0xb1, 0x7e, 0x6b, 0xb0, 0x77, 0xa9, 0x3e, 0x09,
+	0xd7, 0xc2, 0x16, 0x11, 0xc9, 0xfa, 0x9f, 0x19, 0x2b, 0x99, 0x67, 0xb5, 0x40, 0x6f, 0x61, 0xc0,
+	0x7d, 0x97, 0x46, 0x22, 0x9b, 0x8b, 0xb4, 0x21, 0x43, 0xd5, 0x90, 0x69, 0xf6, 0xde, 0x90, 0x4a,
+	0x58, 0x72, 0x90, 0xd1, 0xef, 0xc5, 0x83, 0xad, 0x35, 0x07, 0xcb, 0x61, 0xe8, 0x19, 0x0c, 0x92,
+	0xab, 0x0b, 0x8f, 0x6c, 0x5b, 0x12, 0xaa, 0xa0, 0x93, 0xbb, 0x36, 0x74, 0xd3, 0x2a, 0xd0, 0x4b,
+	0xe8, 0xe5, 0xe7, 0xf7, 0xab, 0x73, 0x2c, 0xdb, 0x64, 0x6c, 0x2b, 0xf8, 0x7c, 0x11, 0x88, 0x25,
+	0x6e, 0xa0, 0x37, 0xd0, 0x4d, 0x7b, 0x8f, 0x46, 0x15, 0x4f, 0xaa, 0xf8, 0x1a, 0xa7, 0xe2, 0x06,
+	0xfa, 0x08, 0xbb, 0xa5, 0xb7, 0x04, 0x19, 0xe5, 0xdb, 0x8a, 0xd3, 0x62, 0xe8, 0xb5, 0x7b, 0x79,
+	0xa2, 0xd2, 0x70, 0x67, 0x89, 0xea, 0xde, 0x18, 0x43, 0xaf, 0xdd, 0x53, 0x89, 0xce, 0xf2, 0x77,
+	0x46, 0x11, 0x3a, 0xac, 0x73, 0xaa, 0x4a, 0xf3, 0x68, 0xcd, 0x90, 0xe0, 0x06, 0xba, 0x80, 0x41,
+	0xd9, 0x91, 0xe8, 0x71, 0x16, 0x5c, 0x63, 0x68, 0xe3, 0xb0, 0x7e, 0x53, 0xe5, 0x3a, 0x86, 0x61,
+	0xc5, 0xaa, 0xe8, 0x28, 0x8b, 0xaf, 0x73, 0x70, 0x55, 0x9a, 0x17, 0xd0, 0x91, 0xb6, 0x44, 0x45,
+	0x3c, 0x23, 0x7f, 0xcf, 0xb7, 0xb8, 0x71, 0xb3, 0x25, 0xbf, 0x4f, 0xaf, 0xff, 0x06, 0x00, 0x00,
+	0xff, 0xff, 0xe6, 0x7c, 0x17, 0x36, 0xe8, 0x08, 0x00, 0x00,
 }
@@ -91,6 +91,8 @@ type GetProcessingReply_ProcessedOrder struct {
 	Status    uint32     `protobuf:"varint,2,opt,name=status" json:"status,omitempty"`
 	Timestamp *Timestamp `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
 	Extra     string     `protobuf:"bytes,4,opt,name=extra" json:"extra,omitempty"`
+	// dealID is set when the order has resulted in a deal.
+	DealID string `protobuf:"bytes,5,opt,name=dealID" json:"dealID,omitempty"`
 }
 
 func (m *GetProcessingReply_ProcessedOrder) Reset()         { *m = GetProcessingReply_ProcessedOrder{} }
@@ -128,6 +130,13 @@ func (m *GetProcessingReply_ProcessedOrder) GetExtra() string {
 	return ""
 }
 
+func (m *GetProcessingReply_ProcessedOrder) GetDealID() string {
+	if m != nil {
+		return m.DealID
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*GetOrdersRequest)(nil), "sonm.GetOrdersRequest")
 	proto.RegisterType((*GetOrdersReply)(nil), "sonm.GetOrdersReply")
@@ -348,32 +357,33 @@ var _Market_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("marketplace.proto", fileDescriptor6) }
 
 var fileDescriptor6 = []byte{
-	// 421 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x8d, 0x9d, 0x34, 0x22, 0x63, 0x9a, 0x96, 0x51, 0x55, 0x59, 0x3e, 0xa0, 0xc8, 0x20, 0x35,
-	0x1c, 0xc8, 0xc1, 0x08, 0xa9, 0xe2, 0xe3, 0x02, 0xad, 0xaa, 0x1e, 0x50, 0xd1, 0xd2, 0x3f, 0xb0,
-	0x89, 0x47, 0xc8, 0xaa, 0xed, 0x35, 0xbb, 0x63, 0xc0, 0x07, 0x7e, 0x16, 0x7f, 0x0f, 0x21, 0xef,
-	0xda, 0xa9, 0xa3, 0x36, 0x37, 0xcf, 0x9b, 0xf7, 0x66, 0xfc, 0xde, 0x0e, 0x3c, 0x2b, 0xa4, 0xbe,
-	0x23, 0xae, 0x72, 0xb9, 0xa1, 0x55, 0xa5, 0x15, 0x2b, 0x9c, 0x18, 0x55, 0x16, 0xd1, 0x6c, 0x9d,
-	0xa5, 0x0e, 0x88, 0x8e, 0xb2, 0xb2, 0x85, 0xca, 0x4c, 0x3a, 0x20, 0xfe, 0x05, 0xc7, 0x57, 0xc4,
-	0x37, 0x3a, 0x25, 0x6d, 0x04, 0xfd, 0xa8, 0xc9, 0x30, 0x3e, 0x87, 0x89, 0xc9, 0x15, 0x87, 0xde,
-	0xc2, 0x5b, 0x06, 0x09, 0xac, 0x5a, 0xc5, 0xea, 0x5b, 0xae, 0x58, 0x58, 0x1c, 0x5f, 0xc3, 0x4c,
-	0xb5, 0x82, 0xdb, 0xa6, 0xa2, 0xd0, 0x5f, 0x78, 0xcb, 0x79, 0x72, 0xe4, 0x48, 0x37, 0x3d, 0x2c,
-	0xee, 0x19, 0x78, 0x02, 0x07, 0x1b, 0x55, 0x97, 0x1c, 0x8e, 0x17, 0xde, 0x72, 0x22, 0x5c, 0x11,
-	0xbf, 0x85, 0xf9, 0x60, 0x71, 0x95, 0x37, 0xf8, 0x02, 0xa6, 0x56, 0x64, 0x42, 0x6f, 0x31, 0x5e,
-	0x06, 0x49, 0x30, 0x98, 0x29, 0xba, 0x56, 0xfc, 0xd7, 0x07, 0xbc, 0x22, 0xfe, 0xaa, 0xd5, 0x86,
-	0x8c, 0xc9, 0xca, 0xef, 0x4e, 0xfb, 0x61, 0xab, 0xf5, 0xad, 0xf6, 0xa5, 0xd3, 0x3e, 0x64, 0xba,
-	0x71, 0xe6, 0xb2, 0x64, 0xdd, 0xf4, 0x43, 0xa3, 0x3f, 0x30, 0xef, 0x68, 0x94, 0xda, 0x3e, 0xce,
-	0xc1, 0xcf, 0x52, 0x1b, 0xc0, 0x4c, 0xf8, 0x59, 0x8a, 0xa7, 0x30, 0x35, 0x2c, 0xb9, 0x36, 0xd6,
-	0xef, 0xa1, 0xe8, 0xaa, 0x36, 0x0a, 0xce, 0x0a, 0x32, 0x2c, 0x8b, 0xca, 0xfa, 0x0b, 0xfa, 0x28,
-	0x6e, 0x7b, 0x58, 0xdc, 0x33, 0xda, 0x28, 0xe8, 0x37, 0x6b, 0x19, 0x4e, 0xec, 0x64, 0x57, 0x44,
-	0x6b, 0x08, 0x06, 0x7f, 0x85, 0xc7, 0x30, 0xbe, 0xa3, 0xa6, 0x5b, 0xde, 0x7e, 0xe2, 0x47, 0x38,
-	0xf8, 0x29, 0xf3, 0xda, 0x85, 0x1d, 0x24, 0x67, 0x7b, 0xcd, 0xed, 0xba, 0x10, 0x4e, 0xf5, 0xce,
-	0x3f, 0xf7, 0x92, 0x7f, 0x1e, 0x4c, 0xbf, 0xd8, 0xfb, 0xc0, 0xf7, 0x30, 0xdb, 0x26, 0x8f, 0xa7,
-	0xdb, 0x59, 0x3b, 0x37, 0x10, 0x9d, 0x3c, 0xc0, 0xab, 0xbc, 0x89, 0x47, 0x78, 0x06, 0x4f, 0x7b,
-	0xec, 0x53, 0x73, 0x7d, 0x81, 0x4f, 0x1c, 0xef, 0xfa, 0x22, 0x1a, 0x3e, 0x57, 0x3c, 0xc2, 0x57,
-	0x10, 0x7c, 0xd6, 0x24, 0x99, 0x5c, 0xa0, 0xc3, 0xee, 0x63, 0x54, 0x59, 0x6e, 0x28, 0xdf, 0x4f,
-	0xbd, 0x2c, 0x2a, 0x6e, 0xd7, 0x9f, 0xc3, 0xe1, 0x8e, 0x6d, 0x1c, 0xf6, 0xa3, 0x70, 0x5f, 0x30,
-	0xf1, 0x68, 0x3d, 0xb5, 0xf7, 0xfe, 0xe6, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x98, 0x5b, 0x4a,
-	0xe6, 0x26, 0x03, 0x00, 0x00,
+	// 433 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0x8d, 0x9d, 0x8b, 0xc8, 0x98, 0xa6, 0x65, 0x54, 0x55, 0x96, 0x1f, 0x50, 0x64, 0x90, 0x1a,
+	0x1e, 0xc8, 0x83, 0x11, 0x52, 0xc5, 0xe5, 0x05, 0x52, 0x55, 0x79, 0x40, 0x45, 0x4b, 0x7f, 0x60,
+	0x13, 0x8f, 0x90, 0x55, 0xdf, 0xf0, 0x8e, 0x01, 0x7f, 0x08, 0xbf, 0xc5, 0x27, 0x21, 0xb4, 0xbb,
+	0x76, 0xea, 0x28, 0xcd, 0x9b, 0xe7, 0xcc, 0x39, 0x67, 0x77, 0xce, 0xac, 0xe1, 0x59, 0x26, 0xab,
+	0x7b, 0xe2, 0x32, 0x95, 0x5b, 0x5a, 0x96, 0x55, 0xc1, 0x05, 0x8e, 0x54, 0x91, 0x67, 0xc1, 0x74,
+	0x93, 0xc4, 0x16, 0x08, 0x4e, 0x93, 0x5c, 0x43, 0x79, 0x22, 0x2d, 0x10, 0xfe, 0x82, 0xb3, 0x1b,
+	0xe2, 0xdb, 0x2a, 0xa6, 0x4a, 0x09, 0xfa, 0x51, 0x93, 0x62, 0x7c, 0x0e, 0x23, 0x95, 0x16, 0xec,
+	0x3b, 0x73, 0x67, 0xe1, 0x45, 0xb0, 0xd4, 0x8a, 0xe5, 0xb7, 0xb4, 0x60, 0x61, 0x70, 0x7c, 0x0d,
+	0xd3, 0x42, 0x0b, 0xee, 0x9a, 0x92, 0x7c, 0x77, 0xee, 0x2c, 0x66, 0xd1, 0xa9, 0x25, 0xdd, 0x76,
+	0xb0, 0x78, 0x60, 0xe0, 0x39, 0x8c, 0xb7, 0x45, 0x9d, 0xb3, 0x3f, 0x9c, 0x3b, 0x8b, 0x91, 0xb0,
+	0x45, 0xf8, 0x16, 0x66, 0xbd, 0x83, 0xcb, 0xb4, 0xc1, 0x17, 0x30, 0x31, 0x22, 0xe5, 0x3b, 0xf3,
+	0xe1, 0xc2, 0x8b, 0xbc, 0x9e, 0xa7, 0x68, 0x5b, 0xe1, 0x5f, 0x17, 0xf0, 0x86, 0xf8, 0x6b, 0x55,
+	0x6c, 0x49, 0xa9, 0x24, 0xff, 0x6e, 0xb5, 0x1f, 0x76, 0x5a, 0xd7, 0x68, 0x5f, 0x5a, 0xed, 0x21,
+	0xd3, 0xda, 0xa9, 0xeb, 0x9c, 0xab, 0xa6, 0x33, 0x0d, 0xfe, 0x38, 0x30, 0x6b, 0x79, 0x14, 0x1b,
+	0x02, 0xce, 0xc0, 0x4d, 0x62, 0x93, 0xc0, 0x54, 0xb8, 0x49, 0x8c, 0x17, 0x30, 0x51, 0x2c, 0xb9,
+	0x56, 0x66, 0xe0, 0x13, 0xd1, 0x56, 0x3a, 0x0b, 0x4e, 0x32, 0x52, 0x2c, 0xb3, 0xd2, 0x0c, 0xe8,
+	0x75, 0x59, 0xdc, 0x75, 0xb0, 0x78, 0x60, 0xe8, 0x2c, 0xe8, 0x37, 0x57, 0xd2, 0x1f, 0x19, 0x67,
+	0x5b, 0x68, 0xf3, 0x98, 0x64, 0xba, 0x5e, 0xf9, 0x63, 0x03, 0xb7, 0x55, 0xb0, 0x01, 0xaf, 0x77,
+	0x5d, 0x3c, 0x83, 0xe1, 0x3d, 0x35, 0xed, 0xa5, 0xf4, 0x27, 0x7e, 0x84, 0xf1, 0x4f, 0x99, 0xd6,
+	0x76, 0x0b, 0x5e, 0x74, 0x79, 0x74, 0xea, 0xfd, 0xe9, 0x84, 0x55, 0xbd, 0x73, 0xaf, 0x9c, 0xe8,
+	0x9f, 0x03, 0x93, 0x2f, 0xe6, 0xe1, 0xe0, 0x7b, 0x98, 0xee, 0x56, 0x82, 0x17, 0x3b, 0xaf, 0xbd,
+	0xc7, 0x11, 0x9c, 0x1f, 0xe0, 0x65, 0xda, 0x84, 0x03, 0xbc, 0x84, 0xa7, 0x1d, 0xf6, 0xa9, 0x59,
+	0xaf, 0xf0, 0x89, 0xe5, 0xad, 0x57, 0x41, 0x7f, 0x8f, 0xe1, 0x00, 0x5f, 0x81, 0xf7, 0xb9, 0x22,
+	0xc9, 0x64, 0x83, 0xee, 0x77, 0x1f, 0xa3, 0xca, 0x7c, 0x4b, 0xe9, 0x71, 0xea, 0x75, 0x56, 0xb2,
+	0x3e, 0xfe, 0x0a, 0x4e, 0xf6, 0xc6, 0xc6, 0x7e, 0x3f, 0xf0, 0x8f, 0x05, 0x13, 0x0e, 0x36, 0x13,
+	0xf3, 0x23, 0xbc, 0xf9, 0x1f, 0x00, 0x00, 0xff, 0xff, 0x02, 0x9a, 0x47, 0xd0, 0x3f, 0x03, 0x00,
+	0x00,
 }
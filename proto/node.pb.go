@@ -75,10 +75,36 @@ func (m *DealListReply) GetDeal() []*Deal {
 	return nil
 }
 
+type DealFindRequest struct {
+	// counterparty restricts the search to deals with the given address, any if empty
+	Counterparty string `protobuf:"bytes,1,opt,name=counterparty" json:"counterparty,omitempty"`
+	SpecHash     string `protobuf:"bytes,2,opt,name=specHash" json:"specHash,omitempty"`
+}
+
+func (m *DealFindRequest) Reset()                    { *m = DealFindRequest{} }
+func (m *DealFindRequest) String() string            { return proto.CompactTextString(m) }
+func (*DealFindRequest) ProtoMessage()               {}
+func (*DealFindRequest) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{3} }
+
+func (m *DealFindRequest) GetCounterparty() string {
+	if m != nil {
+		return m.Counterparty
+	}
+	return ""
+}
+
+func (m *DealFindRequest) GetSpecHash() string {
+	if m != nil {
+		return m.SpecHash
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*TaskListRequest)(nil), "sonm.TaskListRequest")
 	proto.RegisterType((*DealListRequest)(nil), "sonm.DealListRequest")
 	proto.RegisterType((*DealListReply)(nil), "sonm.DealListReply")
+	proto.RegisterType((*DealFindRequest)(nil), "sonm.DealFindRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -461,6 +487,8 @@ type DealManagementClient interface {
 	Status(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Deal, error)
 	// Finish finishes a deal with given ID
 	Finish(ctx context.Context, in *ID, opts ...grpc.CallOption) (*Empty, error)
+	// FindBySpecHash finds a pending or accepted deal by its specification hash
+	FindBySpecHash(ctx context.Context, in *DealFindRequest, opts ...grpc.CallOption) (*Deal, error)
 }
 
 type dealManagementClient struct {
@@ -498,6 +526,15 @@ func (c *dealManagementClient) Finish(ctx context.Context, in *ID, opts ...grpc.
 	return out, nil
 }
 
+func (c *dealManagementClient) FindBySpecHash(ctx context.Context, in *DealFindRequest, opts ...grpc.CallOption) (*Deal, error) {
+	out := new(Deal)
+	err := grpc.Invoke(ctx, "/sonm.DealManagement/FindBySpecHash", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for DealManagement service
 
 type DealManagementServer interface {
@@ -507,6 +544,8 @@ type DealManagementServer interface {
 	Status(context.Context, *ID) (*Deal, error)
 	// Finish finishes a deal with given ID
 	Finish(context.Context, *ID) (*Empty, error)
+	// FindBySpecHash finds a pending or accepted deal by its specification hash
+	FindBySpecHash(context.Context, *DealFindRequest) (*Deal, error)
 }
 
 func RegisterDealManagementServer(s *grpc.Server, srv DealManagementServer) {
@@ -567,6 +606,24 @@ func _DealManagement_Finish_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DealManagement_FindBySpecHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DealFindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DealManagementServer).FindBySpecHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DealManagement/FindBySpecHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DealManagementServer).FindBySpecHash(ctx, req.(*DealFindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DealManagement_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.DealManagement",
 	HandlerType: (*DealManagementServer)(nil),
@@ -583,6 +640,10 @@ var _DealManagement_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Finish",
 			Handler:    _DealManagement_Finish_Handler,
 		},
+		{
+			MethodName: "FindBySpecHash",
+			Handler:    _DealManagement_FindBySpecHash_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "node.proto",
@@ -1128,44 +1189,47 @@ var _HubManagement_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("node.proto", fileDescriptor8) }
 
 var fileDescriptor8 = []byte{
-	// 610 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x41, 0x4f, 0xdb, 0x4c,
-	0x10, 0x8d, 0xf9, 0x42, 0x04, 0x13, 0x92, 0xa0, 0x85, 0x4f, 0x50, 0x1f, 0x68, 0xea, 0x1e, 0xea,
-	0x0a, 0x91, 0x20, 0x17, 0xf5, 0xd6, 0x03, 0xc2, 0x05, 0x22, 0x51, 0x29, 0xb5, 0x2b, 0xf5, 0xec,
-	0x34, 0x53, 0xc7, 0x8a, 0xe3, 0x75, 0x77, 0xd7, 0x54, 0xfc, 0x86, 0xfe, 0xd0, 0xfe, 0x83, 0x9e,
-	0xab, 0xf5, 0x7a, 0x1d, 0xdb, 0x24, 0x52, 0x6f, 0x99, 0x99, 0x37, 0x33, 0xef, 0xbd, 0x71, 0x16,
-	0x20, 0xa1, 0x73, 0x1c, 0xa5, 0x8c, 0x0a, 0x4a, 0xda, 0x9c, 0x26, 0x2b, 0x13, 0xe6, 0x18, 0xc4,
-	0x2a, 0x63, 0x0e, 0xa2, 0x44, 0xe6, 0x92, 0x28, 0x28, 0x12, 0xfb, 0x8b, 0x6c, 0xa6, 0x7e, 0x5a,
-	0x6f, 0x60, 0xf0, 0x25, 0xe0, 0xcb, 0x87, 0x88, 0x0b, 0x0f, 0x7f, 0x64, 0xc8, 0x05, 0x39, 0x86,
-	0xdd, 0x45, 0x36, 0x9b, 0xb8, 0xa7, 0xc6, 0xd0, 0xb0, 0xf7, 0x3d, 0x15, 0x58, 0x9f, 0x61, 0xe0,
-	0x62, 0x10, 0x37, 0x80, 0xf4, 0x67, 0x82, 0x4c, 0x03, 0xf3, 0x80, 0xd8, 0xd0, 0xe1, 0x22, 0x10,
-	0x19, 0x3f, 0xdd, 0x19, 0x1a, 0x76, 0xdf, 0x39, 0x1c, 0xc9, 0xe5, 0x23, 0xd9, 0xec, 0xe7, 0x79,
-	0xaf, 0xa8, 0x5b, 0x63, 0xe8, 0xad, 0x47, 0xa6, 0xf1, 0x13, 0x39, 0x83, 0xb6, 0xa4, 0x7d, 0x6a,
-	0x0c, 0xff, 0xb3, 0xbb, 0x0e, 0xac, 0x1b, 0xbd, 0x3c, 0xef, 0xfc, 0xd9, 0x81, 0xbe, 0x64, 0xfb,
-	0x29, 0x48, 0x82, 0x10, 0x57, 0x98, 0x08, 0x72, 0x05, 0x6d, 0xd9, 0x4f, 0xfe, 0x57, 0xe0, 0x86,
-	0x16, 0xf3, 0xa8, 0x99, 0x4e, 0xe3, 0x27, 0xab, 0x45, 0x2e, 0x60, 0x6f, 0x9a, 0xf1, 0x85, 0x4c,
-	0x93, 0xae, 0x82, 0xdc, 0x2c, 0xb2, 0x64, 0x69, 0xf6, 0x55, 0x30, 0x65, 0x34, 0x64, 0xc8, 0xb9,
-	0xd5, 0xb2, 0x8d, 0x4b, 0x83, 0x7c, 0x80, 0x5d, 0x5f, 0x04, 0x4c, 0x90, 0x17, 0xaa, 0x7c, 0x9f,
-	0xcd, 0xf2, 0x58, 0xf6, 0xeb, 0x4d, 0x27, 0x9b, 0x4a, 0x6a, 0xdb, 0x18, 0x3a, 0x4a, 0x39, 0x39,
-	0x58, 0xd3, 0x99, 0xb8, 0x66, 0x85, 0x73, 0xe1, 0x4c, 0xd1, 0xf0, 0x1e, 0xda, 0x0f, 0x34, 0xe4,
-	0x35, 0x51, 0x34, 0xe4, 0x9b, 0x44, 0xd1, 0x90, 0xe7, 0xcc, 0xad, 0xd6, 0xa5, 0x41, 0x5e, 0x43,
-	0xdb, 0x17, 0x34, 0x6d, 0xac, 0x29, 0x04, 0x7e, 0x5c, 0xa5, 0x42, 0x0e, 0x77, 0xa4, 0xf6, 0x38,
-	0xce, 0xb5, 0x17, 0x0b, 0x74, 0xac, 0x17, 0x54, 0x2d, 0x91, 0x83, 0x9d, 0x5f, 0x06, 0xf4, 0xe5,
-	0x1d, 0xb6, 0x1b, 0xdf, 0xf8, 0x36, 0x34, 0xc7, 0xda, 0x7d, 0xad, 0x16, 0x19, 0x96, 0x56, 0xec,
-	0x29, 0xc0, 0xc4, 0x35, 0x2b, 0x77, 0xb6, 0x5a, 0xe4, 0x15, 0x74, 0x6e, 0xa3, 0x24, 0xe2, 0x8b,
-	0x0a, 0xa2, 0xae, 0xc0, 0xf9, 0xbd, 0x0b, 0xbd, 0xfb, 0x6c, 0x56, 0x21, 0x73, 0x51, 0x8e, 0xad,
-	0x42, 0xcd, 0xe3, 0xea, 0x4d, 0x2a, 0xfe, 0x5e, 0x40, 0xf7, 0x2b, 0x65, 0x4b, 0x64, 0x3c, 0x97,
-	0x50, 0xeb, 0x19, 0xa8, 0xa0, 0x4a, 0xfa, 0x1c, 0x0e, 0x14, 0xfc, 0x19, 0xf5, 0x02, 0x3c, 0x49,
-	0xbe, 0x53, 0x0d, 0xbe, 0x85, 0xe3, 0x3b, 0x14, 0x1e, 0x86, 0x11, 0x17, 0xc8, 0x70, 0x5e, 0x2c,
-	0xaa, 0x2f, 0x79, 0xa9, 0x82, 0x4d, 0x40, 0x3d, 0xe7, 0x2d, 0xf4, 0x75, 0x4d, 0x55, 0xb6, 0xfa,
-	0x41, 0xce, 0xe1, 0xd0, 0x45, 0xf6, 0x8f, 0xe0, 0x31, 0x80, 0x8b, 0x8f, 0xd1, 0x37, 0x7c, 0x2e,
-	0x9d, 0xe8, 0x43, 0xc8, 0x72, 0x49, 0xe4, 0x1a, 0x8e, 0xee, 0x50, 0xa8, 0xe4, 0x94, 0xd1, 0x14,
-	0x99, 0x88, 0xb0, 0x6a, 0xc2, 0x59, 0x29, 0xa6, 0x09, 0x5a, 0x7b, 0x72, 0xe4, 0x6f, 0x18, 0x31,
-	0x54, 0x8d, 0xfe, 0xa6, 0xc6, 0xda, 0x87, 0xa8, 0xb9, 0x8f, 0xa0, 0x7b, 0x87, 0xe2, 0x9a, 0x2f,
-	0xa7, 0x71, 0x90, 0x34, 0x2c, 0x2d, 0x9e, 0x19, 0x3f, 0xa6, 0xa2, 0xdc, 0x7b, 0x05, 0xbd, 0x1b,
-	0x86, 0x81, 0xc0, 0xa2, 0x85, 0x9c, 0xe8, 0x7b, 0x71, 0x64, 0x42, 0x42, 0xf5, 0xa2, 0x52, 0x8d,
-	0xd5, 0x22, 0x36, 0xf4, 0x3c, 0x5c, 0xd1, 0xc7, 0xb2, 0x6b, 0xab, 0x97, 0x23, 0xd8, 0xd3, 0x2f,
-	0x4b, 0x9d, 0xcc, 0x96, 0x67, 0x67, 0x0c, 0xb0, 0xfe, 0xb3, 0x57, 0xc6, 0x6e, 0x7b, 0x08, 0x66,
-	0x9d, 0xfc, 0x91, 0x7e, 0xf7, 0x37, 0x00, 0x00, 0xff, 0xff, 0xc7, 0x25, 0x75, 0x62, 0xe0, 0x05,
-	0x00, 0x00,
+	// 662 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4f, 0x6f, 0xda, 0x4a,
+	0x10, 0xc7, 0x79, 0x04, 0x91, 0x21, 0x40, 0xb4, 0xe4, 0x29, 0x79, 0x3e, 0xe4, 0x51, 0xf7, 0x50,
+	0xaa, 0x28, 0x10, 0xd1, 0xb4, 0xb7, 0x1e, 0xd2, 0xd0, 0x24, 0x48, 0xa9, 0x44, 0xed, 0x4a, 0x3d,
+	0x9b, 0x30, 0x05, 0x0b, 0xe3, 0x75, 0x77, 0xd7, 0xa9, 0xf8, 0x7a, 0xfd, 0x32, 0xfd, 0x06, 0x3d,
+	0x57, 0xeb, 0xf5, 0x9a, 0xb5, 0x03, 0x52, 0x6f, 0xcc, 0xec, 0x6f, 0xe6, 0xf7, 0xc7, 0xab, 0x05,
+	0x20, 0xa2, 0x33, 0xec, 0xc7, 0x8c, 0x0a, 0x4a, 0xaa, 0x9c, 0x46, 0x2b, 0x1b, 0x66, 0xe8, 0x87,
+	0xaa, 0x63, 0xb7, 0x83, 0x48, 0xf6, 0xa2, 0xc0, 0xcf, 0x1a, 0x07, 0x8b, 0x64, 0xaa, 0x7e, 0x3a,
+	0xaf, 0xa0, 0xfd, 0xc5, 0xe7, 0xcb, 0x87, 0x80, 0x0b, 0x17, 0xbf, 0x27, 0xc8, 0x05, 0x39, 0x86,
+	0xfd, 0x45, 0x32, 0x1d, 0x8f, 0x4e, 0xad, 0xae, 0xd5, 0x3b, 0x70, 0x55, 0xe1, 0x7c, 0x86, 0xf6,
+	0x08, 0xfd, 0xb0, 0x04, 0xa4, 0x3f, 0x22, 0x64, 0x1a, 0x98, 0x16, 0xa4, 0x07, 0x35, 0x2e, 0x7c,
+	0x91, 0xf0, 0xd3, 0xbd, 0xae, 0xd5, 0x6b, 0x0d, 0x8f, 0xfa, 0x92, 0xbc, 0x2f, 0x87, 0xbd, 0xb4,
+	0xef, 0x66, 0xe7, 0xce, 0x00, 0x9a, 0x9b, 0x95, 0x71, 0xb8, 0x26, 0x67, 0x50, 0x95, 0xb2, 0x4f,
+	0xad, 0xee, 0x3f, 0xbd, 0xc6, 0x10, 0x36, 0x83, 0x6e, 0xda, 0xd7, 0x1a, 0x6e, 0x83, 0x68, 0xa6,
+	0x35, 0x38, 0x70, 0xf8, 0x48, 0x93, 0x48, 0x20, 0x8b, 0x7d, 0x26, 0xd6, 0x99, 0x94, 0x42, 0x8f,
+	0xd8, 0x50, 0xe7, 0x31, 0x3e, 0xde, 0xfb, 0x7c, 0x91, 0x6a, 0x3a, 0x70, 0xf3, 0x7a, 0xf8, 0x7b,
+	0x0f, 0x5a, 0x32, 0x80, 0x4f, 0x7e, 0xe4, 0xcf, 0x71, 0x85, 0x91, 0x20, 0x57, 0x50, 0x95, 0x92,
+	0xc8, 0xbf, 0x8a, 0xbf, 0x14, 0x8f, 0xdd, 0x29, 0xb7, 0xe3, 0x70, 0xed, 0x54, 0xc8, 0x05, 0xd4,
+	0x27, 0x09, 0x5f, 0xc8, 0x36, 0x69, 0x28, 0xc8, 0xcd, 0x22, 0x89, 0x96, 0x76, 0x4b, 0x15, 0x13,
+	0x46, 0xe7, 0x0c, 0x39, 0x77, 0x2a, 0x3d, 0xeb, 0xd2, 0x22, 0xef, 0x61, 0xdf, 0x13, 0x3e, 0x13,
+	0xe4, 0x3f, 0x75, 0x7c, 0x9f, 0x4c, 0xd3, 0x5a, 0xce, 0x6b, 0xa6, 0x93, 0x6d, 0x47, 0x8a, 0x6d,
+	0x00, 0x35, 0x15, 0x26, 0x39, 0xdc, 0xc8, 0x19, 0x8f, 0x6c, 0x43, 0x73, 0x16, 0x76, 0x36, 0xf0,
+	0x0e, 0xaa, 0x0f, 0x74, 0xce, 0x0b, 0xa6, 0xe8, 0x9c, 0x6f, 0x33, 0x45, 0xe7, 0x3c, 0x55, 0xee,
+	0x54, 0x2e, 0x2d, 0xf2, 0x12, 0xaa, 0x9e, 0xa0, 0x71, 0x89, 0x26, 0x33, 0xf8, 0x71, 0x15, 0x0b,
+	0xb9, 0x7c, 0x28, 0xbd, 0x87, 0x61, 0xea, 0x3d, 0x23, 0xd0, 0xb5, 0x26, 0x30, 0x23, 0x91, 0x8b,
+	0x87, 0x3f, 0x2d, 0x68, 0xc9, 0x8f, 0xb9, 0x3b, 0xf8, 0xd2, 0x75, 0xb3, 0x3b, 0xe5, 0xb6, 0x72,
+	0xd6, 0xcd, 0xa3, 0xa8, 0x2b, 0xc0, 0x78, 0x64, 0x1b, 0x57, 0xc7, 0xa9, 0x90, 0x17, 0x50, 0xbb,
+	0x0d, 0xa2, 0x80, 0x2f, 0x0c, 0x44, 0xc9, 0xc1, 0x5b, 0x68, 0xc9, 0x5b, 0xf5, 0x61, 0xed, 0x65,
+	0x17, 0xc3, 0x14, 0x61, 0xdc, 0xb7, 0xe2, 0xe6, 0xe1, 0xaf, 0x7d, 0x68, 0xde, 0x27, 0x53, 0xc3,
+	0xc3, 0x45, 0xae, 0xc6, 0x64, 0xb0, 0x8f, 0xcd, 0x4f, 0x69, 0x7c, 0x96, 0x0b, 0x68, 0x7c, 0xa5,
+	0x6c, 0x89, 0x8c, 0xa7, 0xce, 0x0b, 0x33, 0x6d, 0x55, 0x98, 0x5e, 0xcf, 0xe1, 0x50, 0xc1, 0x9f,
+	0x39, 0xce, 0xc0, 0xe3, 0xe8, 0x1b, 0xd5, 0xe0, 0x5b, 0x38, 0xbe, 0x43, 0xe1, 0xe2, 0x3c, 0xe0,
+	0x02, 0x19, 0xce, 0x32, 0xa2, 0x22, 0xc9, 0xff, 0xaa, 0xd8, 0x06, 0xd4, 0x7b, 0x5e, 0x43, 0x4b,
+	0x9f, 0xa9, 0x93, 0xdd, 0x31, 0x9e, 0xc3, 0xd1, 0x08, 0xd9, 0x5f, 0x82, 0x07, 0x00, 0x23, 0x7c,
+	0x0a, 0x1e, 0xf1, 0xb9, 0x75, 0xa2, 0x53, 0x96, 0xc7, 0xb9, 0x90, 0x6b, 0xe8, 0xdc, 0xa1, 0x50,
+	0xcd, 0x09, 0xa3, 0x31, 0x32, 0x11, 0xa0, 0x19, 0xc2, 0x59, 0x6e, 0xa6, 0x0c, 0xda, 0x64, 0xd2,
+	0xf1, 0xb6, 0xac, 0xe8, 0xaa, 0x41, 0x6f, 0xdb, 0x60, 0xe1, 0xfe, 0x6a, 0xed, 0x7d, 0x68, 0xdc,
+	0xa1, 0xb8, 0xe6, 0xcb, 0x49, 0xe8, 0x47, 0xa5, 0x48, 0xb3, 0x07, 0xcf, 0x0b, 0xa9, 0xc8, 0x79,
+	0xaf, 0xa0, 0x79, 0xc3, 0xd0, 0x17, 0x98, 0x8d, 0x90, 0x13, 0xfd, 0xbd, 0x38, 0x32, 0x21, 0xa1,
+	0x9a, 0x28, 0x77, 0xe3, 0x54, 0x48, 0x0f, 0x9a, 0x2e, 0xae, 0xe8, 0x53, 0x3e, 0xb5, 0x33, 0xcb,
+	0x3e, 0xd4, 0xf5, 0x83, 0x54, 0x14, 0xb3, 0xe3, 0xb5, 0x1a, 0x00, 0x6c, 0xde, 0x08, 0x63, 0xed,
+	0xae, 0xf7, 0x63, 0x5a, 0x4b, 0xff, 0x2e, 0xde, 0xfc, 0x09, 0x00, 0x00, 0xff, 0xff, 0x84, 0xff,
+	0x34, 0x26, 0x6a, 0x06, 0x00, 0x00,
 }